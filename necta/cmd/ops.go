@@ -0,0 +1,57 @@
+/*
+Copyright © 2024 NAME HERE <EMAIL ADDRESS>
+*/
+package cmd
+
+import (
+	"os"
+
+	application "github.com/edward1christian/block-forge/pkg/application"
+	"github.com/edward1christian/block-forge/pkg/application/component"
+	"github.com/edward1christian/block-forge/pkg/application/system"
+	"github.com/spf13/cobra"
+)
+
+// opsCmd represents the ops command
+var opsCmd = &cobra.Command{
+	Use:   "ops",
+	Short: "Inspect the operations available in the system",
+	Long:  `Inspect the operations and services available in the system`,
+}
+
+// opsListCmd represents the ops list command
+var opsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all operations and services",
+	Long: `List all operations and services with their owning plugin, input schema and labels.
+The system is created from the application configuration file, with the plugins found in the
+plugin paths. Use --selector to list only the entries whose labels match a selector, such as "role=command".`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		selector, err := component.ParseSelector(opsSelector)
+		if err != nil {
+			return err
+		}
+		sys, err := application.NewSystem(&application.InitOptions{
+			ConfigFilePath: opsConfigFile,
+			PluginPaths:    opsPluginPaths,
+		})
+		if err != nil {
+			return err
+		}
+		return system.WriteCatalog(os.Stdout, system.FilterCatalog(sys.Catalog(), selector))
+	},
+}
+
+var (
+	opsSelector    string   // Label selector of the ops list command
+	opsConfigFile  string   // Path of the application configuration file
+	opsPluginPaths []string // Paths in which plugin manifests are discovered
+)
+
+func init() {
+	rootCmd.AddCommand(opsCmd)
+	opsCmd.AddCommand(opsListCmd)
+	opsListCmd.Flags().StringVarP(&opsSelector, "selector", "l", "", "Label selector, such as plugin=nova,role=command")
+	opsListCmd.Flags().StringVar(&opsConfigFile, "app-config", "", "Path to the application configuration file")
+	opsListCmd.Flags().StringSliceVar(&opsPluginPaths, "plugin-path", nil, "Path in which plugin manifests are discovered, may be repeated")
+}
@@ -15,7 +15,7 @@ var moduleCmd = &cobra.Command{
 	Short: "Add a new module node to the configuration",
 	Long:  `Add a new module node to the configuration`,
 	Run: func(cmd *cobra.Command, args []string) {
-		provider.Init(&provider.InitOptions{
+		initNova(&provider.InitOptions{
 			Debug:   debug,
 			Command: plugin.AddModuleOp,
 		})
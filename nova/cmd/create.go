@@ -30,7 +30,7 @@ var createCmd = &cobra.Command{
 		}
 
 		// Pass InitOptions to your main application API
-		provider.Init(&provider.InitOptions{
+		initNova(&provider.InitOptions{
 			Debug:   debug,
 			Daemon:  daemon,
 			Verbose: verbose,
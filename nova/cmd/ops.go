@@ -0,0 +1,45 @@
+/*
+Copyright © 2024 Edward Banfa <ebanfa@gmail.com>
+*/
+package cmd
+
+import (
+	provider "github.com/edward1christian/block-forge/nova/pkg"
+	"github.com/edward1christian/block-forge/nova/pkg/components/plugin"
+	"github.com/spf13/cobra"
+)
+
+// opsCmd represents the ops command
+var opsCmd = &cobra.Command{
+	Use:   "ops",
+	Short: "Inspect the operations available in the system",
+	Long:  `Inspect the operations and services available in the system`,
+	Run: func(cmd *cobra.Command, args []string) {
+	},
+}
+
+// opsListCmd represents the ops list command
+var opsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all operations and services",
+	Long: `List all operations and services with their owning plugin, input schema and labels.
+Use --selector to list only the entries whose labels match a selector, such as "role=command".`,
+	Run: func(cmd *cobra.Command, args []string) {
+		initNova(&provider.InitOptions{
+			Debug:   debug,
+			Daemon:  daemon,
+			Verbose: verbose,
+			Command: plugin.ListOperationsOp,
+			Data:    opsSelector,
+		})
+	},
+}
+
+// opsSelector is the label selector of the ops list command
+var opsSelector string
+
+func init() {
+	rootCmd.AddCommand(opsCmd)
+	opsCmd.AddCommand(opsListCmd)
+	opsListCmd.Flags().StringVarP(&opsSelector, "selector", "l", "", "Label selector, such as plugin=nova,role=command")
+}
@@ -0,0 +1,27 @@
+/*
+Copyright © 2024 Edward Banfa <ebanfa@gmail.com>
+*/
+package cmd
+
+import (
+	provider "github.com/edward1christian/block-forge/nova/pkg"
+	"github.com/spf13/cobra"
+)
+
+var (
+	remotePlugins        []string
+	allowUnsignedPlugins bool
+)
+
+// addPluginFlags defines the flags selecting the plugin bundles to load on the given command and its subcommands.
+func addPluginFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringSliceVar(&remotePlugins, "plugin", nil, "URL or path of a plugin bundle to load, may be repeated")
+	cmd.PersistentFlags().BoolVar(&allowUnsignedPlugins, "allow-unsigned-plugins", false, "Load plugin bundles that are not signed by a trusted key")
+}
+
+// initNova initializes Nova with the given options and the plugin bundles selected on the command line.
+func initNova(options *provider.InitOptions) {
+	options.RemotePlugins = remotePlugins
+	options.AllowUnsignedPlugins = allowUnsignedPlugins
+	provider.Init(options)
+}
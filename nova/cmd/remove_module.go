@@ -15,7 +15,7 @@ var removeModuleCmd = &cobra.Command{
 	Short: "Remove an existing module node from the configuration",
 	Long:  `Remove an existing module node from the configuration`,
 	Run: func(cmd *cobra.Command, args []string) {
-		provider.Init(&provider.InitOptions{
+		initNova(&provider.InitOptions{
 			Debug:   debug,
 			Command: plugin.RemoveModuleOp,
 		})
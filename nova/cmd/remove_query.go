@@ -15,7 +15,7 @@ var removeQueryCmd = &cobra.Command{
 	Short: "Remove an existing query from the configuration",
 	Long:  `Remove an existing query from the configuration`,
 	Run: func(cmd *cobra.Command, args []string) {
-		provider.Init(&provider.InitOptions{
+		initNova(&provider.InitOptions{
 			Debug:   debug,
 			Command: plugin.RemoveQueryOp,
 		})
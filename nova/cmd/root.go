@@ -47,6 +47,7 @@ func init() {
 	rootCmd.PersistentFlags().BoolVar(&daemon, "daemon", false, "Run in daemon mode")
 	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "Enable debug mode for troubleshooting")
 	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "Enable verbose mode for detailed output")
+	addPluginFlags(rootCmd)
 
 	// Add flags for help and version
 	rootCmd.Flags().BoolP("help", "h", false, "Show this help message and exit")
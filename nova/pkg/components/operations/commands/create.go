@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/asaskevich/govalidator"
 	novaConfigApi "github.com/edward1christian/block-forge/nova/pkg/config"
 	"github.com/edward1christian/block-forge/nova/pkg/store"
 	"github.com/edward1christian/block-forge/pkg/application/common/context"
@@ -47,14 +48,30 @@ func NewCreateConfigurationOp(id, name, description string) *CreateConfiguration
 	}
 }
 
+// InputSchema returns the schema of the input data, the name of the project to create.
+func (bo *CreateConfigurationOp) InputSchema() *system.OperationSchema {
+	return system.NewOperationSchema[string]("Name of the project to create").
+		WithConstraint(func(data interface{}) error {
+			if !govalidator.IsAlphanumeric(data.(string)) {
+				return errors.New("project name must be alphanumeric")
+			}
+			return nil
+		})
+}
+
+// OutputSchema returns nil as the output data is not validated.
+func (bo *CreateConfigurationOp) OutputSchema() *system.OperationSchema {
+	return nil
+}
+
 // Execute performs the operation with the given context and input parameters,
 // and returns any output or error encountered.
 func (bo *CreateConfigurationOp) Execute(ctx *context.Context, input *system.SystemOperationInput) (*system.SystemOperationOutput, error) {
 
 	// Check if input data is in the expected format
-	projectName, ok := input.Data.(string)
-	if !ok {
-		return nil, errors.New("failed to create project. Invalid input data")
+	projectName, err := system.InputAs[string](input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create project. %w", err)
 	}
 
 	multiStore := bo.System.MultiStore()
@@ -66,7 +83,7 @@ func (bo *CreateConfigurationOp) Execute(ctx *context.Context, input *system.Sys
 		return nil, errors.New("failed to create project. Invalid configuration")
 	}
 
-	_, err := bo.CreateProjectMetadataEntry(projectName, novaConfig, multiStore)
+	_, err = bo.CreateProjectMetadataEntry(projectName, novaConfig, multiStore)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create project metadata entry for project %s. %w", projectName, err)
 	}
@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"io"
+	"os"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	"github.com/edward1christian/block-forge/pkg/application/component"
+	configApi "github.com/edward1christian/block-forge/pkg/application/config"
+	"github.com/edward1christian/block-forge/pkg/application/system"
+)
+
+// ListOperationsOpFactory is responsible for creating instances of ListOperationsOp.
+type ListOperationsOpFactory struct {
+}
+
+// CreateComponent creates a new instance of ListOperationsOp.
+func (bf *ListOperationsOpFactory) CreateComponent(config *configApi.ComponentConfig) (component.ComponentInterface, error) {
+	return NewListOperationsOp(config.ID, config.Name, config.Description), nil
+}
+
+// ListOperationsOp prints the catalog of the operations and services registered with the system.
+// The input data may hold a label selector restricting the listed entries, such as "role=command".
+type ListOperationsOp struct {
+	system.BaseSystemOperation
+	Out io.Writer // Writer the catalog is printed to
+}
+
+// Type returns the type of the component.
+func (bo *ListOperationsOp) Type() component.ComponentType {
+	return component.OperationType
+}
+
+func NewListOperationsOp(id, name, description string) *ListOperationsOp {
+	return &ListOperationsOp{
+		BaseSystemOperation: system.BaseSystemOperation{
+			BaseSystemComponent: system.BaseSystemComponent{
+				BaseComponent: component.BaseComponent{
+					Id:   id,
+					Nm:   name,
+					Desc: description,
+				},
+			},
+		},
+		Out: os.Stdout,
+	}
+}
+
+// Execute performs the operation with the given context and input parameters,
+// and returns any output or error encountered.
+func (bo *ListOperationsOp) Execute(ctx *context.Context,
+	input *system.SystemOperationInput) (*system.SystemOperationOutput, error) {
+
+	catalog := bo.System.Catalog()
+
+	// Restrict the catalog to the entries matching the selector, if any
+	if input != nil {
+		if expression, ok := input.Data.(string); ok && expression != "" {
+			selector, err := component.ParseSelector(expression)
+			if err != nil {
+				return nil, err
+			}
+			catalog = system.FilterCatalog(catalog, selector)
+		}
+	}
+	if err := system.WriteCatalog(bo.Out, catalog); err != nil {
+		return nil, err
+	}
+
+	return &system.SystemOperationOutput{
+		Data: catalog,
+	}, nil
+}
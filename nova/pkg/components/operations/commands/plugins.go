@@ -0,0 +1,122 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	"github.com/edward1christian/block-forge/pkg/application/component"
+	configApi "github.com/edward1christian/block-forge/pkg/application/config"
+	"github.com/edward1christian/block-forge/pkg/application/system"
+)
+
+// ReloadPluginOpFactory is responsible for creating instances of ReloadPluginOp.
+type ReloadPluginOpFactory struct {
+}
+
+// CreateComponent creates a new instance of ReloadPluginOp.
+func (bf *ReloadPluginOpFactory) CreateComponent(config *configApi.ComponentConfig) (component.ComponentInterface, error) {
+	return NewReloadPluginOp(config.ID, config.Name, config.Description), nil
+}
+
+// ReloadPluginOp reloads the plugin whose ID is given as input data from its manifest,
+// without restarting the system.
+type ReloadPluginOp struct {
+	system.BaseSystemOperation
+}
+
+// Type returns the type of the component.
+func (bo *ReloadPluginOp) Type() component.ComponentType {
+	return component.OperationType
+}
+
+func NewReloadPluginOp(id, name, description string) *ReloadPluginOp {
+	return &ReloadPluginOp{
+		BaseSystemOperation: system.BaseSystemOperation{
+			BaseSystemComponent: system.BaseSystemComponent{
+				BaseComponent: component.BaseComponent{
+					Id:   id,
+					Nm:   name,
+					Desc: description,
+				},
+			},
+		},
+	}
+}
+
+// Execute reloads the plugin and returns its ID.
+func (bo *ReloadPluginOp) Execute(ctx *context.Context,
+	input *system.SystemOperationInput) (*system.SystemOperationOutput, error) {
+
+	id, err := pluginIDInput(input)
+	if err != nil {
+		return nil, err
+	}
+	if err := bo.System.PluginManager().ReloadPlugin(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return &system.SystemOperationOutput{
+		Data: id,
+	}, nil
+}
+
+// UnloadPluginOpFactory is responsible for creating instances of UnloadPluginOp.
+type UnloadPluginOpFactory struct {
+}
+
+// CreateComponent creates a new instance of UnloadPluginOp.
+func (bf *UnloadPluginOpFactory) CreateComponent(config *configApi.ComponentConfig) (component.ComponentInterface, error) {
+	return NewUnloadPluginOp(config.ID, config.Name, config.Description), nil
+}
+
+// UnloadPluginOp stops the plugin whose ID is given as input data and unregisters
+// the components it contributed.
+type UnloadPluginOp struct {
+	system.BaseSystemOperation
+}
+
+// Type returns the type of the component.
+func (bo *UnloadPluginOp) Type() component.ComponentType {
+	return component.OperationType
+}
+
+func NewUnloadPluginOp(id, name, description string) *UnloadPluginOp {
+	return &UnloadPluginOp{
+		BaseSystemOperation: system.BaseSystemOperation{
+			BaseSystemComponent: system.BaseSystemComponent{
+				BaseComponent: component.BaseComponent{
+					Id:   id,
+					Nm:   name,
+					Desc: description,
+				},
+			},
+		},
+	}
+}
+
+// Execute unloads the plugin and returns its ID.
+func (bo *UnloadPluginOp) Execute(ctx *context.Context,
+	input *system.SystemOperationInput) (*system.SystemOperationOutput, error) {
+
+	id, err := pluginIDInput(input)
+	if err != nil {
+		return nil, err
+	}
+	if err := bo.System.PluginManager().UnloadPlugin(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return &system.SystemOperationOutput{
+		Data: id,
+	}, nil
+}
+
+// pluginIDInput returns the plugin ID held by the input data.
+func pluginIDInput(input *system.SystemOperationInput) (string, error) {
+	if input != nil {
+		if id, ok := input.Data.(string); ok && id != "" {
+			return id, nil
+		}
+	}
+	return "", fmt.Errorf("%w: expected the ID of a plugin", system.ErrInvalidOperationInput)
+}
@@ -1,7 +1,6 @@
 package operations
 
 import (
-	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -49,12 +48,22 @@ func NewInitDirectoriesOperation(id, name, description string) *InitDirectoriesO
 	}
 }
 
+// InputSchema returns the schema of the input data, the home directory of the user.
+func (op *InitDirectoriesOperation) InputSchema() *system.OperationSchema {
+	return system.NewOperationSchema[string]("Home directory in which the .nova directory is created")
+}
+
+// OutputSchema returns nil as the operation does not return any output.
+func (op *InitDirectoriesOperation) OutputSchema() *system.OperationSchema {
+	return nil
+}
+
 // Execute performs the operation to initialize directories.
 func (op *InitDirectoriesOperation) Execute(ctx *context.Context, input *system.SystemOperationInput) (*system.SystemOperationOutput, error) {
 	// Extract the home directory from the input
-	homeDir, ok := input.Data.(string)
-	if !ok {
-		return nil, errors.New("invalid home directory provided in input data")
+	homeDir, err := system.InputAs[string](input)
+	if err != nil {
+		return nil, fmt.Errorf("invalid home directory provided in input data: %w", err)
 	}
 
 	// Define the .nova directory path
@@ -66,7 +75,7 @@ func (op *InitDirectoriesOperation) Execute(ctx *context.Context, input *system.
 	}
 
 	// Create subdirectories within the .nova directory
-	subdirectories := []string{"databases", "configs", "logs", "cache"}
+	subdirectories := []string{"databases", "configs", "logs", "cache", "plugins"}
 	for _, subdir := range subdirectories {
 		dirPath := filepath.Join(novaDir, subdir)
 		if err := createDirectory(dirPath); err != nil {
@@ -6,6 +6,9 @@ const (
 	CreateConfigurationOp = "CreateConfigurationOp"
 	GenerateArtifactsOp   = "GenerateArtifactsOp"
 	ListConfigurationsOp  = "ListConfigurationsOp"
+	ListOperationsOp      = "ListOperationsOp"
+	ReloadPluginOp        = "ReloadPluginOp"
+	UnloadPluginOp        = "UnloadPluginOp"
 	AddEntityOp           = "AddEntityOp"
 	AddMessageOp          = "AddMessageOp"
 	AddModuleOp           = "AddModuleOp"
@@ -21,3 +24,18 @@ const (
 	// Startup operations
 	InitDirectoriesOperation = "InitDirectoriesOperation"
 )
+
+const (
+	// PluginLabelValue is the value of the plugin label of the components registered by Nova.
+	PluginLabelValue = "nova"
+
+	// Component roles, set as the role label of the components registered by Nova
+	CommandRole = "command"
+	StartupRole = "startup"
+	ServiceRole = "service"
+)
+
+// operationRoles holds the role of the operations that are not commands, by operation ID.
+var operationRoles = map[string]string{
+	InitDirectoriesOperation: StartupRole,
+}
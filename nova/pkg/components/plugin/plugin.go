@@ -1,7 +1,6 @@
 package plugin
 
 import (
-	"errors"
 	"fmt"
 
 	"github.com/edward1christian/block-forge/nova/pkg/common"
@@ -11,6 +10,14 @@ import (
 	systemApi "github.com/edward1christian/block-forge/pkg/application/system"
 )
 
+const (
+	// PluginVersion is the version of the Nova plugin, on which plugins extending Nova may depend.
+	PluginVersion = "0.1.0"
+
+	// CoreAPIVersion is the range of the core API versions supported by the Nova plugin.
+	CoreAPIVersion = "^1.0"
+)
+
 // NovaPlugin represents a plugin in the system.
 type NovaPlugin struct {
 	systemApi.BaseSystemComponent
@@ -27,6 +34,17 @@ func NewNovaPlugin() systemApi.PluginInterface {
 	}
 }
 
+// Manifest returns the manifest of the plugin, declaring its version and the core API it requires.
+func (p *NovaPlugin) Manifest() *systemApi.PluginManifest {
+	return &systemApi.PluginManifest{
+		ID:             p.ID(),
+		Name:           "Nova",
+		Description:    "Blockchain project scaffolding and configuration management",
+		Version:        PluginVersion,
+		CoreAPIVersion: CoreAPIVersion,
+	}
+}
+
 // Initialize initializes the module.
 // Returns an error if the initialization fails.
 func (p *NovaPlugin) Initialize(ctx *context.Context, system systemApi.SystemInterface) error {
@@ -73,15 +91,10 @@ func (p *NovaPlugin) Start(ctx *context.Context) error {
 // Returns an error if the stop operation fails.
 func (p *NovaPlugin) Stop(ctx *context.Context) error {
 	// Retrieve the BuildService component from the ComponentRegistry
-	component, err := p.System.ComponentRegistry().GetComponent(common.BuildService)
+	buildService, err := component.GetComponentAs[systemApi.SystemServiceInterface](
+		p.System.ComponentRegistry(), common.BuildService)
 	if err != nil {
-		return fmt.Errorf("failed to get BuildService component: %v", err)
-	}
-
-	// Check if the retrieved component implements the SystemServiceInterface
-	buildService, ok := component.(systemApi.SystemServiceInterface)
-	if !ok {
-		return errors.New("BuildService component does not implement SystemServiceInterface")
+		return fmt.Errorf("failed to get BuildService component: %w", err)
 	}
 
 	// Stop the BuildService
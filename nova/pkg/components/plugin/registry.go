@@ -14,6 +14,7 @@ type ComponentRegistration struct {
 	ID        string
 	FactoryID string
 	Factory   component.ComponentFactoryInterface
+	Labels    map[string]string // Labels of the component, in addition to the plugin label
 }
 
 // RegisterComponents registers all components returned by GetComponentsToRegister
@@ -37,6 +38,10 @@ func getListOfComponentsToRegister(ctx *context.Context, system systemApi.System
 		config := &configApi.ComponentConfig{
 			ID:        comp.ID,
 			FactoryID: comp.FactoryID,
+			Labels:    map[string]string{component.PluginLabel: PluginLabelValue},
+		}
+		for key, value := range comp.Labels {
+			config.Labels[key] = value
 		}
 		if err := systemApi.RegisterComponent(ctx, system, config, comp.Factory); err != nil {
 			return err
@@ -51,7 +56,7 @@ func GetServicesToRegister() []ComponentRegistration {
 		//"BuildProjectOp":      &commands.BuildProjectOpFactory{},
 	}
 
-	return populateComponentRegistrations(serviceFactories)
+	return populateComponentRegistrations(serviceFactories, ServiceRole)
 }
 
 // GetOperationsToRegister returns the list of operations to register
@@ -61,6 +66,9 @@ func GetOperationsToRegister() []ComponentRegistration {
 		"CreateConfigurationOp":    &commands.CreateConfigurationOpFactory{},
 		"GenerateArtifactsOp":      &commands.GenerateArtifactsOpFactory{},
 		"ListConfigurationsOp":     &commands.ListConfigurationsOpFactory{},
+		"ListOperationsOp":         &commands.ListOperationsOpFactory{},
+		"ReloadPluginOp":           &commands.ReloadPluginOpFactory{},
+		"UnloadPluginOp":           &commands.UnloadPluginOpFactory{},
 		"AddEntityOp":              &commands.AddEntityOpFactory{},
 		"AddMessageOp":             &commands.AddMessageOpFactory{},
 		"AddModuleOp":              &commands.AddModuleOpFactory{},
@@ -76,7 +84,7 @@ func GetOperationsToRegister() []ComponentRegistration {
 		"InitDirectoriesOperation": &operations.InitDirectoriesOperationFactory{},
 	}
 
-	return populateComponentRegistrations(operationFactories)
+	return populateComponentRegistrations(operationFactories, CommandRole)
 }
 
 // GetNormalComponentsToRegister returns the list of normal components to register
@@ -85,11 +93,12 @@ func GetNormalComponentsToRegister() []ComponentRegistration {
 		// Add normal components here
 	}
 
-	return populateComponentRegistrations(normalComponentFactories)
+	return populateComponentRegistrations(normalComponentFactories, "")
 }
 
-// PopulateComponentRegistrations iterates over the given map of components and populates the component registrations
-func populateComponentRegistrations(componentMap map[string]component.ComponentFactoryInterface) []ComponentRegistration {
+// PopulateComponentRegistrations iterates over the given map of components and populates the component registrations.
+// The components are labeled with the given role, unless operationRoles holds another one.
+func populateComponentRegistrations(componentMap map[string]component.ComponentFactoryInterface, role string) []ComponentRegistration {
 	var registrations []ComponentRegistration
 
 	for componentName, factory := range componentMap {
@@ -101,8 +110,21 @@ func populateComponentRegistrations(componentMap map[string]component.ComponentF
 			ID:        componentName,
 			FactoryID: factoryID,
 			Factory:   factory,
+			Labels:    componentRoleLabels(componentName, role),
 		})
 	}
 
 	return registrations
 }
+
+// componentRoleLabels returns the role label of the component with the given ID,
+// or nil if the component has no role.
+func componentRoleLabels(id, role string) map[string]string {
+	if operationRole, ok := operationRoles[id]; ok {
+		role = operationRole
+	}
+	if role == "" {
+		return nil
+	}
+	return map[string]string{component.RoleLabel: role}
+}
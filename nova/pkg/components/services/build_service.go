@@ -1,7 +1,9 @@
 package services
 
 import (
+	"errors"
 	"fmt"
+	"sync"
 
 	ncApi "github.com/edward1christian/block-forge/nova/pkg/common"
 	"github.com/edward1christian/block-forge/nova/pkg/components/common"
@@ -24,6 +26,9 @@ func (bf *BuildServiceFactory) CreateComponent(config *configApi.ComponentConfig
 // BuildService represents a service for managing build pipelines.
 type BuildService struct {
 	systemApi.BaseSystemService // Embedding BaseComponent
+	mutex                       sync.RWMutex
+	running                     bool  // Whether the service has been started
+	lastErr                     error // Last error encountered while running the pipeline
 }
 
 // NewBuildService creates a new instance of BuildService.
@@ -64,11 +69,41 @@ func (bs *BuildService) Start(ctx *context.Context) error {
 		FactoryID: ncApi.BuildPipelineFactory,
 	})
 	if err != nil {
+		bs.setState(false, err)
 		return fmt.Errorf("failed to start pipeline: %w", err)
 	}
+	bs.setState(true, nil)
 
 	// Start the pipeline
-	return pipeline.Execute(ctx, &systemApi.SystemOperationInput{})
+	if err := pipeline.Execute(ctx, &systemApi.SystemOperationInput{}); err != nil {
+		bs.setState(true, err)
+		return err
+	}
+	return nil
+}
+
+// Health returns the current health of the BuildService.
+// The service is live once started and ready as long as the pipeline has not failed.
+func (bs *BuildService) Health(ctx *context.Context) component.HealthStatus {
+	bs.mutex.RLock()
+	defer bs.mutex.RUnlock()
+
+	switch {
+	case !bs.running:
+		return component.HealthStatus{Reason: "build service not started"}
+	case bs.lastErr != nil:
+		return component.HealthStatus{Live: true, Reason: fmt.Sprintf("build pipeline failed: %v", bs.lastErr)}
+	default:
+		return component.HealthStatus{Live: true, Ready: true, Reason: "build service running"}
+	}
+}
+
+// setState records whether the service is running and the last error encountered.
+func (bs *BuildService) setState(running bool, err error) {
+	bs.mutex.Lock()
+	defer bs.mutex.Unlock()
+	bs.running = running
+	bs.lastErr = err
 }
 
 // createPipeline creates a new instance of the pipeline builder.
@@ -79,11 +114,10 @@ func (bs *BuildService) createPipeline(ctx *context.Context, config *configApi.C
 	if err != nil {
 		return nil, fmt.Errorf("failed to create pipeline: %w", err)
 	}
-	// Check if the created component is a system service interface
-	pipeline, ok := pipelineComp.(common.PipelineInterface)
-	if !ok {
-		// Return an error if the created component is not a system service
-		return nil, fmt.Errorf("instantiated pipeline (%s) is not a system service", pipelineComp.ID())
+	// Check if the created component is a pipeline
+	pipeline, err := component.ComponentAs[common.PipelineInterface](pipelineComp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pipeline: %w", err)
 	}
 	// Initialize the pipeline
 	err = pipeline.Initialize(ctx, bs.System)
@@ -96,15 +130,19 @@ func (bs *BuildService) createPipeline(ctx *context.Context, config *configApi.C
 }
 
 // Stop stops the BuildService.
-// It removes the pipeline component registration and unregisters the associated factory.
+// It removes the pipeline component registration, if the pipeline was created,
+// and unregisters the associated factory.
 func (bs *BuildService) Stop(ctx *context.Context) error {
+	bs.setState(false, nil)
+
 	// Remove the pipeline component registration
-	if err := bs.System.ComponentRegistry().RemoveComponent(ctx, "pipeline"); err != nil {
+	err := bs.System.ComponentRegistry().RemoveComponent(ctx, ncApi.BuildPipeline)
+	if err != nil && !errors.Is(err, component.ErrComponentNotFound) {
 		return fmt.Errorf("failed to remove pipeline component: %w", err)
 	}
 
 	// Unregister the factory used to create the pipeline
-	if err := bs.System.ComponentRegistry().UnregisterFactory(ctx, "factory"); err != nil {
+	if err := bs.System.ComponentRegistry().UnregisterFactory(ctx, ncApi.BuildPipelineFactory); err != nil {
 		return fmt.Errorf("failed to unregister pipeline factory: %w", err)
 	}
 
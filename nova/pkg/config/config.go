@@ -1,12 +1,19 @@
 package config
 
 import (
+	"bufio"
+	"errors"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 const (
 	DataDirName      = ".nova"
+	PluginsDirName   = "plugins"
+	CacheDirName     = "cache"
+	ConfigsDirName   = "configs"
+	TrustedKeysName  = "trusted_plugin_keys"
 	MetadataDbName   = "MetadataStore"
 	MultiStoreDbName = "MultiStore"
 )
@@ -16,6 +23,8 @@ type NovaConfig struct {
 	DatabasesDir     string
 	MetadataDbName   string
 	MultiStoreDbName string
+	PluginCacheDir   string // Directory caching the verified remote plugin bundles
+	TrustedKeysFile  string // File listing the keys trusted to sign plugin bundles, one per line
 }
 
 func GetDefaultConfig() (NovaConfig, error) {
@@ -33,7 +42,32 @@ func GetDefaultConfig() (NovaConfig, error) {
 		DatabasesDir:     databasesDir,
 		MetadataDbName:   MetadataDbName,
 		MultiStoreDbName: MultiStoreDbName,
+		PluginCacheDir:   filepath.Join(homeDir, DataDirName, CacheDirName, PluginsDirName),
+		TrustedKeysFile:  filepath.Join(homeDir, DataDirName, ConfigsDirName, TrustedKeysName),
 	}
 
 	return configuration, nil
 }
+
+// ReadTrustedKeys reads the keys trusted to sign plugin bundles from the given file, one base64
+// ed25519 public key per line. Empty lines and lines starting with '#' are ignored.
+// Returns no keys if the file does not exist.
+func ReadTrustedKeys(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var keys []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" && !strings.HasPrefix(line, "#") {
+			keys = append(keys, line)
+		}
+	}
+	return keys, scanner.Err()
+}
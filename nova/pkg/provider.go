@@ -35,6 +35,9 @@ type InitOptions struct {
 	Verbose bool        `valid:"type(bool),optional"` // Verbose mode flag
 	Command string      `valid:"alpha,optional"`      // Command to execute during initialization
 	Data    interface{} `valid:"-"`                   // Data for system initialization
+
+	RemotePlugins        []string `valid:"-"`                   // Locations of plugin bundles to load
+	AllowUnsignedPlugins bool     `valid:"type(bool),optional"` // Whether unsigned plugin bundles may be loaded
 }
 
 // Init initializes the Fx application with the provided options.
@@ -75,17 +78,42 @@ func ProvideConfiguration(options *InitOptions) func() (*config.Configuration, e
 			return nil, err
 		}
 
+		trustedKeys, err := novaConfigApi.ReadTrustedKeys(configuration.TrustedKeysFile)
+		if err != nil {
+			return nil, err
+		}
+
 		return &config.Configuration{
-			Debug:        options.Debug,
-			Verbose:      options.Verbose,
+			Debug:   options.Debug,
+			Verbose: options.Verbose,
+			RemotePlugins: config.RemotePluginConfiguration{
+				CacheDir:      configuration.PluginCacheDir,
+				TrustedKeys:   trustedKeys,
+				AllowUnsigned: options.AllowUnsignedPlugins,
+			},
 			CustomConfig: configuration,
 		}, nil
 	}
 }
 
-// ProvideEventBus provides an event bus interface.
-func ProvideEventBus() event.EventBusInterface {
-	return event.NewSystemEventBus()
+// ProvideEventBus provides an event bus interface. When the event log is enabled in the
+// configuration, the published events are appended to a store of the MultiStore.
+func ProvideEventBus(
+	configuration *config.Configuration,
+	multiStore store.MultiStore,
+	logger logger.LoggerInterface) (event.EventBusInterface, error) {
+
+	bus := event.NewSystemEventBus()
+	if !configuration.EventLog.Enabled {
+		return bus, nil
+	}
+
+	durable, err := event.NewDurableEventBus(bus, multiStore, configuration.EventLog.Store)
+	if err != nil {
+		return nil, err
+	}
+	durable.Logger = logger
+	return durable, nil
 }
 
 // ProvideLogger provides a logger interface based on the initialization options.
@@ -144,9 +172,10 @@ func ProvideSystem(options *InitOptions) SystemProvider {
 }
 
 // OnStart returns a function to initialize the system and execute a command on system start.
-func OnStart(options *InitOptions, system systemApi.SystemInterface, shutdowner fx.Shutdowner) func(ctx context.Context) error {
+// In daemon mode, the plugins are reloaded when they change in the plugin paths.
+func OnStart(options *InitOptions, system systemApi.SystemInterface, watcher *systemApi.PluginWatcher, shutdowner fx.Shutdowner) func(ctx context.Context) error {
 	return func(ctx context.Context) error {
-		contx := contextApi.WithContext(ctx)
+		contx := contextApi.WithContext(ctx).WithRemotePluginLocations(options.RemotePlugins...)
 
 		// Initialize the system and execute the command.
 		if err := InitializeSystem(contx, system); err != nil {
@@ -174,17 +203,23 @@ func OnStart(options *InitOptions, system systemApi.SystemInterface, shutdowner
 			return shutdowner.Shutdown()
 		}
 
+		// Watch the plugin paths while running as a daemon
+		if err := WatchPlugins(contx, watcher); err != nil {
+			system.Logger().Log(logger.LevelWarn, "Failed to watch plugins:", err)
+		}
+
 		return nil
 	}
 }
 
-// OnStop returns a function to stop the system on system shutdown.
-func OnStop(options *InitOptions, system systemApi.SystemInterface) func(ctx context.Context) error {
+// OnStop returns a function to stop the plugin watcher and the system on system shutdown.
+func OnStop(options *InitOptions, system systemApi.SystemInterface, watcher *systemApi.PluginWatcher) func(ctx context.Context) error {
 	return func(ctx context.Context) error {
 		contx := contextApi.WithContext(ctx)
 
 		// Check if the system is running as a daemon
 		if options.Daemon {
+			watcher.Stop(contx)
 			// If not running as a daemon, perform shutdown
 			return system.Stop(contx)
 		}
@@ -3,8 +3,10 @@ package provider
 import (
 	"errors"
 	"os"
+	"path/filepath"
 
 	"github.com/edward1christian/block-forge/nova/pkg/components/plugin"
+	novaConfigApi "github.com/edward1christian/block-forge/nova/pkg/config"
 	contextApi "github.com/edward1christian/block-forge/pkg/application/common/context"
 	"github.com/edward1christian/block-forge/pkg/application/common/event"
 	"github.com/edward1christian/block-forge/pkg/application/common/logger"
@@ -42,10 +44,14 @@ func SystemProviderFn(options *InitOptions) SystemProvider {
 			logger, eventBus, configuration,
 			pluginManager, registrar, multiStore)
 
+		// Create the watcher reloading the plugins in daemon mode.
+		watcher := systemApi.NewPluginWatcher(pluginManager, nil, systemApi.DefaultPluginWatchInterval)
+		watcher.Logger = logger
+
 		// Add lifecycle hooks to start and stop the system.
 		lc.Append(fx.Hook{
-			OnStart: OnStart(options, system, shutdowner),
-			OnStop:  OnStop(options, system),
+			OnStart: OnStart(options, system, watcher, shutdowner),
+			OnStop:  OnStop(options, system, watcher),
 		})
 
 		return system
@@ -78,14 +84,51 @@ func InitializeSystem(ctx *contextApi.Context, system systemApi.SystemInterface)
 		return errors.New("failed to execute operation: " + err.Error())
 	}
 
+	// Add the plugins found in the plugin paths and in the plugins directory of the user.
+	if err := AddDiscoveredPlugins(ctx.WithPluginPaths(PluginPaths(ctx, homeDir)...), system); err != nil {
+		return errors.New("failed to add discovered plugins: " + err.Error())
+	}
+
 	return nil
 }
 
+// PluginPaths returns the plugins directory of the user followed by the plugin paths of the context.
+func PluginPaths(ctx *contextApi.Context, homeDir string) []string {
+	return append([]string{filepath.Join(homeDir, novaConfigApi.DataDirName, novaConfigApi.PluginsDirName)}, ctx.PluginPaths...)
+}
+
+// WatchPlugins starts the watcher reloading the plugins found in the plugin paths when they change.
+func WatchPlugins(ctx *contextApi.Context, watcher *systemApi.PluginWatcher) error {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return errors.New("failed to get user's home directory: " + err.Error())
+	}
+
+	watcher.Paths = PluginPaths(ctx, homeDir)
+	return watcher.Start(ctx)
+}
+
 // AddNovaPlugin adds the Nova plugin to the system.
 func AddNovaPlugin(ctx *contextApi.Context, system systemApi.SystemInterface) error {
 	return AddPlugin(ctx, system, plugin.NewNovaPlugin())
 }
 
+// AddDiscoveredPlugins adds the plugins discovered in the plugin paths of the context to the system.
+// Plugins failing to load are logged and skipped.
+func AddDiscoveredPlugins(ctx *contextApi.Context, system systemApi.SystemInterface) error {
+	plugins, err := system.PluginManager().DiscoverPlugins(ctx)
+	if err != nil {
+		system.Logger().Log(logger.LevelWarn, "Failed to load plugins:", err)
+	}
+
+	for _, p := range plugins {
+		if err := AddPlugin(ctx, system, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // AddPlugin adds a plugin to the system using the provided context and system interface.
 func AddPlugin(ctx *contextApi.Context, system systemApi.SystemInterface, p systemApi.PluginInterface) error {
 	// Add the provided plugin to the plugin manager.
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"errors"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -99,7 +100,7 @@ func TestCreateConfigurationOp_Execute_InvalidInputData(t *testing.T) {
 	// Assert
 	assert.Error(t, err)
 	assert.Nil(t, output)
-	assert.EqualError(t, err, "failed to create project. Invalid input data")
+	assert.True(t, errors.Is(err, system.ErrInvalidOperationInput))
 }
 
 // ClearTempDir removes all files and subdirectories within the temporary directory.
@@ -117,3 +118,16 @@ func ClearTempDir() error {
 	}
 	return nil
 }
+
+// TestCreateConfigurationOp_InputSchema tests that the input schema requires an alphanumeric project name.
+func TestCreateConfigurationOp_InputSchema(t *testing.T) {
+	op := commands.NewCreateConfigurationOp("test-id", "Test Config Op", "Test description")
+	schema := op.InputSchema()
+
+	assert.Equal(t, "string", schema.TypeName())
+	assert.NoError(t, schema.Validate("TestProject"))
+	assert.Error(t, schema.Validate("Test Project"))
+	assert.Error(t, schema.Validate(123))
+	assert.Error(t, schema.Validate(nil))
+	assert.Nil(t, op.OutputSchema())
+}
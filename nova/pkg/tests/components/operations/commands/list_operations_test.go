@@ -0,0 +1,71 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/edward1christian/block-forge/nova/pkg/components/operations/commands"
+	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	"github.com/edward1christian/block-forge/pkg/application/component"
+	mocksApi "github.com/edward1christian/block-forge/pkg/application/mocks"
+	"github.com/edward1christian/block-forge/pkg/application/system"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestListOperationsOp_Execute tests that the catalog of the system is printed and returned.
+func TestListOperationsOp_Execute(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockSystem := &mocksApi.MockSystem{}
+	catalog := []*system.CatalogEntry{
+		{ID: "BuildProjectOp", Kind: system.CatalogOperationKind, Owner: "NovaPlugin", FactoryID: "BuildProjectOpFactory"},
+	}
+	mockSystem.On("Catalog").Return(catalog)
+
+	var out bytes.Buffer
+	op := commands.NewListOperationsOp("id", "name", "description")
+	op.Out = &out
+	op.Initialize(ctx, mockSystem)
+
+	// Act
+	output, err := op.Execute(ctx, &system.SystemOperationInput{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, catalog, output.Data)
+	assert.Contains(t, out.String(), "BuildProjectOp")
+	assert.Contains(t, out.String(), "NovaPlugin")
+}
+
+// TestListOperationsOp_Execute_Selector tests that only the entries matching the selector are printed.
+func TestListOperationsOp_Execute_Selector(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockSystem := &mocksApi.MockSystem{}
+	catalog := []*system.CatalogEntry{
+		{ID: "BuildProjectOp", Kind: system.CatalogOperationKind, Owner: "NovaPlugin",
+			Labels: map[string]string{component.PluginLabel: "nova", component.RoleLabel: "command"}},
+		{ID: "InitDirectoriesOp", Kind: system.CatalogOperationKind, Owner: "NovaPlugin",
+			Labels: map[string]string{component.PluginLabel: "nova", component.RoleLabel: "startup"}},
+	}
+	mockSystem.On("Catalog").Return(catalog)
+
+	var out bytes.Buffer
+	op := commands.NewListOperationsOp("id", "name", "description")
+	op.Out = &out
+	op.Initialize(ctx, mockSystem)
+
+	// Act
+	output, err := op.Execute(ctx, &system.SystemOperationInput{Data: "role=command"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, catalog[:1], output.Data)
+	assert.Contains(t, out.String(), "BuildProjectOp")
+	assert.NotContains(t, out.String(), "InitDirectoriesOp")
+
+	_, err = op.Execute(ctx, &system.SystemOperationInput{Data: "role in (command"})
+	assert.True(t, errors.Is(err, component.ErrInvalidSelector))
+}
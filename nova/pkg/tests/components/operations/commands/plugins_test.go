@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/edward1christian/block-forge/nova/pkg/components/operations/commands"
+	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	mocksApi "github.com/edward1christian/block-forge/pkg/application/mocks"
+	"github.com/edward1christian/block-forge/pkg/application/system"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestReloadPluginOp_Execute tests that the plugin given as input is reloaded.
+func TestReloadPluginOp_Execute(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockSystem := &mocksApi.MockSystem{}
+	mockPluginManager := &mocksApi.MockPluginManager{}
+	mockSystem.On("PluginManager").Return(mockPluginManager)
+	mockPluginManager.On("ReloadPlugin", ctx, "extractor").Return(nil)
+
+	op := commands.NewReloadPluginOp("id", "name", "description")
+	op.Initialize(ctx, mockSystem)
+
+	// Act
+	output, err := op.Execute(ctx, &system.SystemOperationInput{Data: "extractor"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "extractor", output.Data)
+	mockPluginManager.AssertExpectations(t)
+
+	_, err = op.Execute(ctx, &system.SystemOperationInput{})
+	assert.True(t, errors.Is(err, system.ErrInvalidOperationInput))
+}
+
+// TestUnloadPluginOp_Execute tests that the plugin given as input is unloaded, and that
+// the errors of the plugin manager are returned.
+func TestUnloadPluginOp_Execute(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	mockSystem := &mocksApi.MockSystem{}
+	mockPluginManager := &mocksApi.MockPluginManager{}
+	mockSystem.On("PluginManager").Return(mockPluginManager)
+	mockPluginManager.On("UnloadPlugin", ctx, "extractor").Return(nil)
+	mockPluginManager.On("UnloadPlugin", ctx, "core").Return(system.ErrPluginInUse)
+
+	op := commands.NewUnloadPluginOp("id", "name", "description")
+	op.Initialize(ctx, mockSystem)
+
+	// Act
+	output, err := op.Execute(ctx, &system.SystemOperationInput{Data: "extractor"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "extractor", output.Data)
+	_, err = op.Execute(ctx, &system.SystemOperationInput{Data: "core"})
+	assert.True(t, errors.Is(err, system.ErrPluginInUse))
+}
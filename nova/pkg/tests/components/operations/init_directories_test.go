@@ -34,7 +34,7 @@ func TestInitDirectoriesOperation_Execute_Success(t *testing.T) {
 	assert.Nil(t, output, "Output should be nil")
 
 	novaDir := filepath.Join(homeDir, ".nova")
-	subdirectories := []string{"databases", "configs", "logs", "cache"}
+	subdirectories := []string{"databases", "configs", "logs", "cache", "plugins"}
 	for _, subdir := range subdirectories {
 		dirPath := filepath.Join(novaDir, subdir)
 		_, err := os.Stat(dirPath)
@@ -11,6 +11,7 @@ import (
 	"github.com/edward1christian/block-forge/nova/pkg/components/plugin"
 	"github.com/edward1christian/block-forge/pkg/application/common/context"
 	"github.com/edward1christian/block-forge/pkg/application/mocks"
+	systemApi "github.com/edward1christian/block-forge/pkg/application/system"
 )
 
 func TestNovaPlugin_Initialize(t *testing.T) {
@@ -278,6 +279,7 @@ func TestNovaPlugin_Stop_Failure_CastComponent(t *testing.T) {
 
 	// Mock invalid BuildService component
 	invalidComponentMock := &mocks.MockComponent{}
+	invalidComponentMock.On("ID").Return(common.BuildService)
 
 	// Expectations for retrieving BuildService component
 	registrarMock.On("GetComponent", common.BuildService).Return(invalidComponentMock, nil)
@@ -294,7 +296,8 @@ func TestNovaPlugin_Stop_Failure_CastComponent(t *testing.T) {
 	// Test stopping the plugin failure for invalid component cast
 	err = p.Stop(ctx)
 	assert.Error(t, err)
-	assert.EqualError(t, err, "BuildService component does not implement SystemServiceInterface")
+	assert.ErrorIs(t, err, systemApi.ErrComponentTypeMismatch)
+	assert.Contains(t, err.Error(), "failed to get BuildService component")
 }
 
 func TestNovaPlugin_Stop_Failure_StopService(t *testing.T) {
@@ -335,3 +338,16 @@ func TestNovaPlugin_Stop_Failure_StopService(t *testing.T) {
 	assert.Error(t, err)
 	assert.EqualError(t, err, "failed to stop BuildService: "+expectedErr.Error())
 }
+
+func TestNovaPlugin_Manifest(t *testing.T) {
+	// Arrange
+	p := plugin.NewNovaPlugin()
+
+	// Act
+	manifest := p.(systemApi.ManifestedPluginInterface).Manifest()
+
+	// Assert
+	assert.Equal(t, p.ID(), manifest.ID)
+	assert.Equal(t, plugin.PluginVersion, manifest.Version)
+	assert.NoError(t, manifest.CheckCompatibility(), "Nova must support the core API of the system")
+}
@@ -7,9 +7,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	ncApi "github.com/edward1christian/block-forge/nova/pkg/common"
 	"github.com/edward1christian/block-forge/nova/pkg/components/services"
 	novaMocksApi "github.com/edward1christian/block-forge/nova/pkg/mocks"
 	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	"github.com/edward1christian/block-forge/pkg/application/component"
 	"github.com/edward1christian/block-forge/pkg/application/mocks"
 )
 
@@ -76,8 +78,8 @@ func TestBuildService_Stop_Success(t *testing.T) {
 	bs := services.NewBuildService("id", "name", "description")
 
 	mockSystem.On("ComponentRegistry").Return(mockRegistrar)
-	mockRegistrar.On("RemoveComponent", ctx, "pipeline").Return(nil)
-	mockRegistrar.On("UnregisterFactory", ctx, "factory").Return(nil)
+	mockRegistrar.On("RemoveComponent", ctx, ncApi.BuildPipeline).Return(nil)
+	mockRegistrar.On("UnregisterFactory", ctx, ncApi.BuildPipelineFactory).Return(nil)
 	mockRegistrar.On("RegisterFactory", ctx, mock.Anything, mock.Anything).Return(nil)
 
 	// Act
@@ -92,6 +94,33 @@ func TestBuildService_Stop_Success(t *testing.T) {
 	mockRegistrar.AssertExpectations(t)
 }
 
+// TestBuildService_Stop_PipelineNotCreated tests that stopping a service whose pipeline
+// was never created succeeds.
+func TestBuildService_Stop_PipelineNotCreated(t *testing.T) {
+	// Arrange
+	ctx := &context.Context{}
+	mockSystem := &mocks.MockSystem{}
+	mockRegistrar := &mocks.MockComponentRegistrar{}
+
+	bs := services.NewBuildService("id", "name", "description")
+
+	mockSystem.On("ComponentRegistry").Return(mockRegistrar)
+	mockRegistrar.On("RegisterFactory", ctx, mock.Anything, mock.Anything).Return(nil)
+	mockRegistrar.On("RemoveComponent", ctx, ncApi.BuildPipeline).Return(
+		fmt.Errorf("%w: %s", component.ErrComponentNotFound, ncApi.BuildPipeline))
+	mockRegistrar.On("UnregisterFactory", ctx, ncApi.BuildPipelineFactory).Return(nil)
+
+	// Act
+	err := bs.Initialize(ctx, mockSystem)
+	assert.NoError(t, err)
+
+	err = bs.Stop(ctx)
+
+	// Assert
+	assert.NoError(t, err, "Stopping BuildService should not fail when the pipeline was not created")
+	mockRegistrar.AssertExpectations(t)
+}
+
 func TestBuildService_Stop_Error_RemoveComponentFailed(t *testing.T) {
 	// Arrange
 	mockSystem := &mocks.MockSystem{}
@@ -102,7 +131,7 @@ func TestBuildService_Stop_Error_RemoveComponentFailed(t *testing.T) {
 
 	mockSystem.On("ComponentRegistry").Return(mockRegistrar)
 	mockRegistrar.On("RegisterFactory", ctx, mock.Anything, mock.Anything).Return(nil)
-	mockRegistrar.On("RemoveComponent", ctx, "pipeline").Return(fmt.Errorf("failed to remove pipeline"))
+	mockRegistrar.On("RemoveComponent", ctx, ncApi.BuildPipeline).Return(fmt.Errorf("failed to remove pipeline"))
 
 	// Act
 	err := bs.Initialize(ctx, mockSystem)
@@ -125,9 +154,9 @@ func TestBuildService_Stop_Error_UnregisterFactoryFailed(t *testing.T) {
 	bs := services.NewBuildService("id", "name", "description")
 
 	mockSystem.On("ComponentRegistry").Return(mockRegistrar)
-	mockRegistrar.On("RemoveComponent", ctx, "pipeline").Return(nil)
+	mockRegistrar.On("RemoveComponent", ctx, ncApi.BuildPipeline).Return(nil)
 	mockRegistrar.On("RegisterFactory", ctx, mock.Anything, mock.Anything).Return(nil)
-	mockRegistrar.On("UnregisterFactory", ctx, "factory").Return(fmt.Errorf("failed to unregister factory"))
+	mockRegistrar.On("UnregisterFactory", ctx, ncApi.BuildPipelineFactory).Return(fmt.Errorf("failed to unregister factory"))
 
 	// Act
 	err := bs.Initialize(ctx, mockSystem)
@@ -140,3 +169,37 @@ func TestBuildService_Stop_Error_UnregisterFactoryFailed(t *testing.T) {
 	mockSystem.AssertExpectations(t)
 	mockRegistrar.AssertExpectations(t)
 }
+
+func TestBuildService_Health(t *testing.T) {
+	// Arrange
+	ctx := &context.Context{}
+	mockSystem := &mocks.MockSystem{}
+	mockPipeline := &novaMocksApi.MockPipeline{}
+	mockRegistrar := &mocks.MockComponentRegistrar{}
+
+	bs := services.NewBuildService("id", "name", "description")
+	reporter, ok := bs.(component.HealthReporterInterface)
+	assert.True(t, ok, "BuildService should report its health")
+
+	mockPipeline.On("Initialize", ctx, mockSystem).Return(nil)
+	mockPipeline.On("Execute", ctx, mock.Anything).Return(fmt.Errorf("pipeline failed"))
+
+	mockSystem.On("ComponentRegistry").Return(mockRegistrar)
+	mockRegistrar.On("RegisterFactory", ctx, mock.Anything, mock.Anything).Return(nil)
+	mockRegistrar.On("CreateComponent", ctx, mock.Anything).Return(mockPipeline, nil)
+
+	// Act
+	err := bs.Initialize(ctx, mockSystem)
+	assert.NoError(t, err)
+	before := reporter.Health(ctx)
+
+	err = bs.Start(ctx)
+	after := reporter.Health(ctx)
+
+	// Assert
+	assert.Error(t, err)
+	assert.False(t, before.Live, "BuildService should not be live before it is started")
+	assert.True(t, after.Live, "BuildService should be live once started")
+	assert.False(t, after.Ready, "BuildService should not be ready after the pipeline failed")
+	assert.Contains(t, after.Reason, "pipeline failed")
+}
@@ -22,7 +22,7 @@ type Context struct {
 	// PluginPaths is a slice of paths to search for plugins.
 	PluginPaths []string
 
-	// RemotePluginLocations is a slice of remote locations (e.g., GitHub repositories) to download plugins from.
+	// RemotePluginLocations is a slice of locations (URLs or paths) of plugin bundles to download plugins from.
 	RemotePluginLocations []string
 }
 
@@ -38,8 +38,10 @@ func (c *Context) WithValue(key, value interface{}) *Context {
 	}
 
 	newCtx := &Context{
-		Context: c.Context,
-		values:  make(map[interface{}]interface{}, len(c.values)+1),
+		Context:               c.Context,
+		values:                make(map[interface{}]interface{}, len(c.values)+1),
+		PluginPaths:           c.PluginPaths,
+		RemotePluginLocations: c.RemotePluginLocations,
 	}
 
 	for k, v := range c.values {
@@ -67,9 +69,10 @@ func (c *Context) Value(key interface{}) interface{} {
 // WithPluginPaths returns a new Context with the given plugin paths.
 func (c *Context) WithPluginPaths(paths ...string) *Context {
 	newCtx := &Context{
-		Context:     c.Context,
-		values:      c.values,
-		PluginPaths: make([]string, len(paths)),
+		Context:               c.Context,
+		values:                c.values,
+		PluginPaths:           make([]string, len(paths)),
+		RemotePluginLocations: c.RemotePluginLocations,
 	}
 
 	copy(newCtx.PluginPaths, paths)
@@ -93,6 +96,7 @@ func (c *Context) WithRemotePluginLocations(locations ...string) *Context {
 	newCtx := &Context{
 		Context:               c.Context,
 		values:                c.values,
+		PluginPaths:           c.PluginPaths,
 		RemotePluginLocations: append([]string(nil), locations...),
 	}
 
@@ -136,3 +140,23 @@ func WithTimeout(parent *Context, timeout time.Duration) (*Context, context.Canc
 
 	return newCtx, cancel
 }
+
+// WithCancel returns a new Context that is canceled when the returned cancel function
+// is called or when the parent's context is canceled, whichever happens first.
+// It is similar to the standard context.WithCancel() function but returns a custom Context type.
+func WithCancel(parent *Context) (*Context, context.CancelFunc) {
+	base := parent.Context
+	if base == nil {
+		base = context.Background()
+	}
+
+	ctx, cancel := context.WithCancel(base)
+	newCtx := &Context{
+		Context:               ctx,
+		values:                parent.values,
+		PluginPaths:           parent.PluginPaths,
+		RemotePluginLocations: parent.RemotePluginLocations,
+	}
+
+	return newCtx, cancel
+}
@@ -0,0 +1,15 @@
+package event
+
+import "errors"
+
+// Custom errors
+var (
+	ErrInvalidSubscription    = errors.New("invalid subscription")
+	ErrSubscriptionNotFound   = errors.New("subscription not found")
+	ErrEventLogUnavailable    = errors.New("event log unavailable")
+	ErrTopicNotRegistered     = errors.New("topic not registered")
+	ErrTopicAlreadyRegistered = errors.New("topic already registered")
+	ErrPayloadTypeMismatch    = errors.New("payload type mismatch")
+	ErrInvalidPayload         = errors.New("invalid payload")
+	ErrInvalidTopic           = errors.New("invalid topic")
+)
@@ -1,20 +1,35 @@
 package event
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Namespaces of the event topics. Topics are dot-separated paths starting with the namespace of
+// the subsystem publishing them, so that a pattern such as "etl.#" selects a whole subsystem.
+const (
+	// NamespaceETL is the namespace of the topics of the data pipelines.
+	NamespaceETL string = "etl"
 
-	"github.com/asaskevich/EventBus"
+	// NamespaceSystem is the namespace of the topics of the core system.
+	NamespaceSystem string = "system"
+
+	// NamespaceNova is the namespace of the topics of Nova.
+	NamespaceNova string = "nova"
 )
 
 const (
 	// EventTypeDataExtracted represents an event emitted when data is extracted from a source blockchain.
-	EventTypeDataExtracted string = "data_extracted"
+	EventTypeDataExtracted string = NamespaceETL + ".process.extracted"
 
 	// EventTypeDataTransformed represents an event emitted when data is transformed by a pipeline stage.
-	EventTypeDataTransformed string = "data_transformed"
+	EventTypeDataTransformed string = NamespaceETL + ".process.transformed"
 
 	// EventTypeDataLoaded represents an event emitted when data is loaded into a target blockchain.
-	EventTypeDataLoaded string = "data_loaded"
+	EventTypeDataLoaded string = NamespaceETL + ".process.loaded"
 )
 
 // Event represents an event within the system.
@@ -23,7 +38,30 @@ type Event struct {
 	Type string
 
 	// Data is the payload or data associated with the event.
+	// Events replayed from an event log hold their data as a json.RawMessage.
 	Data interface{}
+
+	// Sequence is the position of the event in the event log, or 0 if the bus is not durable.
+	Sequence uint64
+
+	// Timestamp is the time at which the event was appended to the event log, if the bus is durable.
+	Timestamp time.Time
+}
+
+// Decode decodes the data of the event into the value pointed to by v. It accepts the data of
+// published events as well as the JSON data of replayed events.
+func (e Event) Decode(v interface{}) error {
+	raw, ok := e.Data.(json.RawMessage)
+	if !ok {
+		var err error
+		if raw, err = json.Marshal(e.Data); err != nil {
+			return fmt.Errorf("failed to encode data of event %s: %w", e.Type, err)
+		}
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("failed to decode data of event %s: %w", e.Type, err)
+	}
+	return nil
 }
 
 // EventHandler defines the signature for an event handler function.
@@ -38,28 +76,61 @@ type BusSubscriptionParams struct {
 	EventHandler EventHandler
 }
 
+// Subscription identifies a handler subscribed to an event topic. It is returned by the
+// Subscribe methods of the bus and given back to Unsubscribe to remove that handler only.
+type Subscription struct {
+	id            uint64       // Unique identifier of the subscription on its bus
+	topic         string       // Topic the handler is subscribed to
+	handler       EventHandler // Handler of the events
+	async         bool         // Whether the handler is called in its own goroutine
+	once          bool         // Whether the handler is removed after its first event
+	transactional bool         // Whether asynchronous calls of the handler are serialized
+	pattern       bool         // Whether the topic is a pattern matching several topics
+	mutex         sync.Mutex   // Serializes the transactional calls of the handler
+}
+
+// ID returns the identifier of the subscription, unique on its bus.
+func (s *Subscription) ID() uint64 {
+	return s.id
+}
+
+// Topic returns the topic of the subscription, or its topic pattern.
+func (s *Subscription) Topic() string {
+	return s.topic
+}
+
 // BusSubscriber defines subscription-related bus behavior.
 type BusSubscriber interface {
 	// Subscribe subscribes to an event topic with the given parameters.
-	Subscribe(params BusSubscriptionParams) error
+	Subscribe(params BusSubscriptionParams) (*Subscription, error)
 
 	// SubscribeAsync subscribes to an event topic asynchronously with the given parameters.
-	SubscribeAsync(params BusSubscriptionParams, transactional bool) error
+	// Transactional handlers are called one event at a time.
+	SubscribeAsync(params BusSubscriptionParams, transactional bool) (*Subscription, error)
 
 	// SubscribeOnce subscribes to an event topic for a single event occurrence with the given parameters.
-	SubscribeOnce(params BusSubscriptionParams) error
+	SubscribeOnce(params BusSubscriptionParams) (*Subscription, error)
 
 	// SubscribeOnceAsync subscribes to an event topic asynchronously for a single event occurrence with the given parameters.
-	SubscribeOnceAsync(params BusSubscriptionParams) error
+	SubscribeOnceAsync(params BusSubscriptionParams) (*Subscription, error)
+
+	// SubscribePattern subscribes to the topics matching the topic pattern of the parameters,
+	// as described by MatchTopic.
+	SubscribePattern(params BusSubscriptionParams) (*Subscription, error)
+
+	// SubscribePatternAsync subscribes asynchronously to the topics matching the topic pattern of the parameters.
+	// Transactional handlers are called one event at a time.
+	SubscribePatternAsync(params BusSubscriptionParams, transactional bool) (*Subscription, error)
 
-	// Unsubscribe unsubscribes from an event topic with the given parameters.
-	Unsubscribe(params BusSubscriptionParams) error
+	// Unsubscribe removes the handler of the given subscription.
+	Unsubscribe(subscription *Subscription) error
 }
 
 // BusPublisher defines publishing-related bus behavior.
 type BusPublisher interface {
-	// Publish publishes an event to the event bus.
-	Publish(event Event)
+	// Publish publishes an event to the event bus. It fails with ErrPayloadTypeMismatch if the
+	// topic of the event is registered with another payload type.
+	Publish(event Event) error
 }
 
 // BusController defines bus control behavior (checking handler's presence, synchronization).
@@ -69,6 +140,9 @@ type BusController interface {
 
 	// WaitAsync blocks until all asynchronous operations are completed.
 	WaitAsync()
+
+	// Topics returns the registry of the topics of the bus and of their payload types.
+	Topics() *TopicRegistry
 }
 
 // EventBusInterface englobes global (subscribe, publish, control) bus behavior.
@@ -78,120 +152,202 @@ type EventBusInterface interface {
 	BusPublisher
 }
 
-// SystemEventBus is a concrete implementation of the EventBusInterface.
+// SystemEventBus is a concrete implementation of the EventBusInterface. Any number of handlers
+// may be subscribed to a topic or to a pattern matching it; they are called in the order they
+// subscribed. It is safe for concurrent use, and handlers may subscribe and unsubscribe while an
+// event is published.
 type SystemEventBus struct {
-	bus      EventBus.Bus           // Underlying third-party EventBus instance
-	handlers map[string]interface{} // Map to store function references used for subscription
+	mutex    sync.RWMutex
+	lastID   uint64                     // Identifier of the last subscription
+	handlers map[string][]*Subscription // Subscriptions by topic, in subscription order
+	patterns map[string][]*Subscription // Pattern subscriptions by topic pattern, in subscription order
+	pending  sync.WaitGroup             // Asynchronous handler calls in progress
+	topics   *TopicRegistry             // Payload types of the registered topics
 }
 
 // NewSystemEventBus creates a new instance of the SystemEventBus.
 func NewSystemEventBus() EventBusInterface {
 	return &SystemEventBus{
-		bus:      EventBus.New(),
-		handlers: make(map[string]interface{}),
+		handlers: make(map[string][]*Subscription),
+		patterns: make(map[string][]*Subscription),
+		topics:   NewTopicRegistry(),
 	}
 }
 
 // Subscribe subscribes to an event topic with the given parameters.
-func (eb *SystemEventBus) Subscribe(params BusSubscriptionParams) error {
-	// Create a wrapper function that constructs the Event and calls the provided EventHandler
-	handler := func(args ...interface{}) {
-		event := Event{
-			Type: params.Topic,
-			Data: args[0],
-		}
-		params.EventHandler(event)
-	}
-
-	// Store the function reference for later use in Unsubscribe
-	eb.handlers[params.Topic] = handler
-
-	// Subscribe to the underlying EventBus using the wrapper function
-	return eb.bus.Subscribe(params.Topic, handler)
+func (eb *SystemEventBus) Subscribe(params BusSubscriptionParams) (*Subscription, error) {
+	return eb.subscribe(params, &Subscription{})
 }
 
 // SubscribeAsync subscribes to an event topic asynchronously with the given parameters.
-func (eb *SystemEventBus) SubscribeAsync(params BusSubscriptionParams, transactional bool) error {
-	// Create a wrapper function that constructs the Event and calls the provided EventHandler
-	handler := func(args ...interface{}) {
-		event := Event{
-			Type: params.Topic,
-			Data: args[0],
-		}
-		params.EventHandler(event)
-	}
-
-	// Store the function reference for later use in Unsubscribe
-	eb.handlers[params.Topic] = handler
-
-	// Subscribe asynchronously to the underlying EventBus using the wrapper function
-	return eb.bus.SubscribeAsync(params.Topic, handler, transactional)
+// Transactional handlers are called one event at a time.
+func (eb *SystemEventBus) SubscribeAsync(params BusSubscriptionParams, transactional bool) (*Subscription, error) {
+	return eb.subscribe(params, &Subscription{async: true, transactional: transactional})
 }
 
 // SubscribeOnce subscribes to an event topic for a single event occurrence with the given parameters.
-func (eb *SystemEventBus) SubscribeOnce(params BusSubscriptionParams) error {
-	// Create a wrapper function that constructs the Event and calls the provided EventHandler
-	handler := func(args ...interface{}) {
-		event := Event{
-			Type: params.Topic,
-			Data: args[0],
-		}
-		params.EventHandler(event)
-	}
+func (eb *SystemEventBus) SubscribeOnce(params BusSubscriptionParams) (*Subscription, error) {
+	return eb.subscribe(params, &Subscription{once: true})
+}
+
+// SubscribeOnceAsync subscribes to an event topic asynchronously for a single event occurrence with the given parameters.
+func (eb *SystemEventBus) SubscribeOnceAsync(params BusSubscriptionParams) (*Subscription, error) {
+	return eb.subscribe(params, &Subscription{async: true, once: true})
+}
 
-	// Store the function reference for later use in Unsubscribe
-	eb.handlers[params.Topic] = handler
+// SubscribePattern subscribes to the topics matching the topic pattern of the parameters,
+// as described by MatchTopic.
+func (eb *SystemEventBus) SubscribePattern(params BusSubscriptionParams) (*Subscription, error) {
+	return eb.subscribe(params, &Subscription{pattern: true})
+}
 
-	// Subscribe once to the underlying EventBus using the wrapper function
-	return eb.bus.SubscribeOnce(params.Topic, handler)
+// SubscribePatternAsync subscribes asynchronously to the topics matching the topic pattern of the parameters.
+// Transactional handlers are called one event at a time.
+func (eb *SystemEventBus) SubscribePatternAsync(params BusSubscriptionParams, transactional bool) (*Subscription, error) {
+	return eb.subscribe(params, &Subscription{pattern: true, async: true, transactional: transactional})
 }
 
-// SubscribeOnceAsync subscribes to an event topic asynchronously for a single event occurrence with the given parameters.
-func (eb *SystemEventBus) SubscribeOnceAsync(params BusSubscriptionParams) error {
-	// Create a wrapper function that constructs the Event and calls the provided EventHandler
-	handler := func(args ...interface{}) {
-		event := Event{
-			Type: params.Topic,
-			Data: args[0],
-		}
-		params.EventHandler(event)
+// Unsubscribe removes the handler of the given subscription. It fails with ErrSubscriptionNotFound
+// if the subscription was already removed, or belongs to another bus.
+func (eb *SystemEventBus) Unsubscribe(subscription *Subscription) error {
+	if subscription == nil {
+		return fmt.Errorf("%w: nil subscription", ErrSubscriptionNotFound)
 	}
 
-	// Store the function reference for later use in Unsubscribe
-	eb.handlers[params.Topic] = handler
+	eb.mutex.Lock()
+	defer eb.mutex.Unlock()
 
-	// Subscribe once asynchronously to the underlying EventBus using the wrapper function
-	return eb.bus.SubscribeOnceAsync(params.Topic, handler)
+	if !eb.remove(subscription) {
+		return fmt.Errorf("%w: topic %s", ErrSubscriptionNotFound, subscription.topic)
+	}
+	return nil
 }
 
-// Unsubscribe unsubscribes from an event topic with the given parameters.
-func (eb *SystemEventBus) Unsubscribe(params BusSubscriptionParams) error {
-	// Retrieve the function reference used for subscription
-	handler, ok := eb.handlers[params.Topic]
-	if !ok {
-		return fmt.Errorf("no handler found for topic %s", params.Topic)
+// Publish publishes an event to the handlers subscribed to its topic or to a pattern matching it.
+// It fails with ErrInvalidTopic if the topic is a pattern, and with ErrPayloadTypeMismatch if the
+// topic of the event is registered with another payload type.
+func (eb *SystemEventBus) Publish(event Event) error {
+	if IsTopicPattern(event.Type) {
+		return fmt.Errorf("%w: cannot publish on topic pattern %s", ErrInvalidTopic, event.Type)
+	}
+	if err := eb.topics.Validate(event); err != nil {
+		return err
 	}
 
-	// Unsubscribe from the underlying EventBus using the stored function reference
-	err := eb.bus.Unsubscribe(params.Topic, handler)
-	if err == nil {
-		// Remove the function reference from the handlers map if unsubscription succeeded
-		delete(eb.handlers, params.Topic)
+	eb.mutex.Lock()
+	subscriptions := append([]*Subscription(nil), eb.handlers[event.Type]...)
+	if matched := eb.matchingPatterns(event.Type); len(matched) > 0 {
+		subscriptions = append(subscriptions, matched...)
+		sort.Slice(subscriptions, func(i, j int) bool { return subscriptions[i].id < subscriptions[j].id })
 	}
-	return err
-}
+	for i, subscription := range subscriptions {
+		// Handlers subscribed once are removed before being called, so that they are called once
+		// even if events are published concurrently
+		if subscription.once && !eb.remove(subscription) {
+			subscriptions[i] = nil
+		}
+	}
+	eb.mutex.Unlock()
 
-// Publish publishes an event to the event bus.
-func (eb *SystemEventBus) Publish(event Event) {
-	eb.bus.Publish(event.Type, event.Data)
+	for _, subscription := range subscriptions {
+		if subscription == nil {
+			continue
+		}
+		if !subscription.async {
+			subscription.handler(event)
+			continue
+		}
+		eb.pending.Add(1)
+		go func(subscription *Subscription) {
+			defer eb.pending.Done()
+			if subscription.transactional {
+				subscription.mutex.Lock()
+				defer subscription.mutex.Unlock()
+			}
+			subscription.handler(event)
+		}(subscription)
+	}
+	return nil
 }
 
 // HasCallback checks if a handler is registered for the given topic.
 func (eb *SystemEventBus) HasCallback(topic string) bool {
-	return eb.bus.HasCallback(topic)
+	eb.mutex.RLock()
+	defer eb.mutex.RUnlock()
+
+	return len(eb.handlers[topic]) > 0 || len(eb.matchingPatterns(topic)) > 0
 }
 
 // WaitAsync blocks until all asynchronous operations are completed.
 func (eb *SystemEventBus) WaitAsync() {
-	eb.bus.WaitAsync()
+	eb.pending.Wait()
+}
+
+// Topics returns the registry of the topics of the bus and of their payload types.
+func (eb *SystemEventBus) Topics() *TopicRegistry {
+	return eb.topics
+}
+
+// subscribe adds the given subscription to the handlers of the topic of the parameters.
+func (eb *SystemEventBus) subscribe(params BusSubscriptionParams, subscription *Subscription) (*Subscription, error) {
+	if params.Topic == "" || params.EventHandler == nil {
+		return nil, fmt.Errorf("%w: a topic and an event handler are required", ErrInvalidSubscription)
+	}
+	if subscription.pattern {
+		if err := validatePattern(params.Topic); err != nil {
+			return nil, err
+		}
+	}
+
+	eb.mutex.Lock()
+	defer eb.mutex.Unlock()
+
+	eb.lastID++
+	subscription.id = eb.lastID
+	subscription.topic = params.Topic
+	subscription.handler = params.EventHandler
+	subscriptions := eb.subscriptions(subscription)
+	subscriptions[params.Topic] = append(subscriptions[params.Topic], subscription)
+	return subscription, nil
+}
+
+// subscriptions returns the subscriptions by topic holding the given subscription: the topic
+// subscriptions or the pattern subscriptions.
+func (eb *SystemEventBus) subscriptions(subscription *Subscription) map[string][]*Subscription {
+	if subscription.pattern {
+		return eb.patterns
+	}
+	return eb.handlers
+}
+
+// matchingPatterns returns the pattern subscriptions matching the given topic. The mutex must be held.
+func (eb *SystemEventBus) matchingPatterns(topic string) []*Subscription {
+	var matched []*Subscription
+	for pattern, subscriptions := range eb.patterns {
+		if MatchTopic(pattern, topic) {
+			matched = append(matched, subscriptions...)
+		}
+	}
+	return matched
+}
+
+// remove removes the given subscription from the handlers of its topic, and reports whether it
+// was found. The mutex must be held.
+func (eb *SystemEventBus) remove(subscription *Subscription) bool {
+	byTopic := eb.subscriptions(subscription)
+	subscriptions := byTopic[subscription.topic]
+	for i, candidate := range subscriptions {
+		if candidate == subscription {
+			// Copy the remaining subscriptions, so that the slices taken by Publish are left intact
+			remaining := make([]*Subscription, 0, len(subscriptions)-1)
+			remaining = append(append(remaining, subscriptions[:i]...), subscriptions[i+1:]...)
+			if len(remaining) == 0 {
+				delete(byTopic, subscription.topic)
+			} else {
+				byTopic[subscription.topic] = remaining
+			}
+			return true
+		}
+	}
+	return false
 }
@@ -0,0 +1,256 @@
+package event
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/logger"
+	"github.com/edward1christian/block-forge/pkg/application/store"
+)
+
+// DefaultEventLogStore is the name of the store of the event log in the MultiStore.
+const DefaultEventLogStore = "eventlog"
+
+// replayBatchSize is the number of logged events read from the store at once when replaying.
+const replayBatchSize = 256
+
+// loggedEvent is the record of an event in the event log.
+type loggedEvent struct {
+	Sequence  uint64          `json:"sequence"`
+	Timestamp time.Time       `json:"timestamp"`
+	Type      string          `json:"type"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// DurableEventBus decorates an event bus with a durable event log. Every published event is given
+// the next sequence number and a timestamp, and is appended to a store of the MultiStore before
+// being delivered, so that subscribers can replay the events they missed.
+type DurableEventBus struct {
+	EventBusInterface
+	Logger logger.LoggerInterface // Logger of the events that could not be appended, if set
+
+	store    store.Store // Store of the event log, keyed by sequence number
+	mutex    sync.Mutex  // Serializes the appends to the event log
+	sequence uint64      // Sequence number of the last logged event
+}
+
+// NewDurableEventBus creates a durable event bus delivering its events through the given bus, and
+// logging them in the store with the given name of the MultiStore, DefaultEventLogStore if empty.
+// The sequence numbers continue after the last event found in the store.
+func NewDurableEventBus(bus EventBusInterface, multiStore store.MultiStore, name string) (*DurableEventBus, error) {
+	if multiStore == nil {
+		return nil, fmt.Errorf("%w: no multistore", ErrEventLogUnavailable)
+	}
+	if name == "" {
+		name = DefaultEventLogStore
+	}
+
+	logStore, created, err := multiStore.CreateStore(name)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to create store %s: %v", ErrEventLogUnavailable, name, err)
+	}
+	if created {
+		if _, err := logStore.Load(); err != nil {
+			return nil, fmt.Errorf("%w: failed to load store %s: %v", ErrEventLogUnavailable, name, err)
+		}
+	}
+
+	// Resume the sequence after the last logged event
+	durable := &DurableEventBus{EventBusInterface: bus, store: logStore}
+	err = logStore.IterateRange(nil, nil, false, func(key, value []byte) bool {
+		if len(key) == 8 {
+			durable.sequence = binary.BigEndian.Uint64(key)
+			return true
+		}
+		return false
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read store %s: %v", ErrEventLogUnavailable, name, err)
+	}
+	return durable, nil
+}
+
+// Sequence returns the sequence number of the last logged event.
+func (d *DurableEventBus) Sequence() uint64 {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	return d.sequence
+}
+
+// Append appends the event to the event log and returns it with its sequence number and timestamp.
+// A version of the store is saved for every event, so that logged events survive a crash.
+func (d *DurableEventBus) Append(event Event) (Event, error) {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return event, fmt.Errorf("failed to encode data of event %s: %w", event.Type, err)
+	}
+
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	logged := event
+	logged.Sequence = d.sequence + 1
+	logged.Timestamp = time.Now().UTC()
+	record, err := json.Marshal(loggedEvent{
+		Sequence:  logged.Sequence,
+		Timestamp: logged.Timestamp,
+		Type:      logged.Type,
+		Data:      data,
+	})
+	if err != nil {
+		return event, fmt.Errorf("failed to encode event %s: %w", event.Type, err)
+	}
+
+	if err := d.store.Set(sequenceKey(logged.Sequence), record); err != nil {
+		return event, fmt.Errorf("failed to append event %s to the event log: %w", event.Type, err)
+	}
+	if _, _, err := d.store.SaveVersion(); err != nil {
+		d.store.Rollback()
+		return event, fmt.Errorf("failed to append event %s to the event log: %w", event.Type, err)
+	}
+	d.sequence = logged.Sequence
+	return logged, nil
+}
+
+// Publish appends the event to the event log and delivers it. Events that cannot be appended are
+// reported to the Logger and delivered without a sequence number. Events published on a topic
+// pattern, or whose payload does not have the type of their topic, are rejected without being logged.
+func (d *DurableEventBus) Publish(event Event) error {
+	if IsTopicPattern(event.Type) {
+		return fmt.Errorf("%w: cannot publish on topic pattern %s", ErrInvalidTopic, event.Type)
+	}
+	if err := d.Topics().Validate(event); err != nil {
+		return err
+	}
+
+	logged, err := d.Append(event)
+	if err != nil && d.Logger != nil {
+		d.Logger.Log(logger.LevelError, "Failed to log event:", err)
+	}
+	return d.EventBusInterface.Publish(logged)
+}
+
+// Replay calls the handler with the logged events of the topic, of the topics matching it if it is
+// a topic pattern, or of every topic if empty, from the given sequence number up to the last
+// logged event, in sequence order.
+func (d *DurableEventBus) Replay(topic string, from uint64, handler EventHandler) error {
+	return d.replay(topic, from, d.Sequence(), handler)
+}
+
+// SubscribeFrom subscribes to an event topic with the given parameters, after replaying the logged
+// events of the topic from the given sequence number. Events published during the replay are
+// delivered once it completes, so that the handler receives every event once and in order.
+// Subscribers resume from the sequence number following the last event they handled, or replay
+// the whole log from 0. The topic may be a topic pattern, as accepted by SubscribePattern.
+func (d *DurableEventBus) SubscribeFrom(params BusSubscriptionParams, from uint64) (*Subscription, error) {
+	if params.EventHandler == nil {
+		return nil, fmt.Errorf("%w: a topic and an event handler are required", ErrInvalidSubscription)
+	}
+
+	var mutex sync.Mutex
+	var pending []Event
+	replaying := true
+
+	// Subscribe before reading the log, so that no event falls between the replay and the subscription
+	d.mutex.Lock()
+	head := d.sequence
+	subscribe := d.EventBusInterface.Subscribe
+	if IsTopicPattern(params.Topic) {
+		subscribe = d.EventBusInterface.SubscribePattern
+	}
+	subscription, err := subscribe(BusSubscriptionParams{
+		Topic: params.Topic,
+		EventHandler: func(event Event) {
+			if event.Sequence != 0 && event.Sequence <= head {
+				return // Replayed
+			}
+			mutex.Lock()
+			if replaying {
+				pending = append(pending, event)
+				mutex.Unlock()
+				return
+			}
+			mutex.Unlock()
+			params.EventHandler(event)
+		},
+	})
+	d.mutex.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := d.replay(params.Topic, from, head, params.EventHandler); err != nil {
+		_ = d.Unsubscribe(subscription)
+		return nil, err
+	}
+
+	// Deliver the events published during the replay
+	for {
+		mutex.Lock()
+		if len(pending) == 0 {
+			replaying = false
+			mutex.Unlock()
+			return subscription, nil
+		}
+		event := pending[0]
+		pending = pending[1:]
+		mutex.Unlock()
+		params.EventHandler(event)
+	}
+}
+
+// replay calls the handler with the logged events of the topic or topic pattern between the given sequence numbers.
+// Events are read in batches, and the handler is called outside of the iteration of the store so
+// that it can publish events.
+func (d *DurableEventBus) replay(topic string, from, to uint64, handler EventHandler) error {
+	if from == 0 {
+		from = 1
+	}
+	pattern := IsTopicPattern(topic)
+	for from <= to {
+		var batch []Event
+		var decodeErr error
+		err := d.store.IterateRange(sequenceKey(from), sequenceKey(to+1), true, func(key, value []byte) bool {
+			var record loggedEvent
+			if decodeErr = json.Unmarshal(value, &record); decodeErr != nil {
+				decodeErr = fmt.Errorf("failed to decode logged event %x: %w", key, decodeErr)
+				return true
+			}
+			from = record.Sequence + 1
+			if topic == "" || record.Type == topic || (pattern && MatchTopic(topic, record.Type)) {
+				batch = append(batch, Event{
+					Type:      record.Type,
+					Data:      record.Data,
+					Sequence:  record.Sequence,
+					Timestamp: record.Timestamp,
+				})
+			}
+			return len(batch) == replayBatchSize
+		})
+		if err == nil {
+			err = decodeErr
+		}
+		if err != nil {
+			return fmt.Errorf("failed to replay the event log: %w", err)
+		}
+
+		for _, event := range batch {
+			handler(event)
+		}
+		if len(batch) < replayBatchSize {
+			return nil
+		}
+	}
+	return nil
+}
+
+// sequenceKey returns the key of the logged event with the given sequence number, ordered as the numbers.
+func sequenceKey(sequence uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, sequence)
+	return key
+}
@@ -0,0 +1,70 @@
+package event
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// TopicSeparator separates the segments of hierarchical topics, such as "etl.process.extracted".
+	TopicSeparator = "."
+
+	// SingleSegmentWildcard matches exactly one segment of a topic in a topic pattern.
+	SingleSegmentWildcard = "*"
+
+	// MultiSegmentWildcard matches zero or more segments of a topic in a topic pattern.
+	MultiSegmentWildcard = "#"
+)
+
+// IsTopicPattern reports whether the topic holds wildcard segments.
+func IsTopicPattern(topic string) bool {
+	for _, segment := range strings.Split(topic, TopicSeparator) {
+		if segment == SingleSegmentWildcard || segment == MultiSegmentWildcard {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchTopic reports whether the topic matches the pattern. In a pattern, the segment "*" matches
+// exactly one segment of the topic and the segment "#" matches zero or more segments, so that
+// "etl.process.*" matches "etl.process.extracted" and "system.#" matches every system topic.
+// Other segments match themselves.
+func MatchTopic(pattern, topic string) bool {
+	return matchSegments(strings.Split(pattern, TopicSeparator), strings.Split(topic, TopicSeparator))
+}
+
+// matchSegments reports whether the segments of a topic match those of a pattern.
+func matchSegments(pattern, topic []string) bool {
+	if len(pattern) == 0 {
+		return len(topic) == 0
+	}
+	switch pattern[0] {
+	case MultiSegmentWildcard:
+		for i := 0; i <= len(topic); i++ {
+			if matchSegments(pattern[1:], topic[i:]) {
+				return true
+			}
+		}
+		return false
+	case SingleSegmentWildcard:
+		return len(topic) > 0 && matchSegments(pattern[1:], topic[1:])
+	default:
+		return len(topic) > 0 && pattern[0] == topic[0] && matchSegments(pattern[1:], topic[1:])
+	}
+}
+
+// validatePattern checks that the segments of the pattern are not empty, and that wildcards are
+// whole segments.
+func validatePattern(pattern string) error {
+	for _, segment := range strings.Split(pattern, TopicSeparator) {
+		if segment == "" {
+			return fmt.Errorf("%w: empty segment in topic pattern %q", ErrInvalidSubscription, pattern)
+		}
+		if segment != SingleSegmentWildcard && segment != MultiSegmentWildcard &&
+			strings.ContainsAny(segment, SingleSegmentWildcard+MultiSegmentWildcard) {
+			return fmt.Errorf("%w: wildcards must be whole segments in topic pattern %q", ErrInvalidSubscription, pattern)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,300 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Codec serializes the payloads of a topic, so that its events can be delivered to other processes.
+type Codec interface {
+	// Name returns the name of the serialization format.
+	Name() string
+
+	// Marshal encodes the given payload.
+	Marshal(payload interface{}) ([]byte, error)
+
+	// Unmarshal decodes the data into the payload pointed to by v.
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// JSONCodec serializes payloads as JSON. It is the codec of the topics registered without one.
+type JSONCodec struct{}
+
+// Name returns the name of the serialization format.
+func (JSONCodec) Name() string {
+	return "json"
+}
+
+// Marshal encodes the given payload.
+func (JSONCodec) Marshal(payload interface{}) ([]byte, error) {
+	return json.Marshal(payload)
+}
+
+// Unmarshal decodes the data into the payload pointed to by v.
+func (JSONCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// ProtoCodec serializes payloads as protocol buffers. The payloads must be protobuf messages.
+type ProtoCodec struct{}
+
+// Name returns the name of the serialization format.
+func (ProtoCodec) Name() string {
+	return "protobuf"
+}
+
+// Marshal encodes the given payload.
+func (ProtoCodec) Marshal(payload interface{}) ([]byte, error) {
+	message, ok := payload.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T is not a protobuf message", ErrInvalidPayload, payload)
+	}
+	return proto.Marshal(message)
+}
+
+// Unmarshal decodes the data into the payload pointed to by v, either a protobuf message or a
+// pointer to a pointer to a protobuf message, in which case the message is allocated.
+func (ProtoCodec) Unmarshal(data []byte, v interface{}) error {
+	if message, ok := v.(proto.Message); ok {
+		return proto.Unmarshal(data, message)
+	}
+
+	target := reflect.ValueOf(v)
+	if target.Kind() == reflect.Ptr && target.Elem().Kind() == reflect.Ptr {
+		allocated := reflect.New(target.Elem().Type().Elem())
+		if message, ok := allocated.Interface().(proto.Message); ok {
+			if err := proto.Unmarshal(data, message); err != nil {
+				return err
+			}
+			target.Elem().Set(allocated)
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %T is not a protobuf message", ErrInvalidPayload, v)
+}
+
+// Topic is an event topic whose events carry a payload of type T.
+type Topic[T any] struct {
+	name string
+}
+
+// NewTopic creates a topic with the given name carrying payloads of type T.
+func NewTopic[T any](name string) Topic[T] {
+	return Topic[T]{name: name}
+}
+
+// Name returns the name of the topic, which is the type of its events.
+func (t Topic[T]) Name() string {
+	return t.name
+}
+
+// topicSchema is the payload type and codec of a registered topic.
+type topicSchema struct {
+	payload reflect.Type
+	codec   Codec
+}
+
+// TopicRegistry records the payload type and the codec of the topics of an event bus.
+// Events of a registered topic must carry a payload of its type. It is safe for concurrent use.
+type TopicRegistry struct {
+	mutex  sync.RWMutex
+	topics map[string]topicSchema
+}
+
+// NewTopicRegistry creates an empty topic registry.
+func NewTopicRegistry() *TopicRegistry {
+	return &TopicRegistry{
+		topics: make(map[string]topicSchema),
+	}
+}
+
+// RegisterTopic registers the topic with its payload type and the codec of its payloads, JSONCodec
+// if nil. Registering a topic again with the same payload type is a no-op, and fails with
+// ErrTopicAlreadyRegistered for another payload type.
+func RegisterTopic[T any](registry *TopicRegistry, topic Topic[T], codec Codec) error {
+	payload := reflect.TypeOf((*T)(nil)).Elem()
+	if codec == nil {
+		codec = JSONCodec{}
+	}
+	if _, ok := codec.(ProtoCodec); ok && !payload.Implements(reflect.TypeOf((*proto.Message)(nil)).Elem()) {
+		return fmt.Errorf("%w: %s is not a protobuf message", ErrInvalidPayload, payload)
+	}
+	return registry.register(topic.name, topicSchema{payload: payload, codec: codec})
+}
+
+// Topics returns the names of the registered topics, in lexical order.
+func (r *TopicRegistry) Topics() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	names := make([]string, 0, len(r.topics))
+	for name := range r.topics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PayloadType returns the payload type of the given topic, and whether the topic is registered.
+func (r *TopicRegistry) PayloadType(topic string) (reflect.Type, bool) {
+	schema, ok := r.schema(topic)
+	return schema.payload, ok
+}
+
+// Validate checks that the payload of the event has the type of its topic. Events of topics that
+// are not registered are valid, as are events replayed from an event log, whose payload is JSON.
+func (r *TopicRegistry) Validate(event Event) error {
+	schema, ok := r.schema(event.Type)
+	if !ok {
+		return nil
+	}
+	if _, raw := event.Data.(json.RawMessage); raw {
+		return nil
+	}
+	if event.Data == nil {
+		switch schema.payload.Kind() {
+		case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice:
+			return nil
+		}
+	} else if reflect.TypeOf(event.Data).AssignableTo(schema.payload) {
+		return nil
+	}
+	return fmt.Errorf("%w: topic %s carries %s, got %T", ErrPayloadTypeMismatch, event.Type, schema.payload, event.Data)
+}
+
+// Marshal encodes the payload of the event with the codec of its topic.
+func (r *TopicRegistry) Marshal(event Event) ([]byte, error) {
+	schema, ok := r.schema(event.Type)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrTopicNotRegistered, event.Type)
+	}
+	if err := r.Validate(event); err != nil {
+		return nil, err
+	}
+	if raw, ok := event.Data.(json.RawMessage); ok {
+		if _, isJSON := schema.codec.(JSONCodec); isJSON {
+			return raw, nil
+		}
+		if err := r.decodeJSON(event.Type, raw, &event); err != nil {
+			return nil, err
+		}
+	}
+	data, err := schema.codec.Marshal(event.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode payload of topic %s as %s: %w", event.Type, schema.codec.Name(), err)
+	}
+	return data, nil
+}
+
+// Unmarshal decodes an event of the topic from a payload encoded with the codec of the topic.
+func (r *TopicRegistry) Unmarshal(topic string, data []byte) (Event, error) {
+	schema, ok := r.schema(topic)
+	if !ok {
+		return Event{}, fmt.Errorf("%w: %s", ErrTopicNotRegistered, topic)
+	}
+	payload := reflect.New(schema.payload)
+	if err := schema.codec.Unmarshal(data, payload.Interface()); err != nil {
+		return Event{}, fmt.Errorf("failed to decode payload of topic %s as %s: %w", topic, schema.codec.Name(), err)
+	}
+	return Event{Type: topic, Data: payload.Elem().Interface()}, nil
+}
+
+// register registers the schema of the given topic.
+func (r *TopicRegistry) register(topic string, schema topicSchema) error {
+	if topic == "" {
+		return fmt.Errorf("%w: a topic name is required", ErrInvalidSubscription)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if registered, exists := r.topics[topic]; exists {
+		if registered.payload != schema.payload {
+			return fmt.Errorf("%w: %s carries %s", ErrTopicAlreadyRegistered, topic, registered.payload)
+		}
+		return nil
+	}
+	r.topics[topic] = schema
+	return nil
+}
+
+// schema returns the schema of the given topic, and whether the topic is registered.
+func (r *TopicRegistry) schema(topic string) (topicSchema, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	schema, ok := r.topics[topic]
+	return schema, ok
+}
+
+// decodeJSON replaces the JSON payload of the event with a payload of the type of its topic.
+func (r *TopicRegistry) decodeJSON(topic string, raw json.RawMessage, event *Event) error {
+	payloadType, _ := r.PayloadType(topic)
+	payload := reflect.New(payloadType)
+	if err := json.Unmarshal(raw, payload.Interface()); err != nil {
+		return fmt.Errorf("failed to decode payload of topic %s: %w", topic, err)
+	}
+	event.Data = payload.Elem().Interface()
+	return nil
+}
+
+// Publish publishes the payload on the topic of the bus. It fails with ErrTopicNotRegistered if the
+// topic is not registered on the bus with the payload type T.
+func Publish[T any](bus EventBusInterface, topic Topic[T], payload T) error {
+	if err := checkTopic(bus, topic); err != nil {
+		return err
+	}
+	return bus.Publish(Event{Type: topic.name, Data: payload})
+}
+
+// Subscribe subscribes the handler to the topic of the bus. The handler receives the payload of the
+// events along with the events, the JSON payloads of replayed events being decoded. It fails with
+// ErrTopicNotRegistered if the topic is not registered on the bus with the payload type T.
+func Subscribe[T any](bus EventBusInterface, topic Topic[T], handler func(payload T, event Event)) (*Subscription, error) {
+	if err := checkTopic(bus, topic); err != nil {
+		return nil, err
+	}
+	if handler == nil {
+		return nil, fmt.Errorf("%w: a topic and an event handler are required", ErrInvalidSubscription)
+	}
+	return bus.Subscribe(BusSubscriptionParams{
+		Topic:        topic.name,
+		EventHandler: TypedHandler(handler),
+	})
+}
+
+// TypedHandler adapts a handler of payloads of type T to an EventHandler, so that it can be given to
+// the other Subscribe methods of a bus. Events whose payload cannot be converted to T are ignored.
+func TypedHandler[T any](handler func(payload T, event Event)) EventHandler {
+	return func(event Event) {
+		switch data := event.Data.(type) {
+		case T:
+			handler(data, event)
+		case nil:
+			var zero T
+			handler(zero, event)
+		case json.RawMessage:
+			var payload T
+			if err := event.Decode(&payload); err == nil {
+				handler(payload, event)
+			}
+		}
+	}
+}
+
+// checkTopic checks that the topic is registered on the bus with the payload type T.
+func checkTopic[T any](bus EventBusInterface, topic Topic[T]) error {
+	registered, ok := bus.Topics().PayloadType(topic.name)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrTopicNotRegistered, topic.name)
+	}
+	if payload := reflect.TypeOf((*T)(nil)).Elem(); registered != payload {
+		return fmt.Errorf("%w: topic %s carries %s, not %s", ErrPayloadTypeMismatch, topic.name, registered, payload)
+	}
+	return nil
+}
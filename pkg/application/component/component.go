@@ -25,6 +25,24 @@ const (
 	ApplicationComponentType
 )
 
+// String returns the string representation of the component type.
+func (t ComponentType) String() string {
+	switch t {
+	case BasicComponentType:
+		return "basic"
+	case SystemComponentType:
+		return "system"
+	case OperationType:
+		return "operation"
+	case ServiceType:
+		return "service"
+	case ApplicationComponentType:
+		return "application"
+	default:
+		return "unknown"
+	}
+}
+
 // ComponentInterface represents a generic component in the system.
 type ComponentInterface interface {
 	// ID returns the unique identifier of the component.
@@ -64,3 +82,30 @@ type StartableInterface interface {
 	// Returns an error if the stop operation fails.
 	Stop(ctx *context.Context) error
 }
+
+// DisposableInterface defines the interface for instances holding resources to release
+// when they are removed from the registry.
+type DisposableInterface interface {
+	// Dispose releases the resources held by the instance.
+	// Returns an error if the resources could not be released.
+	Dispose(ctx *context.Context) error
+}
+
+// DependentInterface defines the interface for instances that depend on other components.
+type DependentInterface interface {
+	// Dependencies returns the IDs of the components this instance depends on.
+	Dependencies() []string
+}
+
+// HealthStatus represents the health of an instance at a point in time.
+type HealthStatus struct {
+	Live   bool   // Live reports whether the instance is running
+	Ready  bool   // Ready reports whether the instance is able to do its work
+	Reason string // Reason explains the reported status
+}
+
+// HealthReporterInterface defines the interface for instances that report their health.
+type HealthReporterInterface interface {
+	// Health returns the current health of the instance.
+	Health(ctx *context.Context) HealthStatus
+}
@@ -0,0 +1,159 @@
+package component
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	configApi "github.com/edward1christian/block-forge/pkg/application/config"
+)
+
+// Dependency declares a collaborator that the registrar injects into a component when creating it.
+// The dependency is resolved by component ID when ComponentID is set, otherwise by the interface
+// it implements. If it cannot be found and Config is set, it is created from Config first.
+type Dependency struct {
+	Name        string                     // Name under which the dependency is injected
+	ComponentID string                     // ID of the component to inject
+	Interface   reflect.Type               // Interface the injected component must implement
+	Optional    bool                       // Whether the component may be created without the dependency
+	Config      *configApi.ComponentConfig // Configuration used to create the dependency if it is not registered
+}
+
+// Dependencies holds the resolved dependencies of a component, by name.
+type Dependencies map[string]ComponentInterface
+
+// InjectableFactoryInterface is a component factory declaring the dependencies of its components.
+// The registrar resolves the dependencies and creates the component with CreateInjectedComponent.
+type InjectableFactoryInterface interface {
+	ComponentFactoryInterface
+
+	// Dependencies returns the dependencies of the component created with the given configuration.
+	Dependencies(config *configApi.ComponentConfig) []Dependency
+
+	// CreateInjectedComponent creates a new instance of the component with its resolved dependencies.
+	// Returns the created component and an error if the creation fails.
+	CreateInjectedComponent(config *configApi.ComponentConfig, dependencies Dependencies) (ComponentInterface, error)
+}
+
+// InterfaceOf returns the reflection type of the interface T, to be used as Dependency.Interface.
+func InterfaceOf[T any]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// DependencyAs returns the dependency with the given name as a T.
+// It returns an error if the dependency was not resolved or is not a T.
+func DependencyAs[T any](dependencies Dependencies, name string) (T, error) {
+	var zero T
+	dependency, exists := dependencies[name]
+	if !exists {
+		return zero, fmt.Errorf("%w: %s", ErrMissingDependency, name)
+	}
+	value, ok := dependency.(T)
+	if !ok {
+		return zero, fmt.Errorf("%w: %s does not implement %s", ErrMissingDependency, name, InterfaceOf[T]())
+	}
+	return value, nil
+}
+
+// String returns a description of the dependency for error messages.
+func (d Dependency) String() string {
+	switch {
+	case d.ComponentID != "":
+		return fmt.Sprintf("%s (component %s)", d.Name, d.ComponentID)
+	case d.Interface != nil:
+		return fmt.Sprintf("%s (%s)", d.Name, d.Interface)
+	case d.Config != nil:
+		return fmt.Sprintf("%s (component %s)", d.Name, d.Config.ID)
+	default:
+		return d.Name
+	}
+}
+
+// resolveDependencies resolves the dependencies of the component created with the given
+// configuration. The path holds the IDs of the components being created, to detect cycles.
+func (cr *ComponentRegistrar) resolveDependencies(ctx *context.Context, config *configApi.ComponentConfig,
+	dependencies []Dependency, path []string) (Dependencies, error) {
+	resolved := make(Dependencies, len(dependencies))
+	path = append(path, config.ID)
+
+	for _, dependency := range dependencies {
+		component, err := cr.resolveDependency(ctx, dependency, path)
+		if err != nil {
+			return nil, err
+		}
+		if component == nil {
+			if dependency.Optional {
+				continue
+			}
+			return nil, fmt.Errorf("%w: %s requires %s", ErrMissingDependency, config.ID, dependency)
+		}
+		if dependency.Interface != nil && !reflect.TypeOf(component).Implements(dependency.Interface) {
+			return nil, fmt.Errorf("%w: %s requires %s, but %s does not implement it",
+				ErrMissingDependency, config.ID, dependency, component.ID())
+		}
+		resolved[dependency.Name] = component
+	}
+	return resolved, nil
+}
+
+// resolveDependency finds the component satisfying the given dependency, creating it from the
+// dependency configuration if needed. It returns nil if the dependency cannot be satisfied.
+func (cr *ComponentRegistrar) resolveDependency(ctx *context.Context, dependency Dependency, path []string) (ComponentInterface, error) {
+	id := dependency.ComponentID
+	if id == "" && dependency.Config != nil {
+		id = dependency.Config.ID
+	}
+
+	// Components being created cannot be injected
+	for i, pathID := range path {
+		if id != "" && pathID == id {
+			cycle := append(append([]string{}, path[i:]...), id)
+			return nil, fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(cycle, " -> "))
+		}
+	}
+
+	// Look the dependency up by ID, or by interface
+	if dependency.ComponentID != "" {
+		if component, err := cr.GetComponent(dependency.ComponentID); err == nil {
+			return component, nil
+		}
+	} else if dependency.Interface != nil {
+		component, err := cr.findImplementation(dependency)
+		if component != nil || err != nil {
+			return component, err
+		}
+	}
+
+	// Create the dependency if it is not registered
+	if dependency.Config == nil {
+		return nil, nil
+	}
+	return cr.createComponent(ctx, dependency.Config, path)
+}
+
+// findImplementation returns the only visible component implementing the interface of the
+// given dependency, or nil if there is none.
+func (cr *ComponentRegistrar) findImplementation(dependency Dependency) (ComponentInterface, error) {
+	var candidates []ComponentInterface
+	for _, component := range cr.GetAllComponents() {
+		if reflect.TypeOf(component).Implements(dependency.Interface) {
+			candidates = append(candidates, component)
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil, nil
+	case 1:
+		return candidates[0], nil
+	default:
+		ids := make([]string, 0, len(candidates))
+		for _, candidate := range candidates {
+			ids = append(ids, candidate.ID())
+		}
+		sort.Strings(ids)
+		return nil, fmt.Errorf("%w: %s is implemented by %s", ErrAmbiguousDependency, dependency, strings.Join(ids, ", "))
+	}
+}
@@ -0,0 +1,18 @@
+package component
+
+import "errors"
+
+// Custom errors
+var (
+	ErrComponentNotFound     = errors.New("component not found")
+	ErrFactoryNotFound       = errors.New("factory not found")
+	ErrFactoryAlreadyExists  = errors.New("factory already exists")
+	ErrComponentStopFailed   = errors.New("component failed to stop")
+	ErrScopeNotFound         = errors.New("scope not found")
+	ErrScopeAlreadyExists    = errors.New("scope already exists")
+	ErrMissingDependency     = errors.New("missing dependency")
+	ErrAmbiguousDependency   = errors.New("ambiguous dependency")
+	ErrDependencyCycle       = errors.New("dependency cycle")
+	ErrComponentTypeMismatch = errors.New("component type mismatch")
+	ErrInvalidSelector       = errors.New("invalid label selector")
+)
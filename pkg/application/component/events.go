@@ -0,0 +1,59 @@
+package component
+
+import (
+	"errors"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/event"
+	"github.com/edward1christian/block-forge/pkg/application/common/logger"
+)
+
+const (
+	// EventTypeFactoryRegistered represents an event emitted when a component factory is registered.
+	EventTypeFactoryRegistered string = event.NamespaceSystem + ".registry.factory_registered"
+
+	// EventTypeFactoryUnregistered represents an event emitted when a component factory is unregistered.
+	EventTypeFactoryUnregistered string = event.NamespaceSystem + ".registry.factory_unregistered"
+
+	// EventTypeComponentCreated represents an event emitted when a component is created and registered.
+	EventTypeComponentCreated string = event.NamespaceSystem + ".registry.component_created"
+
+	// EventTypeComponentRemoved represents an event emitted when a component is removed from the registry.
+	EventTypeComponentRemoved string = event.NamespaceSystem + ".registry.component_removed"
+)
+
+// Topics of the events published by the component registrar, carrying a RegistryEvent.
+var (
+	FactoryRegisteredTopic   = event.NewTopic[RegistryEvent](EventTypeFactoryRegistered)
+	FactoryUnregisteredTopic = event.NewTopic[RegistryEvent](EventTypeFactoryUnregistered)
+	ComponentCreatedTopic    = event.NewTopic[RegistryEvent](EventTypeComponentCreated)
+	ComponentRemovedTopic    = event.NewTopic[RegistryEvent](EventTypeComponentRemoved)
+)
+
+// RegisterTopics registers the topics of the registrar events in the given topic registry.
+func RegisterTopics(registry *event.TopicRegistry) error {
+	return errors.Join(
+		event.RegisterTopic(registry, FactoryRegisteredTopic, nil),
+		event.RegisterTopic(registry, FactoryUnregisteredTopic, nil),
+		event.RegisterTopic(registry, ComponentCreatedTopic, nil),
+		event.RegisterTopic(registry, ComponentRemovedTopic, nil),
+	)
+}
+
+// RegistryEvent is the payload of the events published by the component registrar.
+type RegistryEvent struct {
+	FactoryID     string            // ID of the factory registered, unregistered or that created the component
+	ComponentID   string            // ID of the component, empty for factory events
+	ComponentType ComponentType     // Type of the component, for component events
+	Owner         string            // Owner of the factory or component, typically the ID of a plugin
+	Labels        map[string]string // Labels of the component, for component events
+	Scope         string            // ID of the registry scope, empty for the root registry
+}
+
+// EventSourceInterface is implemented by registries publishing their changes on an event bus.
+type EventSourceInterface interface {
+	// SetEventBus sets the event bus on which changes are published.
+	SetEventBus(bus event.EventBusInterface)
+
+	// SetLogger sets the logger with which failures to publish changes are logged.
+	SetLogger(log logger.LoggerInterface)
+}
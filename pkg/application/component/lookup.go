@@ -0,0 +1,55 @@
+package component
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ComponentAs returns the given component as a T.
+// It returns an error wrapping ErrComponentTypeMismatch if the component is not a T.
+func ComponentAs[T any](component ComponentInterface) (T, error) {
+	value, ok := component.(T)
+	if !ok {
+		var zero T
+		return zero, fmt.Errorf("%w: %s is not a %s", ErrComponentTypeMismatch, component.ID(), InterfaceOf[T]())
+	}
+	return value, nil
+}
+
+// GetComponentAs retrieves the component with the specified ID as a T.
+// It returns an error wrapping ErrComponentNotFound if the component is not registered,
+// or ErrComponentTypeMismatch if it is not a T.
+func GetComponentAs[T any](registrar ComponentRegistrarInterface, id string) (T, error) {
+	component, err := registrar.GetComponent(id)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return ComponentAs[T](component)
+}
+
+// MustGetComponentAs retrieves the component with the specified ID as a T.
+// It panics if the component is not registered or is not a T.
+func MustGetComponentAs[T any](registrar ComponentRegistrarInterface, id string) T {
+	value, err := GetComponentAs[T](registrar, id)
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// ListComponentsAs returns the registered components that are a T, sorted by ID.
+func ListComponentsAs[T any](registrar ComponentRegistrarInterface) []T {
+	components := registrar.GetAllComponents()
+	sort.Slice(components, func(i, j int) bool {
+		return components[i].ID() < components[j].ID()
+	})
+
+	values := []T{}
+	for _, component := range components {
+		if value, ok := component.(T); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,21 @@
+package component
+
+import "github.com/edward1christian/block-forge/pkg/application/common/context"
+
+// ownerContextKey is the context key under which the owner of created components is stored.
+type ownerContextKey struct{}
+
+// WithOwner returns a new Context recording the given owner, typically a plugin ID,
+// for the components created with it.
+func WithOwner(ctx *context.Context, owner string) *context.Context {
+	return ctx.WithValue(ownerContextKey{}, owner)
+}
+
+// OwnerFromContext returns the owner recorded in the context, or an empty string if none.
+func OwnerFromContext(ctx *context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	owner, _ := ctx.Value(ownerContextKey{}).(string)
+	return owner
+}
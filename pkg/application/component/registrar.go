@@ -1,10 +1,15 @@
 package component
 
 import (
+	"errors"
 	"fmt"
+	"maps"
+	"sort"
 	"sync"
 
 	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	"github.com/edward1christian/block-forge/pkg/application/common/event"
+	"github.com/edward1christian/block-forge/pkg/application/common/logger"
 	configApi "github.com/edward1christian/block-forge/pkg/application/config"
 )
 
@@ -14,6 +19,13 @@ type ComponentCreationInfo struct {
 	Factory ComponentFactoryInterface
 }
 
+// ComponentInfo describes how a registered component was created.
+type ComponentInfo struct {
+	FactoryID string            // ID of the factory that created the component
+	Owner     string            // Owner of the component, typically the ID of the plugin that created it
+	Labels    map[string]string // Labels of the component, from the component and its configuration
+}
+
 // ComponentRegistrarInterface defines the registry functionality for components and factories.
 type ComponentRegistrarInterface interface {
 	// GetComponentsByType retrieves components of the specified type.
@@ -27,6 +39,13 @@ type ComponentRegistrarInterface interface {
 	// GetAllComponents returns a list of all registered components.
 	GetAllComponents() []ComponentInterface
 
+	// GetComponentsBySelector retrieves the components whose labels match the selector, sorted by ID.
+	GetComponentsBySelector(selector Selector) []ComponentInterface
+
+	// GetComponentInfo retrieves how the component with the specified ID was created.
+	// It returns the information and an error if the component ID is not found.
+	GetComponentInfo(id string) (ComponentInfo, error)
+
 	// GetFactory retrieves the factory with the specified ID.
 	// It returns the factory and an error if the factory ID is not found or other error.
 	GetFactory(id string) (ComponentFactoryInterface, error)
@@ -35,17 +54,48 @@ type ComponentRegistrarInterface interface {
 	// It returns an error if the registration fails.
 	RegisterFactory(ctx *context.Context, id string, factory ComponentFactoryInterface) error
 
-	// UnregisterFactory unregisters a factory with the specified ID.
+	// UnregisterFactory unregisters a factory with the specified ID, removing the components it created.
 	// It returns an error if the ID is not found or other error.
 	UnregisterFactory(ctx *context.Context, id string) error
 
+	// UnregisterOwner removes the components owned by the given owner, then unregisters the
+	// factories it owns along with the components they created.
+	// It returns an error if any of the components could not be removed.
+	UnregisterOwner(ctx *context.Context, owner string) error
+
 	// CreateComponent creates a component with the given ID and factory ID.
 	// It returns the created component and an error if the creation fails.
 	CreateComponent(ctx *context.Context, config *configApi.ComponentConfig) (ComponentInterface, error)
 
 	// RemoveComponent removes a component with the specified ID from the registry.
+	// A running startable component is stopped and a disposable component is disposed first.
+	// Components that do not report their health are only stopped if they were recorded as started.
 	// It returns an error if the ID is not found or other error.
 	RemoveComponent(ctx *context.Context, id string) error
+
+	// CreateScope creates a child registry with the specified ID. Components and factories
+	// are resolved in the child first, then in this registry.
+	// It returns the child registry and an error if a scope with the same ID already exists.
+	CreateScope(id string) (ComponentRegistrarInterface, error)
+
+	// GetScope retrieves the child registry with the specified ID.
+	// It returns the child registry and an error if the scope ID is not found.
+	GetScope(id string) (ComponentRegistrarInterface, error)
+
+	// TearDown removes the child scopes and the components of the registry, most recent first,
+	// and detaches the registry from its parent.
+	// It returns an error if any of the components could not be removed.
+	TearDown(ctx *context.Context) error
+}
+
+// StartTrackerInterface is implemented by registries tracking which of their components are started,
+// so that components that were never started are not stopped when they are removed.
+type StartTrackerInterface interface {
+	// SetStarted records whether the component with the specified ID is started.
+	SetStarted(id string, started bool)
+
+	// Started returns whether the component with the specified ID is recorded as started.
+	Started(id string) bool
 }
 
 // ComponentRegistrar defines the registry functionality for components and factories.
@@ -54,15 +104,162 @@ type ComponentRegistrar struct {
 	factoriesMutex  sync.RWMutex
 	componentsMutex sync.RWMutex
 	factories       map[string]ComponentFactoryInterface
+	factoryOwners   map[string]string // Owner of the factories, by factory ID
 	components      map[string]ComponentInterface
+	infos           map[string]ComponentInfo // Creation information, by component ID
+	sequences       map[string]uint64        // Creation order, by component ID
+	started         map[string]bool          // Components recorded as started, by component ID
+	sequence        uint64
+	id              string              // ID of the scope, empty for the root registry
+	parent          *ComponentRegistrar // Registry to fall back to, nil for the root registry
+	scopesMutex     sync.RWMutex
+	scopes          map[string]*ComponentRegistrar // Child registries, by scope ID
+	eventBusMutex   sync.RWMutex
+	eventBus        event.EventBusInterface // Bus on which changes are published, inherited by scopes
+	logger          logger.LoggerInterface  // Logger of the failures to publish changes, inherited by scopes
 }
 
 // NewComponentRegistrar creates a new instance of ComponentRegistrar.
 func NewComponentRegistrar() *ComponentRegistrar {
 	return &ComponentRegistrar{
-		factories:  make(map[string]ComponentFactoryInterface),
-		components: make(map[string]ComponentInterface),
+		factories:     make(map[string]ComponentFactoryInterface),
+		factoryOwners: make(map[string]string),
+		components:    make(map[string]ComponentInterface),
+		infos:         make(map[string]ComponentInfo),
+		sequences:     make(map[string]uint64),
+		started:       make(map[string]bool),
+		scopes:        make(map[string]*ComponentRegistrar),
+	}
+}
+
+// SetEventBus sets the event bus on which the registry publishes the registration and removal
+// of factories and components. Scopes without an event bus of their own publish on the bus of
+// their parent.
+func (cr *ComponentRegistrar) SetEventBus(bus event.EventBusInterface) {
+	cr.eventBusMutex.Lock()
+	defer cr.eventBusMutex.Unlock()
+	cr.eventBus = bus
+}
+
+// SetLogger sets the logger with which the registry logs the failures to publish its changes.
+// Scopes without a logger of their own log with the logger of their parent.
+func (cr *ComponentRegistrar) SetLogger(log logger.LoggerInterface) {
+	cr.eventBusMutex.Lock()
+	defer cr.eventBusMutex.Unlock()
+	cr.logger = log
+}
+
+// publish publishes the given registry event on the event bus of the registry, if any.
+func (cr *ComponentRegistrar) publish(eventType string, data RegistryEvent) {
+	for registrar := cr; registrar != nil; registrar = registrar.parent {
+		registrar.eventBusMutex.RLock()
+		bus := registrar.eventBus
+		registrar.eventBusMutex.RUnlock()
+		if bus != nil {
+			data.Scope = cr.id
+			err := bus.Publish(event.Event{Type: eventType, Data: data})
+			if log := cr.inheritedLogger(); err != nil && log != nil {
+				log.Log(logger.LevelError, "Failed to publish registry event:", eventType, err)
+			}
+			return
+		}
+	}
+}
+
+// inheritedLogger returns the logger of the registry or of its closest ancestor having one, if any.
+func (cr *ComponentRegistrar) inheritedLogger() logger.LoggerInterface {
+	for registrar := cr; registrar != nil; registrar = registrar.parent {
+		registrar.eventBusMutex.RLock()
+		log := registrar.logger
+		registrar.eventBusMutex.RUnlock()
+		if log != nil {
+			return log
+		}
+	}
+	return nil
+}
+
+// ID returns the ID of the scope of the registry, or an empty string for the root registry.
+func (cr *ComponentRegistrar) ID() string {
+	return cr.id
+}
+
+// Parent returns the registry the registry falls back to, or nil for the root registry.
+func (cr *ComponentRegistrar) Parent() *ComponentRegistrar {
+	return cr.parent
+}
+
+// CreateScope creates a child registry with the specified ID.
+// Components created in the child are registered in the child only, so that they may
+// reuse the IDs of components of other scopes. Lookups fall back to this registry.
+func (cr *ComponentRegistrar) CreateScope(id string) (ComponentRegistrarInterface, error) {
+	cr.scopesMutex.Lock()
+	defer cr.scopesMutex.Unlock()
+
+	// Check if the scope already exists
+	if _, exists := cr.scopes[id]; exists {
+		return nil, fmt.Errorf("%w: %s", ErrScopeAlreadyExists, id)
+	}
+
+	// Register the scope
+	scope := NewComponentRegistrar()
+	scope.id = id
+	scope.parent = cr
+	cr.scopes[id] = scope
+	return scope, nil
+}
+
+// GetScope retrieves the child registry with the specified ID.
+func (cr *ComponentRegistrar) GetScope(id string) (ComponentRegistrarInterface, error) {
+	cr.scopesMutex.RLock()
+	defer cr.scopesMutex.RUnlock()
+
+	// Check if the scope exists
+	scope, exists := cr.scopes[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrScopeNotFound, id)
+	}
+	return scope, nil
+}
+
+// TearDown removes the child scopes and then the components of the registry, in reverse
+// creation order, and detaches the registry from its parent. Factories are left registered.
+// The registry stays attached if any of its components could not be removed.
+func (cr *ComponentRegistrar) TearDown(ctx *context.Context) error {
+	var errs []error
+
+	// Tear down the child scopes first, as their components may depend on ours
+	cr.scopesMutex.RLock()
+	scopes := make([]*ComponentRegistrar, 0, len(cr.scopes))
+	for _, scope := range cr.scopes {
+		scopes = append(scopes, scope)
+	}
+	cr.scopesMutex.RUnlock()
+	for _, scope := range scopes {
+		if err := scope.TearDown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	// Remove the components of the registry
+	for _, componentID := range cr.componentsMatching(func(ComponentInfo) bool { return true }) {
+		if err := cr.RemoveComponent(ctx, componentID); err != nil && !errors.Is(err, ErrComponentNotFound) {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to tear down scope %s: %w", cr.id, errors.Join(errs...))
+	}
+
+	// Detach from the parent
+	if cr.parent != nil {
+		cr.parent.scopesMutex.Lock()
+		defer cr.parent.scopesMutex.Unlock()
+		if cr.parent.scopes[cr.id] == cr {
+			delete(cr.parent.scopes, cr.id)
+		}
 	}
+	return nil
 }
 
 // GetComponent retrieves the component with the specified ID.
@@ -70,25 +267,40 @@ func (cr *ComponentRegistrar) GetComponent(id string) (ComponentInterface, error
 	cr.componentsMutex.RLock()
 	defer cr.componentsMutex.RUnlock()
 
-	// Check if the component exists
+	// Check if the component exists, then fall back to the parent
 	component, exists := cr.components[id]
 	if !exists {
-		return nil, fmt.Errorf("component with ID %s not found", id)
+		if cr.parent != nil {
+			return cr.parent.GetComponent(id)
+		}
+		return nil, fmt.Errorf("%w: %s", ErrComponentNotFound, id)
 	}
 	return component, nil
 }
 
-// GetComponentsByType retrieves components of the specified type.
-func (cr *ComponentRegistrar) GetComponentsByType(componentType ComponentType) []ComponentInterface {
-	// Lock the components mutex for reading to prevent concurrent access while reading
+// GetComponentInfo retrieves how the component with the specified ID was created.
+func (cr *ComponentRegistrar) GetComponentInfo(id string) (ComponentInfo, error) {
 	cr.componentsMutex.RLock()
 	defer cr.componentsMutex.RUnlock()
 
+	// Check if the component exists, then fall back to the parent
+	if _, exists := cr.components[id]; !exists {
+		if cr.parent != nil {
+			return cr.parent.GetComponentInfo(id)
+		}
+		return ComponentInfo{}, fmt.Errorf("%w: %s", ErrComponentNotFound, id)
+	}
+	return cr.infos[id], nil
+}
+
+// GetComponentsByType retrieves components of the specified type.
+// The components of the parent are included unless shadowed by a component with the same ID.
+func (cr *ComponentRegistrar) GetComponentsByType(componentType ComponentType) []ComponentInterface {
 	// Initialize an empty slice to store components of the specified type
 	components := []ComponentInterface{}
 
-	// Iterate over all registered components
-	for _, component := range cr.components {
+	// Iterate over all visible components
+	for _, component := range cr.GetAllComponents() {
 		// Check if the type of the component matches the specified type
 		if component.Type() == componentType {
 			// If the type matches, add the component to the slice
@@ -100,11 +312,28 @@ func (cr *ComponentRegistrar) GetComponentsByType(componentType ComponentType) [
 	return components
 }
 
+// GetComponentsBySelector retrieves the components whose labels match the selector, sorted by ID.
+// The components of the parent are included unless shadowed by a component with the same ID.
+func (cr *ComponentRegistrar) GetComponentsBySelector(selector Selector) []ComponentInterface {
+	components := []ComponentInterface{}
+	for _, component := range cr.GetAllComponents() {
+		info, err := cr.GetComponentInfo(component.ID())
+		if err == nil && selector.Matches(info.Labels) {
+			components = append(components, component)
+		}
+	}
+
+	sort.Slice(components, func(i, j int) bool {
+		return components[i].ID() < components[j].ID()
+	})
+	return components
+}
+
 // GetAllComponents returns a list of all registered components.
+// The components of the parent are included unless shadowed by a component with the same ID.
 func (cr *ComponentRegistrar) GetAllComponents() []ComponentInterface {
 	// Lock the components mutex for reading to prevent concurrent access while reading
 	cr.componentsMutex.RLock()
-	defer cr.componentsMutex.RUnlock()
 
 	// Initialize an empty slice to store all registered components
 	allComponents := make([]ComponentInterface, 0, len(cr.components))
@@ -114,6 +343,20 @@ func (cr *ComponentRegistrar) GetAllComponents() []ComponentInterface {
 		// Add each component to the slice
 		allComponents = append(allComponents, component)
 	}
+	cr.componentsMutex.RUnlock()
+
+	// Add the components of the parent that are not shadowed
+	if cr.parent != nil {
+		shadowed := make(map[string]bool, len(allComponents))
+		for _, component := range allComponents {
+			shadowed[component.ID()] = true
+		}
+		for _, component := range cr.parent.GetAllComponents() {
+			if !shadowed[component.ID()] {
+				allComponents = append(allComponents, component)
+			}
+		}
+	}
 
 	// Return the slice of all components
 	return allComponents
@@ -125,10 +368,13 @@ func (cr *ComponentRegistrar) GetFactory(id string) (ComponentFactoryInterface,
 	cr.factoriesMutex.RLock()
 	defer cr.factoriesMutex.RUnlock()
 
-	// Check if the factory exists
+	// Check if the factory exists, then fall back to the parent
 	factory, exists := cr.factories[id]
 	if !exists {
-		return nil, fmt.Errorf("factory with ID %s not found", id)
+		if cr.parent != nil {
+			return cr.parent.GetFactory(id)
+		}
+		return nil, fmt.Errorf("%w: %s", ErrFactoryNotFound, id)
 	}
 	return factory, nil
 }
@@ -152,81 +398,297 @@ func (cr *ComponentRegistrar) GetAllFactories() []ComponentFactoryInterface {
 	return allFactories
 }
 
-// CreateComponent creates and registers a new instance of the component.
+// CreateComponent creates and registers a new instance of the component in this registry.
+// The factory is resolved in this registry first, then in the parent.
+// The factory ID and the owner found in the context are recorded with the component.
+// If the factory implements InjectableFactoryInterface, the dependencies it declares are
+// resolved, or created from their configuration, and injected into the component.
 func (cr *ComponentRegistrar) CreateComponent(ctx *context.Context, config *configApi.ComponentConfig) (ComponentInterface, error) {
-	cr.factoriesMutex.RLock()
-	defer cr.factoriesMutex.RUnlock()
+	return cr.createComponent(ctx, config, nil)
+}
 
+// createComponent creates and registers a new instance of the component.
+// The path holds the IDs of the components whose creation required this one.
+func (cr *ComponentRegistrar) createComponent(ctx *context.Context, config *configApi.ComponentConfig, path []string) (ComponentInterface, error) {
 	// Check if the factory exists
-	factory, exists := cr.factories[config.FactoryID]
-	if !exists {
-		return nil, fmt.Errorf("factory with ID %s not found", config.FactoryID)
-	}
-
-	// Use the factory to create the component
-	component, err := factory.CreateComponent(config)
+	factory, err := cr.GetFactory(config.FactoryID)
 	if err != nil {
 		return nil, err
 	}
 
+	// Use the factory to create the component, injecting its dependencies if it declares any
+	var component ComponentInterface
+	if injectable, ok := factory.(InjectableFactoryInterface); ok {
+		dependencies, err := cr.resolveDependencies(ctx, config, injectable.Dependencies(config), path)
+		if err != nil {
+			return nil, err
+		}
+		component, err = injectable.CreateInjectedComponent(config, dependencies)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		component, err = factory.CreateComponent(config)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Register the component
+	info := ComponentInfo{FactoryID: config.FactoryID, Owner: OwnerFromContext(ctx), Labels: componentLabels(component, config)}
 	cr.componentsMutex.Lock()
-	defer cr.componentsMutex.Unlock()
 	cr.components[component.ID()] = component
-
+	cr.infos[component.ID()] = info
+	cr.sequence++
+	cr.sequences[component.ID()] = cr.sequence
+	delete(cr.started, component.ID())
+	cr.componentsMutex.Unlock()
+
+	cr.publish(EventTypeComponentCreated, RegistryEvent{
+		FactoryID:     info.FactoryID,
+		ComponentID:   component.ID(),
+		ComponentType: component.Type(),
+		Owner:         info.Owner,
+		Labels:        info.Labels,
+	})
 	return component, nil
 }
 
 // RegisterFactory registers a factory with the given ID.
+// The owner found in the context is recorded with the factory.
 func (cr *ComponentRegistrar) RegisterFactory(ctx *context.Context, id string, factory ComponentFactoryInterface) error {
 	cr.factoriesMutex.Lock()
-	defer cr.factoriesMutex.Unlock()
 
 	// Check if the factory already exists
 	if _, exists := cr.factories[id]; exists {
-		return fmt.Errorf("factory with ID %s already exists", id)
+		cr.factoriesMutex.Unlock()
+		return fmt.Errorf("%w: %s", ErrFactoryAlreadyExists, id)
 	}
 
 	// Register the factory
+	owner := OwnerFromContext(ctx)
 	cr.factories[id] = factory
+	cr.factoryOwners[id] = owner
+	cr.factoriesMutex.Unlock()
+
+	cr.publish(EventTypeFactoryRegistered, RegistryEvent{FactoryID: id, Owner: owner})
 	return nil
 }
 
 // UnregisterComponent unregisters the component with the specified ID.
+// It is equivalent to RemoveComponent.
 func (cr *ComponentRegistrar) UnregisterComponent(ctx *context.Context, id string) error {
-	cr.componentsMutex.Lock()
-	defer cr.componentsMutex.Unlock()
+	return cr.RemoveComponent(ctx, id)
+}
+
+// SetStarted records whether the component with the specified ID is started. The component is
+// tracked by the registry holding it, this registry or one of its parents. Unknown IDs are ignored.
+func (cr *ComponentRegistrar) SetStarted(id string, started bool) {
+	for registrar := cr; registrar != nil; registrar = registrar.parent {
+		registrar.componentsMutex.Lock()
+		_, exists := registrar.components[id]
+		if exists {
+			if started {
+				registrar.started[id] = true
+			} else {
+				delete(registrar.started, id)
+			}
+		}
+		registrar.componentsMutex.Unlock()
+		if exists {
+			return
+		}
+	}
+}
+
+// Started returns whether the component with the specified ID is recorded as started,
+// in this registry or in the parent holding it.
+func (cr *ComponentRegistrar) Started(id string) bool {
+	for registrar := cr; registrar != nil; registrar = registrar.parent {
+		registrar.componentsMutex.RLock()
+		_, exists := registrar.components[id]
+		started := registrar.started[id]
+		registrar.componentsMutex.RUnlock()
+		if exists {
+			return started
+		}
+	}
+	return false
+}
+
+// RemoveComponent removes the component with the specified ID from the registry.
+// A startable component is stopped first if it reports that it is live or, when it does not
+// report its health, if it was recorded as started with SetStarted. A disposable component is
+// then disposed. The component stays registered if it fails to stop.
+// Only components registered in this registry can be removed, not those of the parent.
+func (cr *ComponentRegistrar) RemoveComponent(ctx *context.Context, id string) error {
+	cr.componentsMutex.RLock()
+	component, exists := cr.components[id]
+	started := cr.started[id]
+	cr.componentsMutex.RUnlock()
 
 	// Check if the component exists
-	if _, exists := cr.components[id]; !exists {
-		return fmt.Errorf("component with ID %s not found", id)
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrComponentNotFound, id)
+	}
+
+	// Stop the component outside of the lock, as it may use the registry while stopping
+	if err := releaseComponent(ctx, component, started); err != nil {
+		return fmt.Errorf("%w: %s: %w", ErrComponentStopFailed, id, err)
 	}
 
-	// Unregister the component
-	delete(cr.components, id)
+	// Unregister the component, unless it was replaced in the meantime
+	cr.componentsMutex.Lock()
+	info, removed := cr.infos[id]
+	removed = removed && cr.components[id] == component
+	if removed {
+		delete(cr.components, id)
+		delete(cr.infos, id)
+		delete(cr.sequences, id)
+		delete(cr.started, id)
+	}
+	cr.componentsMutex.Unlock()
+
+	if removed {
+		cr.publish(EventTypeComponentRemoved, RegistryEvent{
+			FactoryID:     info.FactoryID,
+			ComponentID:   id,
+			ComponentType: component.Type(),
+			Owner:         info.Owner,
+			Labels:        info.Labels,
+		})
+	}
 	return nil
 }
 
 // UnregisterFactory unregisters the factory with the specified ID.
+// The components created from the factory are removed first, in reverse creation order.
+// The factory stays registered if any of its components could not be removed.
 func (cr *ComponentRegistrar) UnregisterFactory(ctx *context.Context, id string) error {
-	cr.factoriesMutex.Lock()
-	defer cr.factoriesMutex.Unlock()
+	cr.factoriesMutex.RLock()
+	_, exists := cr.factories[id]
+	cr.factoriesMutex.RUnlock()
 
 	// Check if the factory exists
-	if _, exists := cr.factories[id]; !exists {
-		return fmt.Errorf("factory with ID %s not found", id)
+	if !exists {
+		return fmt.Errorf("%w: %s", ErrFactoryNotFound, id)
+	}
+
+	// Remove components created from this factory
+	var errs []error
+	for _, componentID := range cr.componentsMatching(func(info ComponentInfo) bool { return info.FactoryID == id }) {
+		if err := cr.RemoveComponent(ctx, componentID); err != nil && !errors.Is(err, ErrComponentNotFound) {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to unregister factory %s: %w", id, errors.Join(errs...))
 	}
 
 	// Unregister the factory
+	cr.factoriesMutex.Lock()
+	owner := cr.factoryOwners[id]
+	_, removed := cr.factories[id]
 	delete(cr.factories, id)
+	delete(cr.factoryOwners, id)
+	cr.factoriesMutex.Unlock()
 
-	// Remove components created from this factory
-	cr.componentsMutex.Lock()
-	defer cr.componentsMutex.Unlock()
-	for key := range cr.components {
-		if key == id {
-			delete(cr.components, key)
+	if removed {
+		cr.publish(EventTypeFactoryUnregistered, RegistryEvent{FactoryID: id, Owner: owner})
+	}
+	return nil
+}
+
+// UnregisterOwner removes the components owned by the given owner, in reverse creation order,
+// then unregisters the factories it owns, which removes the components they created for other owners.
+// The factories stay registered if any of the components could not be removed.
+func (cr *ComponentRegistrar) UnregisterOwner(ctx *context.Context, owner string) error {
+	var errs []error
+	for _, componentID := range cr.componentsMatching(func(info ComponentInfo) bool { return info.Owner == owner }) {
+		if err := cr.RemoveComponent(ctx, componentID); err != nil && !errors.Is(err, ErrComponentNotFound) {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to unregister owner %s: %w", owner, errors.Join(errs...))
+	}
+
+	// Unregister the factories of the owner, in a stable order
+	cr.factoriesMutex.RLock()
+	var factoryIDs []string
+	for factoryID, factoryOwner := range cr.factoryOwners {
+		if factoryOwner == owner {
+			factoryIDs = append(factoryIDs, factoryID)
+		}
+	}
+	cr.factoriesMutex.RUnlock()
+	sort.Strings(factoryIDs)
+
+	for _, factoryID := range factoryIDs {
+		if err := cr.UnregisterFactory(ctx, factoryID); err != nil && !errors.Is(err, ErrFactoryNotFound) {
+			errs = append(errs, err)
 		}
 	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to unregister owner %s: %w", owner, errors.Join(errs...))
+	}
+	return nil
+}
+
+// componentsMatching returns the IDs of the components of the registry whose creation
+// information matches the given predicate, most recently created first.
+func (cr *ComponentRegistrar) componentsMatching(match func(ComponentInfo) bool) []string {
+	cr.componentsMutex.RLock()
+	defer cr.componentsMutex.RUnlock()
+
+	var ids []string
+	for id, info := range cr.infos {
+		if match(info) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return cr.sequences[ids[i]] > cr.sequences[ids[j]]
+	})
+	return ids
+}
+
+// componentLabels returns the labels declared by the component, overridden by the labels
+// of its configuration, or nil if there are none.
+func componentLabels(component ComponentInterface, config *configApi.ComponentConfig) map[string]string {
+	var labels map[string]string
+	if labeled, ok := component.(LabeledInterface); ok {
+		labels = maps.Clone(labeled.Labels())
+	}
+	if len(config.Labels) > 0 {
+		if labels == nil {
+			labels = make(map[string]string, len(config.Labels))
+		}
+		maps.Copy(labels, config.Labels)
+	}
+	if len(labels) == 0 {
+		return nil
+	}
+	return labels
+}
+
+// releaseComponent stops the given component if it is startable and live, then disposes it
+// if it is disposable. Components that do not report their health are live if they were started.
+func releaseComponent(ctx *context.Context, component ComponentInterface, started bool) error {
+	if startable, ok := component.(StartableInterface); ok {
+		live := started
+		if reporter, ok := component.(HealthReporterInterface); ok {
+			live = reporter.Health(ctx).Live
+		}
+		if live {
+			if err := startable.Stop(ctx); err != nil {
+				return err
+			}
+		}
+	}
+
+	if disposable, ok := component.(DisposableInterface); ok {
+		return disposable.Dispose(ctx)
+	}
 	return nil
 }
@@ -0,0 +1,243 @@
+package component
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Well-known component labels.
+const (
+	// PluginLabel is the label holding the ID of the plugin providing the component.
+	PluginLabel = "plugin"
+
+	// RoleLabel is the label holding the role of the component, such as "command" or "extract".
+	RoleLabel = "role"
+)
+
+// LabeledInterface is implemented by components declaring their own labels.
+// Labels set in the component configuration take precedence over the declared ones.
+type LabeledInterface interface {
+	// Labels returns the labels of the component.
+	Labels() map[string]string
+}
+
+// selectorOperator represents the operator of a selector requirement.
+type selectorOperator string
+
+const (
+	selectorEquals       selectorOperator = "="
+	selectorNotEquals    selectorOperator = "!="
+	selectorIn           selectorOperator = "in"
+	selectorNotIn        selectorOperator = "notin"
+	selectorExists       selectorOperator = "exists"
+	selectorDoesNotExist selectorOperator = "!"
+)
+
+// selectorRequirement is a single condition of a selector.
+type selectorRequirement struct {
+	key      string
+	operator selectorOperator
+	values   []string
+}
+
+// Selector selects components by their labels. All of its requirements must match.
+// The zero Selector matches every component.
+type Selector struct {
+	requirements []selectorRequirement
+}
+
+// ParseSelector parses a comma-separated list of label requirements:
+// "key=value" or "key==value", "key!=value", "key in (a,b)", "key notin (a,b)",
+// "key" for an existing label and "!key" for a missing label.
+// An empty expression selects every component.
+func ParseSelector(expression string) (Selector, error) {
+	var selector Selector
+	parts, err := splitSelector(expression)
+	if err != nil {
+		return Selector{}, err
+	}
+
+	for _, part := range parts {
+		requirement, err := parseSelectorRequirement(part)
+		if err != nil {
+			return Selector{}, err
+		}
+		selector.requirements = append(selector.requirements, requirement)
+	}
+	return selector, nil
+}
+
+// SelectorFromLabels returns a selector matching the components having all the given labels.
+func SelectorFromLabels(labels map[string]string) Selector {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var selector Selector
+	for _, key := range keys {
+		selector.requirements = append(selector.requirements,
+			selectorRequirement{key: key, operator: selectorEquals, values: []string{labels[key]}})
+	}
+	return selector
+}
+
+// Empty returns whether the selector has no requirement and thus matches every component.
+func (s Selector) Empty() bool {
+	return len(s.requirements) == 0
+}
+
+// Matches returns whether the given labels satisfy all the requirements of the selector.
+func (s Selector) Matches(labels map[string]string) bool {
+	for _, requirement := range s.requirements {
+		if !requirement.matches(labels) {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the selector in the syntax accepted by ParseSelector.
+func (s Selector) String() string {
+	parts := make([]string, 0, len(s.requirements))
+	for _, requirement := range s.requirements {
+		parts = append(parts, requirement.String())
+	}
+	return strings.Join(parts, ",")
+}
+
+// matches returns whether the given labels satisfy the requirement.
+func (r selectorRequirement) matches(labels map[string]string) bool {
+	value, exists := labels[r.key]
+	switch r.operator {
+	case selectorEquals:
+		return exists && value == r.values[0]
+	case selectorNotEquals:
+		return !exists || value != r.values[0]
+	case selectorIn:
+		return exists && slices.Contains(r.values, value)
+	case selectorNotIn:
+		return !exists || !slices.Contains(r.values, value)
+	case selectorExists:
+		return exists
+	case selectorDoesNotExist:
+		return !exists
+	default:
+		return false
+	}
+}
+
+// String returns the requirement in the syntax accepted by ParseSelector.
+func (r selectorRequirement) String() string {
+	switch r.operator {
+	case selectorIn, selectorNotIn:
+		return fmt.Sprintf("%s %s (%s)", r.key, r.operator, strings.Join(r.values, ","))
+	case selectorExists:
+		return r.key
+	case selectorDoesNotExist:
+		return "!" + r.key
+	default:
+		return r.key + string(r.operator) + r.values[0]
+	}
+}
+
+// splitSelector splits a selector expression on the commas found outside of parentheses.
+func splitSelector(expression string) ([]string, error) {
+	var parts []string
+	depth, start := 0, 0
+	for i, c := range expression {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("%w: unbalanced parentheses in %q", ErrInvalidSelector, expression)
+			}
+		case ',':
+			if depth == 0 {
+				parts = append(parts, expression[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("%w: unbalanced parentheses in %q", ErrInvalidSelector, expression)
+	}
+	parts = append(parts, expression[start:])
+
+	// An empty expression has no requirement, but empty requirements are invalid
+	if len(parts) == 1 && strings.TrimSpace(parts[0]) == "" {
+		return nil, nil
+	}
+	return parts, nil
+}
+
+// parseSelectorRequirement parses a single requirement of a selector.
+func parseSelectorRequirement(part string) (selectorRequirement, error) {
+	part = strings.TrimSpace(part)
+
+	// Set based requirements
+	fields := strings.Fields(part)
+	if len(fields) >= 2 && (fields[1] == string(selectorIn) || fields[1] == string(selectorNotIn)) {
+		values := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(part[len(fields[0]):]), fields[1]))
+		if !strings.HasPrefix(values, "(") || !strings.HasSuffix(values, ")") {
+			return selectorRequirement{}, fmt.Errorf("%w: expected a parenthesized list of values in %q", ErrInvalidSelector, part)
+		}
+		requirement := selectorRequirement{key: fields[0], operator: selectorOperator(fields[1])}
+		for _, value := range strings.Split(values[1:len(values)-1], ",") {
+			requirement.values = append(requirement.values, strings.TrimSpace(value))
+		}
+		return requirement, validateLabelKey(requirement.key, part)
+	}
+
+	// Equality based requirements
+	for _, operator := range []string{"!=", "==", "="} {
+		if key, value, ok := strings.Cut(part, operator); ok {
+			requirement := selectorRequirement{key: strings.TrimSpace(key), values: []string{strings.TrimSpace(value)}}
+			requirement.operator = selectorEquals
+			if operator == "!=" {
+				requirement.operator = selectorNotEquals
+			}
+			return requirement, validateLabelKey(requirement.key, part)
+		}
+	}
+
+	// Existence requirements
+	if key, ok := strings.CutPrefix(part, "!"); ok {
+		key = strings.TrimSpace(key)
+		return selectorRequirement{key: key, operator: selectorDoesNotExist}, validateLabelKey(key, part)
+	}
+	return selectorRequirement{key: part, operator: selectorExists}, validateLabelKey(part, part)
+}
+
+// validateLabelKey checks that a label key is not empty and only holds letters, digits, '.', '-', '_' and '/'.
+func validateLabelKey(key, requirement string) error {
+	if key == "" {
+		return fmt.Errorf("%w: missing label key in %q", ErrInvalidSelector, requirement)
+	}
+	for _, c := range key {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.ContainsRune(".-_/", c)) {
+			return fmt.Errorf("%w: invalid label key %q in %q", ErrInvalidSelector, key, requirement)
+		}
+	}
+	return nil
+}
+
+// FormatLabels returns the given labels as a comma-separated list of key=value pairs sorted by key.
+func FormatLabels(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key+"="+labels[key])
+	}
+	return strings.Join(pairs, ",")
+}
@@ -4,9 +4,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
 )
 
 // LoadConfigurationFromFile loads the configuration from a file at the given path.
+// Files with a .yaml or .yml extension are decoded as YAML, other files as JSON.
 func LoadConfigurationFromFile(filePath string, target interface{}) error {
 	// Read the configuration file
 	data, err := ioutil.ReadFile(filePath)
@@ -14,8 +19,14 @@ func LoadConfigurationFromFile(filePath string, target interface{}) error {
 		return fmt.Errorf("failed to read configuration file: %v", err)
 	}
 
-	// Unmarshal the JSON data into the target struct
-	if err := json.Unmarshal(data, target); err != nil {
+	// Unmarshal the data into the target struct
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, target)
+	default:
+		err = json.Unmarshal(data, target)
+	}
+	if err != nil {
 		return fmt.Errorf("failed to unmarshal configuration data: %v", err)
 	}
 
@@ -1,33 +1,69 @@
 package config
 
-import "time"
-
 // ComponentConfig represents the configuration for a component.
 type ComponentConfig struct {
-	ID           string      `json:"id"`
-	Name         string      `json:"name"`
-	Description  string      `json:"description"`
-	FactoryID    string      `json:"factoryId"`
-	CustomConfig interface{} // Custom configuration
+	ID           string            `json:"id" yaml:"id"`
+	Name         string            `json:"name" yaml:"name"`
+	Description  string            `json:"description" yaml:"description"`
+	FactoryID    string            `json:"factoryId" yaml:"factoryId"`
+	Labels       map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`             // Labels used to select the component
+	CustomConfig interface{}       `json:"customConfig,omitempty" yaml:"customConfig,omitempty"` // Custom configuration
 }
 
 type ServiceConfiguration struct {
-	ComponentConfig
-	RetryInterval time.Duration // Interval between retries
-	// Other service-specific configuration optionsetl.ErrScheduledProcessNotFound
-	CustomConfig interface{} // Custom configuration
+	ComponentConfig     `yaml:",inline"`
+	DependsOn           []string `json:"dependsOn" yaml:"dependsOn"`                     // IDs of the components the service depends on
+	AutoStart           *bool    `json:"autoStart,omitempty" yaml:"autoStart,omitempty"` // Whether the service starts with the system, true if unset
+	RestartPolicy       string   `json:"restartPolicy" yaml:"restartPolicy"`             // Restart policy: never, on-failure or always
+	MaxRestartAttempts  int      `json:"maxRestartAttempts" yaml:"maxRestartAttempts"`   // Maximum number of restarts, 0 for unlimited
+	MaxRetryInterval    Duration `json:"maxRetryInterval" yaml:"maxRetryInterval"`       // Upper bound of the restart backoff
+	RetryInterval       Duration `json:"retryInterval" yaml:"retryInterval"`             // Interval between retries
+	RestartStablePeriod Duration `json:"restartStablePeriod" yaml:"restartStablePeriod"` // Running time after which the restart attempts are reset
+}
+
+// StartsWithSystem returns whether the service is started along with the system.
+func (c *ServiceConfiguration) StartsWithSystem() bool {
+	return c.AutoStart == nil || *c.AutoStart
 }
 
 // OperationConfiguration represents the configuration for an operation.
 type OperationConfiguration struct {
-	ComponentConfig
+	ComponentConfig `yaml:",inline"`
+}
+
+// ScheduleConfiguration represents the schedule of an operation executed by the scheduler service.
+type ScheduleConfiguration struct {
+	ID          string      `json:"id" yaml:"id"`                           // ID of the schedule
+	OperationID string      `json:"operationId" yaml:"operationId"`         // ID of the operation to execute
+	Schedule    string      `json:"schedule" yaml:"schedule"`               // Cron expression, descriptor or "@every <duration>"
+	CatchUp     string      `json:"catchUp" yaml:"catchUp"`                 // Missed runs policy: skip, run-once or run-all
+	Input       interface{} `json:"input,omitempty" yaml:"input,omitempty"` // Input data of the operation
+}
+
+// RemotePluginConfiguration configures the loading of plugin bundles from remote locations.
+type RemotePluginConfiguration struct {
+	CacheDir      string   `json:"cacheDir" yaml:"cacheDir"`           // Directory caching the verified bundles
+	TrustedKeys   []string `json:"trustedKeys" yaml:"trustedKeys"`     // Base64 ed25519 public keys trusted to sign bundles
+	AllowUnsigned bool     `json:"allowUnsigned" yaml:"allowUnsigned"` // Whether bundles without a signature may be loaded
+}
+
+// EventLogConfiguration configures the durable log of the events published on the system event bus.
+type EventLogConfiguration struct {
+	Enabled bool   `json:"enabled" yaml:"enabled"` // Whether published events are appended to the event log
+	Store   string `json:"store" yaml:"store"`     // Name of the store of the event log in the MultiStore
 }
 
 // Configuration represents the system configuration.
 type Configuration struct {
-	Debug        bool
-	Verbose      bool
-	Services     []*ServiceConfiguration   // Service configurations
-	Operations   []*OperationConfiguration // Operation configurations
-	CustomConfig interface{}
+	Debug                   bool                      `json:"debug" yaml:"debug"`
+	Verbose                 bool                      `json:"verbose" yaml:"verbose"`
+	MaxConcurrentOperations int                       `json:"maxConcurrentOperations" yaml:"maxConcurrentOperations"` // Number of operations executed asynchronously at once
+	OperationQueueSize      int                       `json:"operationQueueSize" yaml:"operationQueueSize"`           // Number of asynchronous operations waiting for execution
+	JobRetention            Duration                  `json:"jobRetention" yaml:"jobRetention"`                       // How long completed jobs can be retrieved, 10 minutes if unset
+	Services                []*ServiceConfiguration   `json:"services" yaml:"services"`                               // Service configurations
+	Operations              []*OperationConfiguration `json:"operations" yaml:"operations"`                           // Operation configurations
+	Schedules               []*ScheduleConfiguration  `json:"schedules" yaml:"schedules"`                             // Scheduled operation configurations
+	RemotePlugins           RemotePluginConfiguration `json:"remotePlugins" yaml:"remotePlugins"`                     // Loading of remote plugins
+	EventLog                EventLogConfiguration     `json:"eventLog" yaml:"eventLog"`                               // Durable log of the published events
+	CustomConfig            interface{}               `json:"customConfig,omitempty" yaml:"customConfig,omitempty"`
 }
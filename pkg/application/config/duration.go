@@ -0,0 +1,45 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Duration is a time.Duration read from configuration files either as a duration string such as
+// "5s" or "1m30s", or as a number of nanoseconds, so that JSON and YAML files accept the same values.
+type Duration time.Duration
+
+// MarshalText encodes the duration as a duration string.
+func (d Duration) MarshalText() ([]byte, error) {
+	return []byte(time.Duration(d).String()), nil
+}
+
+// UnmarshalText decodes a duration string or a number of nanoseconds.
+func (d *Duration) UnmarshalText(text []byte) error {
+	if nanoseconds, err := strconv.ParseInt(string(text), 10, 64); err == nil {
+		*d = Duration(nanoseconds)
+		return nil
+	}
+
+	duration, err := time.ParseDuration(string(text))
+	if err != nil {
+		return fmt.Errorf("invalid duration %q: %w", text, err)
+	}
+	*d = Duration(duration)
+	return nil
+}
+
+// UnmarshalJSON decodes a JSON duration string or number of nanoseconds.
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return d.UnmarshalText(data)
+	}
+	return d.UnmarshalText([]byte(text))
+}
@@ -13,38 +13,57 @@ type MockEventBus struct {
 }
 
 // Subscribe mocks the Subscribe method of the EventBusInterface.
-func (m *MockEventBus) Subscribe(params event.BusSubscriptionParams) error {
+func (m *MockEventBus) Subscribe(params event.BusSubscriptionParams) (*event.Subscription, error) {
 	args := m.Called(params)
-	return args.Error(0)
+	subscription, _ := args.Get(0).(*event.Subscription)
+	return subscription, args.Error(1)
 }
 
 // SubscribeAsync mocks the SubscribeAsync method of the EventBusInterface.
-func (m *MockEventBus) SubscribeAsync(params event.BusSubscriptionParams, transactional bool) error {
+func (m *MockEventBus) SubscribeAsync(params event.BusSubscriptionParams, transactional bool) (*event.Subscription, error) {
 	args := m.Called(params, transactional)
-	return args.Error(0)
+	subscription, _ := args.Get(0).(*event.Subscription)
+	return subscription, args.Error(1)
 }
 
 // SubscribeOnce mocks the SubscribeOnce method of the EventBusInterface.
-func (m *MockEventBus) SubscribeOnce(params event.BusSubscriptionParams) error {
+func (m *MockEventBus) SubscribeOnce(params event.BusSubscriptionParams) (*event.Subscription, error) {
 	args := m.Called(params)
-	return args.Error(0)
+	subscription, _ := args.Get(0).(*event.Subscription)
+	return subscription, args.Error(1)
 }
 
 // SubscribeOnceAsync mocks the SubscribeOnceAsync method of the EventBusInterface.
-func (m *MockEventBus) SubscribeOnceAsync(params event.BusSubscriptionParams) error {
+func (m *MockEventBus) SubscribeOnceAsync(params event.BusSubscriptionParams) (*event.Subscription, error) {
 	args := m.Called(params)
-	return args.Error(0)
+	subscription, _ := args.Get(0).(*event.Subscription)
+	return subscription, args.Error(1)
 }
 
-// Unsubscribe mocks the Unsubscribe method of the EventBusInterface.
-func (m *MockEventBus) Unsubscribe(params event.BusSubscriptionParams) error {
+// SubscribePattern mocks the SubscribePattern method of the EventBusInterface.
+func (m *MockEventBus) SubscribePattern(params event.BusSubscriptionParams) (*event.Subscription, error) {
 	args := m.Called(params)
+	subscription, _ := args.Get(0).(*event.Subscription)
+	return subscription, args.Error(1)
+}
+
+// SubscribePatternAsync mocks the SubscribePatternAsync method of the EventBusInterface.
+func (m *MockEventBus) SubscribePatternAsync(params event.BusSubscriptionParams, transactional bool) (*event.Subscription, error) {
+	args := m.Called(params, transactional)
+	subscription, _ := args.Get(0).(*event.Subscription)
+	return subscription, args.Error(1)
+}
+
+// Unsubscribe mocks the Unsubscribe method of the EventBusInterface.
+func (m *MockEventBus) Unsubscribe(subscription *event.Subscription) error {
+	args := m.Called(subscription)
 	return args.Error(0)
 }
 
 // Publish mocks the Publish method of the EventBusInterface.
-func (m *MockEventBus) Publish(event event.Event) {
-	m.Called(event)
+func (m *MockEventBus) Publish(event event.Event) error {
+	args := m.Called(event)
+	return args.Error(0)
 }
 
 // HasCallback mocks the HasCallback method of the EventBusInterface.
@@ -57,3 +76,10 @@ func (m *MockEventBus) HasCallback(topic string) bool {
 func (m *MockEventBus) WaitAsync() {
 	m.Called()
 }
+
+// Topics mocks the Topics method of the EventBusInterface.
+func (m *MockEventBus) Topics() *event.TopicRegistry {
+	args := m.Called()
+	topics, _ := args.Get(0).(*event.TopicRegistry)
+	return topics
+}
@@ -29,12 +29,31 @@ func (m *MockPluginManager) RemovePlugin(plugin systemApi.PluginInterface) error
 	return args.Error(0)
 }
 
+// UnloadPlugin mocks the UnloadPlugin method.
+func (m *MockPluginManager) UnloadPlugin(ctx *context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// ReloadPlugin mocks the ReloadPlugin method.
+func (m *MockPluginManager) ReloadPlugin(ctx *context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
 // GetPlugin mocks the GetPlugin method.
 func (m *MockPluginManager) GetPlugin(name string) (systemApi.PluginInterface, error) {
 	args := m.Called(name)
 	return args.Get(0).(systemApi.PluginInterface), args.Error(1)
 }
 
+// GetPluginManifest mocks the GetPluginManifest method.
+func (m *MockPluginManager) GetPluginManifest(id string) (*systemApi.PluginManifest, error) {
+	args := m.Called(id)
+	manifest, _ := args.Get(0).(*systemApi.PluginManifest)
+	return manifest, args.Error(1)
+}
+
 // StartPlugins mocks the StartPlugins method.
 func (m *MockPluginManager) StartPlugins(ctx *context.Context) error {
 	args := m.Called(ctx)
@@ -47,12 +66,25 @@ func (m *MockPluginManager) StopPlugins(ctx *context.Context) error {
 	return args.Error(0)
 }
 
+// RegisterPluginLoader mocks the RegisterPluginLoader method.
+func (m *MockPluginManager) RegisterPluginLoader(kind string, loader systemApi.PluginLoader) error {
+	args := m.Called(kind, loader)
+	return args.Error(0)
+}
+
 // DiscoverPlugins mocks the DiscoverPlugins method.
 func (m *MockPluginManager) DiscoverPlugins(ctx *context.Context) ([]systemApi.PluginInterface, error) {
 	args := m.Called(ctx)
 	return args.Get(0).([]systemApi.PluginInterface), args.Error(1)
 }
 
+// LoadPlugin mocks the LoadPlugin method.
+func (m *MockPluginManager) LoadPlugin(ctx *context.Context, manifestPath string) (systemApi.PluginInterface, error) {
+	args := m.Called(ctx, manifestPath)
+	plugin, _ := args.Get(0).(systemApi.PluginInterface)
+	return plugin, args.Error(1)
+}
+
 // LoadRemotePlugin mocks the LoadRemotePlugin method.
 func (m *MockPluginManager) LoadRemotePlugin(ctx *context.Context, pluginURL string) (systemApi.PluginInterface, error) {
 	args := m.Called(ctx, pluginURL)
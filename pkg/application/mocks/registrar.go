@@ -30,6 +30,12 @@ func (m *MockComponentRegistrar) GetAllComponents() []component.ComponentInterfa
 	return args.Get(0).([]component.ComponentInterface)
 }
 
+// GetComponentInfo mocks the GetComponentInfo method.
+func (m *MockComponentRegistrar) GetComponentInfo(id string) (component.ComponentInfo, error) {
+	args := m.Called(id)
+	return args.Get(0).(component.ComponentInfo), args.Error(1)
+}
+
 // GetFactory mocks the GetFactory method.
 func (m *MockComponentRegistrar) GetFactory(id string) (component.ComponentFactoryInterface, error) {
 	args := m.Called(id)
@@ -48,6 +54,12 @@ func (m *MockComponentRegistrar) UnregisterFactory(ctx *context.Context, id stri
 	return args.Error(0)
 }
 
+// UnregisterOwner mocks the UnregisterOwner method.
+func (m *MockComponentRegistrar) UnregisterOwner(ctx *context.Context, owner string) error {
+	args := m.Called(ctx, owner)
+	return args.Error(0)
+}
+
 // CreateComponent mocks the CreateComponent method.
 func (m *MockComponentRegistrar) CreateComponent(ctx *context.Context, config *config.ComponentConfig) (component.ComponentInterface, error) {
 	args := m.Called(ctx, config)
@@ -59,3 +71,27 @@ func (m *MockComponentRegistrar) RemoveComponent(ctx *context.Context, id string
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
+
+// CreateScope mocks the CreateScope method.
+func (m *MockComponentRegistrar) CreateScope(id string) (component.ComponentRegistrarInterface, error) {
+	args := m.Called(id)
+	return args.Get(0).(component.ComponentRegistrarInterface), args.Error(1)
+}
+
+// GetScope mocks the GetScope method.
+func (m *MockComponentRegistrar) GetScope(id string) (component.ComponentRegistrarInterface, error) {
+	args := m.Called(id)
+	return args.Get(0).(component.ComponentRegistrarInterface), args.Error(1)
+}
+
+// TearDown mocks the TearDown method.
+func (m *MockComponentRegistrar) TearDown(ctx *context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+// GetComponentsBySelector mocks the GetComponentsBySelector method.
+func (m *MockComponentRegistrar) GetComponentsBySelector(selector component.Selector) []component.ComponentInterface {
+	args := m.Called(selector)
+	return args.Get(0).([]component.ComponentInterface)
+}
@@ -84,6 +84,24 @@ func (m *MockSystem) ExecuteOperation(ctx *context.Context, operationID string,
 	return args.Get(0).(*system.SystemOperationOutput), args.Error(1)
 }
 
+// ExecuteOperationAsync provides a mock implementation of the ExecuteOperationAsync method.
+func (m *MockSystem) ExecuteOperationAsync(ctx *context.Context, operationID string, data *system.SystemOperationInput) (*system.Job, error) {
+	args := m.Called(ctx, operationID, data)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*system.Job), args.Error(1)
+}
+
+// GetJob provides a mock implementation of the GetJob method.
+func (m *MockSystem) GetJob(jobID string) (*system.Job, error) {
+	args := m.Called(jobID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*system.Job), args.Error(1)
+}
+
 // StartService provides a mock implementation of the StartService method.
 func (m *MockSystem) StartService(ctx *context.Context, serviceID string) error {
 	args := m.Called(ctx, serviceID)
@@ -101,3 +119,32 @@ func (m *MockSystem) RestartService(ctx *context.Context, serviceID string) erro
 	args := m.Called(ctx, serviceID)
 	return args.Error(0)
 }
+
+// Health provides a mock implementation of the Health method.
+func (m *MockSystem) Health(ctx *context.Context) *system.SystemHealth {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(*system.SystemHealth)
+}
+
+// Supervisor provides a mock implementation of the Supervisor method.
+func (m *MockSystem) Supervisor() *system.Supervisor {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).(*system.Supervisor)
+}
+
+// AddOperationInterceptor provides a mock implementation of the AddOperationInterceptor method.
+func (m *MockSystem) AddOperationInterceptor(interceptor system.OperationInterceptor) {
+	m.Called(interceptor)
+}
+
+// Catalog provides a mock implementation of the Catalog method.
+func (m *MockSystem) Catalog() []*system.CatalogEntry {
+	args := m.Called()
+	return args.Get(0).([]*system.CatalogEntry)
+}
@@ -17,46 +17,114 @@ import (
 type InitOptions struct {
 	Debug          bool
 	Verbose        bool
-	configFilePath string
+	ConfigFilePath string   // Path of the JSON or YAML system configuration file
+	PluginPaths    []string // Paths in which plugin manifests are discovered
 }
 
 // Init initializes the Fx application.
 func Init(options *InitOptions) {
 	// Create an Fx application.
 	app := fx.New(
-		// Provide dependencies.
+		Providers(options),
+		fx.Invoke(func(system.SystemInterface) {}),
+	)
+	// Run the application.
+	app.Run()
+}
+
+// Providers returns the providers of the dependencies of the system.
+func Providers(options *InitOptions) fx.Option {
+	return fx.Options(
 		fx.Provide(ProvideConfiguration(options)),
 		fx.Provide(ProvideEventBus),
 		fx.Provide(ProvideLogger(options)),
-		fx.Provide(ProvideSystem),
+		fx.Provide(ProvideComponentRegistrar),
+		fx.Provide(ProvidePluginManager),
+		fx.Provide(ProvideMultiStore(options)),
+		fx.Provide(ProvideSystem(options)),
 	)
-	// Run the application.
-	app.Run()
+}
+
+// NewSystem creates the system with the providers of Init and initializes it without starting
+// it, so that commands can inspect the operations and services it provides.
+func NewSystem(options *InitOptions) (system.SystemInterface, error) {
+	var sys system.SystemInterface
+	app := fx.New(
+		fx.NopLogger,
+		Providers(options),
+		fx.Populate(&sys),
+	)
+	if err := app.Err(); err != nil {
+		return nil, err
+	}
+
+	ctx := contextApi.Background().WithPluginPaths(options.PluginPaths...)
+	if err := InitializeSystem(ctx, sys); err != nil {
+		return nil, err
+	}
+	return sys, nil
+}
+
+// InitializeSystem initializes the system and adds the plugins discovered in the plugin paths of
+// the context. Plugins failing to load are logged and skipped.
+func InitializeSystem(ctx *contextApi.Context, sys system.SystemInterface) error {
+	if err := sys.Initialize(ctx); err != nil {
+		return err
+	}
+
+	plugins, err := sys.PluginManager().DiscoverPlugins(ctx)
+	if err != nil {
+		sys.Logger().Log(logger.LevelWarn, "Failed to load plugins:", err)
+	}
+	for _, plugin := range plugins {
+		if err := sys.PluginManager().AddPlugin(ctx, plugin); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // ProvideConfiguration loads and provides the application configuration.
+// The operations and services declared in the configuration file are created by the system
+// when it is initialized. The event log is refused, as the application provides no MultiStore.
 func ProvideConfiguration(options *InitOptions) func() (*config.Configuration, error) {
 	return func() (*config.Configuration, error) {
-		var appConfig interface{}
-		if options.configFilePath != "" {
-			if err := config.LoadConfigurationFromFile(options.configFilePath, &appConfig); err != nil {
-				return nil, fmt.Errorf("failed to load custom configuration: %v", err)
+		configuration := &config.Configuration{}
+		if options.ConfigFilePath != "" {
+			if err := config.LoadConfigurationFromFile(options.ConfigFilePath, configuration); err != nil {
+				return nil, fmt.Errorf("failed to load configuration: %v", err)
 			}
 		}
 
-		configuration := &config.Configuration{
-			Debug:        options.Debug,
-			Verbose:      options.Verbose,
-			CustomConfig: appConfig,
+		if configuration.EventLog.Enabled {
+			return nil, fmt.Errorf("invalid configuration: %w: eventLog.enabled is not supported by this application, "+
+				"which provides no MultiStore", event.ErrEventLogUnavailable)
 		}
 
+		configuration.Debug = configuration.Debug || options.Debug
+		configuration.Verbose = configuration.Verbose || options.Verbose
 		return configuration, nil
 	}
 }
 
-// ProvideEventBus provides an event bus interface.
-func ProvideEventBus() event.EventBusInterface {
-	return event.NewSystemEventBus()
+// ProvideEventBus provides an event bus interface. When the event log is enabled in the
+// configuration, the published events are appended to a store of the MultiStore.
+func ProvideEventBus(
+	configuration *config.Configuration,
+	multiStore store.MultiStore,
+	logger logger.LoggerInterface) (event.EventBusInterface, error) {
+
+	bus := event.NewSystemEventBus()
+	if !configuration.EventLog.Enabled {
+		return bus, nil
+	}
+
+	durable, err := event.NewDurableEventBus(bus, multiStore, configuration.EventLog.Store)
+	if err != nil {
+		return nil, err
+	}
+	durable.Logger = logger
+	return durable, nil
 }
 
 // ProvideLogger provides a logger interface based on the initialization options.
@@ -74,41 +142,68 @@ func ProvideLogger(options *InitOptions) func() logger.LoggerInterface {
 	}
 }
 
+// ProvideComponentRegistrar provides the component registrar with the factories of the
+// built-in services registered, so that they can be declared in the configuration.
+func ProvideComponentRegistrar() (component.ComponentRegistrarInterface, error) {
+	registrar := component.NewComponentRegistrar()
+	err := registrar.RegisterFactory(contextApi.Background(), system.SchedulerServiceFactoryID, &system.SchedulerServiceFactory{})
+	if err != nil {
+		return nil, err
+	}
+	return registrar, nil
+}
+
+// ProvidePluginManager provides the plugin manager.
+func ProvidePluginManager() system.PluginManagerInterface {
+	return system.NewPluginManager()
+}
+
+// ProvideMultiStore provides the MultiStore of the system. The application provides none yet, so
+// the features persisting their state in the MultiStore, such as the event log, are unavailable.
 func ProvideMultiStore(options *InitOptions) func() store.MultiStore {
-	// Return a function that creates a MultiStore interface based on the provided options.
 	return func() store.MultiStore {
-
-		// Create a new logger with the determined log level.
 		return nil
 	}
 }
 
-// ProvideSystem provides a system interface.
-func ProvideSystem(
+// SystemProvider is the provider of the system.
+type SystemProvider func(
 	lc fx.Lifecycle,
 	logger logger.LoggerInterface,
 	eventBus event.EventBusInterface,
 	configuration *config.Configuration,
 	pluginManager system.PluginManagerInterface,
 	registrar component.ComponentRegistrarInterface,
-	store store.MultiStore) system.SystemInterface {
-
-	sys := system.NewSystem(logger, eventBus, configuration, pluginManager, registrar, store)
-
-	lc.Append(fx.Hook{
-		OnStart: func(ctx context.Context) error {
-			contx := contextApi.WithContext(ctx)
-			err := sys.Initialize(contx)
-			if err != nil {
-				return err
-			}
-
-			return sys.Start(contx)
-		},
-		OnStop: func(ctx context.Context) error {
-			contx := contextApi.WithContext(ctx)
-			return sys.Stop(contx)
-		},
-	})
-	return sys
+	store store.MultiStore) system.SystemInterface
+
+// ProvideSystem provides a system interface. The system is initialized with the plugins found in
+// the plugin paths of the options, and started, when the application starts.
+func ProvideSystem(options *InitOptions) SystemProvider {
+	return func(
+		lc fx.Lifecycle,
+		logger logger.LoggerInterface,
+		eventBus event.EventBusInterface,
+		configuration *config.Configuration,
+		pluginManager system.PluginManagerInterface,
+		registrar component.ComponentRegistrarInterface,
+		store store.MultiStore) system.SystemInterface {
+
+		sys := system.NewSystem(logger, eventBus, configuration, pluginManager, registrar, store)
+
+		lc.Append(fx.Hook{
+			OnStart: func(ctx context.Context) error {
+				contx := contextApi.WithContext(ctx).WithPluginPaths(options.PluginPaths...)
+				if err := InitializeSystem(contx, sys); err != nil {
+					return err
+				}
+
+				return sys.Start(contx)
+			},
+			OnStop: func(ctx context.Context) error {
+				contx := contextApi.WithContext(ctx)
+				return sys.Stop(contx)
+			},
+		})
+		return sys
+	}
 }
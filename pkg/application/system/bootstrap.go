@@ -0,0 +1,100 @@
+package system
+
+import (
+	"fmt"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	"github.com/edward1christian/block-forge/pkg/application/common/logger"
+	"github.com/edward1christian/block-forge/pkg/application/component"
+	"github.com/edward1christian/block-forge/pkg/application/config"
+)
+
+// bootstrapComponents creates and initializes the operations and services declared in the
+// system configuration, using the factories registered with the component registrar.
+// Components already created from the same factory are left untouched, so that
+// initializing the system again does not fail.
+// Entries without a factory ID only configure components created elsewhere, such as by plugins.
+func (s *SystemImpl) bootstrapComponents(ctx *context.Context) error {
+	if s.configuration == nil {
+		return nil
+	}
+
+	for _, operationConfig := range s.configuration.Operations {
+		if operationConfig == nil {
+			continue
+		}
+		if err := s.bootstrapComponent(ctx, operationConfig.ComponentConfig); err != nil {
+			return err
+		}
+	}
+
+	for _, serviceConfig := range s.configuration.Services {
+		if serviceConfig == nil {
+			continue
+		}
+		if err := s.bootstrapComponent(ctx, serviceConfig.ComponentConfig); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bootstrapComponent creates and initializes the component with the given configuration.
+func (s *SystemImpl) bootstrapComponent(ctx *context.Context, componentConfig config.ComponentConfig) error {
+	if componentConfig.FactoryID == "" {
+		return nil
+	}
+
+	registry := s.ComponentRegistry()
+
+	if _, err := registry.GetComponent(componentConfig.ID); err == nil {
+		if info, err := registry.GetComponentInfo(componentConfig.ID); err == nil && info.FactoryID == componentConfig.FactoryID {
+			return nil
+		}
+		return fmt.Errorf("failed to bootstrap component %s: %w", componentConfig.ID, ErrComponentAlreadyExist)
+	}
+
+	if _, err := registry.GetFactory(componentConfig.FactoryID); err != nil {
+		return fmt.Errorf("failed to bootstrap component %s: %w: %s", componentConfig.ID, ErrFactoryNotFound, componentConfig.FactoryID)
+	}
+
+	comp, err := registry.CreateComponent(ctx, &componentConfig)
+	if err != nil {
+		return fmt.Errorf("failed to bootstrap component %s: %w", componentConfig.ID, err)
+	}
+
+	if err := initializeComponent(ctx, s, comp); err != nil {
+		// Remove the component so that it is created again by the next initialization
+		if removeErr := registry.RemoveComponent(ctx, componentConfig.ID); removeErr != nil {
+			s.logger.Log(logger.LevelError, "Error removing component:", removeErr)
+		}
+		return fmt.Errorf("failed to initialize component %s: %w", componentConfig.ID, err)
+	}
+	return nil
+}
+
+// initializeComponent initializes the given component if it is a system operation or service.
+func initializeComponent(ctx *context.Context, system SystemInterface, comp component.ComponentInterface) error {
+	switch v := comp.(type) {
+	case SystemOperationInterface:
+		return v.Initialize(ctx, system)
+	case SystemServiceInterface:
+		return v.Initialize(ctx, system)
+	default:
+		return nil
+	}
+}
+
+// startsWithSystem returns whether the service with the given ID is started along with the system.
+// Services not declared in the configuration always are.
+func (s *SystemImpl) startsWithSystem(serviceID string) bool {
+	if s.configuration == nil {
+		return true
+	}
+	for _, serviceConfig := range s.configuration.Services {
+		if serviceConfig != nil && serviceConfig.ID == serviceID {
+			return serviceConfig.StartsWithSystem()
+		}
+	}
+	return true
+}
@@ -0,0 +1,111 @@
+package system
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/edward1christian/block-forge/pkg/application/component"
+)
+
+// CatalogKindType represents the kind of a catalog entry.
+type CatalogKindType string
+
+const (
+	// CatalogOperationKind indicates that the entry is an operation.
+	CatalogOperationKind CatalogKindType = "operation"
+
+	// CatalogServiceKind indicates that the entry is a service.
+	CatalogServiceKind CatalogKindType = "service"
+)
+
+// CatalogEntry describes an operation or service registered with the system.
+type CatalogEntry struct {
+	ID          string                  // ID of the component
+	Name        string                  // Name of the component
+	Description string                  // Description of the component
+	Kind        CatalogKindType         // Whether the component is an operation or a service
+	Type        component.ComponentType // Type reported by the component
+	FactoryID   string                  // ID of the factory that created the component
+	Owner       string                  // ID of the plugin that created the component, if any
+	InputSchema *OperationSchema        // Schema of the operation input, if declared
+	Labels      map[string]string       // Labels of the component
+}
+
+// Catalog returns the operations and services registered with the system, sorted by ID.
+func (s *SystemImpl) Catalog() []*CatalogEntry {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	registry := s.ComponentRegistry()
+	entries := []*CatalogEntry{}
+	for _, comp := range registry.GetAllComponents() {
+		entry := &CatalogEntry{
+			ID:          comp.ID(),
+			Name:        comp.Name(),
+			Description: comp.Description(),
+			Type:        comp.Type(),
+		}
+
+		switch v := comp.(type) {
+		case SystemOperationInterface:
+			entry.Kind = CatalogOperationKind
+			if schemaOp, ok := v.(SchemaOperationInterface); ok {
+				entry.InputSchema = schemaOp.InputSchema()
+			}
+		case SystemServiceInterface:
+			entry.Kind = CatalogServiceKind
+		default:
+			continue
+		}
+
+		if info, err := registry.GetComponentInfo(comp.ID()); err == nil {
+			entry.FactoryID = info.FactoryID
+			entry.Owner = info.Owner
+			entry.Labels = info.Labels
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].ID < entries[j].ID
+	})
+	return entries
+}
+
+// FilterCatalog returns the catalog entries whose labels match the given selector.
+func FilterCatalog(entries []*CatalogEntry, selector component.Selector) []*CatalogEntry {
+	filtered := []*CatalogEntry{}
+	for _, entry := range entries {
+		if selector.Matches(entry.Labels) {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered
+}
+
+// WriteCatalog writes the given catalog entries as a table.
+func WriteCatalog(w io.Writer, entries []*CatalogEntry) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tKIND\tOWNER\tFACTORY\tINPUT\tLABELS\tDESCRIPTION")
+
+	for _, entry := range entries {
+		input := "-"
+		if entry.InputSchema != nil {
+			input = entry.InputSchema.TypeName()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			entry.ID, entry.Kind, valueOrDash(entry.Owner), valueOrDash(entry.FactoryID), input,
+			valueOrDash(component.FormatLabels(entry.Labels)), entry.Description)
+	}
+	return tw.Flush()
+}
+
+// valueOrDash returns the given value, or a dash if it is empty.
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -1,15 +1,20 @@
 package system
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/edward1christian/block-forge/pkg/application/component"
+)
 
 // Custom errors
 var (
 	ErrComponentNil                  = errors.New("component is nil")
 	ErrComponentAlreadyExist         = errors.New("component already exists")
-	ErrFactoryNotFound               = errors.New("factory not found")
+	ErrFactoryNotFound               = component.ErrFactoryNotFound
 	ErrComponentFactoryNil           = errors.New("component factory is nil")
 	ErrComponentFactoryAlreadyExists = errors.New("component factory already exists")
-	ErrComponentNotFound             = errors.New("component not found")
+	ErrComponentNotFound             = component.ErrComponentNotFound
+	ErrComponentTypeMismatch         = component.ErrComponentTypeMismatch
 	ErrServiceAlreadyExists          = errors.New("service already exists")
 	ErrServiceNotRegistered          = errors.New("service not registered")
 	ErrOperationNotRegistered        = errors.New("operation not registered")
@@ -18,4 +23,37 @@ var (
 	ErrSystemNotStarted              = errors.New("system not started")
 	ErrSystemNotStopped              = errors.New("system not stopped")
 	ErrComponentTypeNotFound         = errors.New("component type not found")
+	ErrServiceDependencyCycle        = errors.New("service dependency cycle detected")
+	ErrServiceDependencyNotFound     = errors.New("service dependency not found")
+	ErrInvalidRestartPolicy          = errors.New("invalid restart policy")
+	ErrServiceNotSupervisable        = errors.New("service cannot be supervised")
+	ErrOperationPanicked             = errors.New("operation panicked")
+	ErrJobQueueFull                  = errors.New("job queue is full")
+	ErrJobNotFound                   = errors.New("job not found")
+	ErrInvalidOperationInput         = errors.New("invalid operation input")
+	ErrInvalidOperationOutput        = errors.New("invalid operation output")
+	ErrSystemShuttingDown            = errors.New("system is shutting down")
+	ErrShutdownDeadlineExceeded      = errors.New("shutdown deadline exceeded before operations completed")
+	ErrInvalidSchedule               = errors.New("invalid schedule")
+	ErrInvalidCatchUpPolicy          = errors.New("invalid catch-up policy")
+	ErrScheduleNotFound              = errors.New("schedule not found")
+	ErrInvalidPluginManifest         = errors.New("invalid plugin manifest")
+	ErrPluginLoaderNotFound          = errors.New("plugin loader not found")
+	ErrPluginLoaderAlreadyExists     = errors.New("plugin loader already exists")
+	ErrPluginProtocolMismatch        = errors.New("plugin protocol version mismatch")
+	ErrPluginCrashed                 = errors.New("plugin process crashed")
+	ErrPluginNotRunning              = errors.New("plugin process not running")
+	ErrPluginLimitsUnsupported       = errors.New("plugin resource limits are not supported on this platform")
+	ErrInvalidVersion                = errors.New("invalid version")
+	ErrIncompatiblePlugin            = errors.New("incompatible plugin")
+	ErrPluginDependencyNotFound      = errors.New("plugin dependency not found")
+	ErrPluginDependencyCycle         = errors.New("plugin dependency cycle detected")
+	ErrPluginManifestNotFound        = errors.New("plugin manifest not found")
+	ErrPluginInUse                   = errors.New("plugin is required by other plugins")
+	ErrPluginNotReloadable           = errors.New("plugin cannot be reloaded")
+	ErrInvalidPluginBundle           = errors.New("invalid plugin bundle")
+	ErrInvalidPluginKey              = errors.New("invalid plugin signing key")
+	ErrPluginChecksumMismatch        = errors.New("plugin bundle checksum mismatch")
+	ErrPluginSignatureInvalid        = errors.New("plugin bundle signature is invalid")
+	ErrUnsignedPlugin                = errors.New("plugin bundle is not signed")
 )
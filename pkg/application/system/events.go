@@ -0,0 +1,68 @@
+package system
+
+import (
+	"errors"
+	"time"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/event"
+	"github.com/edward1christian/block-forge/pkg/application/component"
+)
+
+const (
+	// EventTypeServiceFailed represents an event emitted when a supervised service fails or exits.
+	EventTypeServiceFailed string = event.NamespaceSystem + ".service.failed"
+
+	// EventTypeServiceRestarted represents an event emitted when a supervised service is restarted.
+	EventTypeServiceRestarted string = event.NamespaceSystem + ".service.restarted"
+
+	// EventTypeServiceRestartAbandoned represents an event emitted when the supervisor
+	// gives up restarting a service.
+	EventTypeServiceRestartAbandoned string = event.NamespaceSystem + ".service.restart_abandoned"
+
+	// EventTypeScheduledOperationSucceeded represents an event emitted when a scheduled operation succeeds.
+	EventTypeScheduledOperationSucceeded string = event.NamespaceSystem + ".schedule.operation_succeeded"
+
+	// EventTypeScheduledOperationFailed represents an event emitted when a scheduled operation fails.
+	EventTypeScheduledOperationFailed string = event.NamespaceSystem + ".schedule.operation_failed"
+)
+
+// Topics of the events published by the supervisor and the scheduler service.
+var (
+	ServiceFailedTopic               = event.NewTopic[ServiceRestartEvent](EventTypeServiceFailed)
+	ServiceRestartedTopic            = event.NewTopic[ServiceRestartEvent](EventTypeServiceRestarted)
+	ServiceRestartAbandonedTopic     = event.NewTopic[ServiceRestartEvent](EventTypeServiceRestartAbandoned)
+	ScheduledOperationSucceededTopic = event.NewTopic[ScheduledRunEvent](EventTypeScheduledOperationSucceeded)
+	ScheduledOperationFailedTopic    = event.NewTopic[ScheduledRunEvent](EventTypeScheduledOperationFailed)
+)
+
+// RegisterTopics registers the topics of the events published by the system, including those of
+// the component registrar, in the given topic registry.
+func RegisterTopics(registry *event.TopicRegistry) error {
+	return errors.Join(
+		component.RegisterTopics(registry),
+		event.RegisterTopic(registry, ServiceFailedTopic, nil),
+		event.RegisterTopic(registry, ServiceRestartedTopic, nil),
+		event.RegisterTopic(registry, ServiceRestartAbandonedTopic, nil),
+		event.RegisterTopic(registry, ScheduledOperationSucceededTopic, nil),
+		event.RegisterTopic(registry, ScheduledOperationFailedTopic, nil),
+	)
+}
+
+// ServiceRestartEvent is the payload of the events published by the supervisor.
+type ServiceRestartEvent struct {
+	ServiceID string        // ID of the supervised service
+	Attempt   int           // Number of the restart attempt
+	Delay     time.Duration // Backoff applied before the restart attempt
+	Error     string        // Error that caused the failure, if any
+}
+
+// ScheduledRunEvent is the payload of the events published by the scheduler service.
+type ScheduledRunEvent struct {
+	ScheduleID  string        // ID of the schedule
+	OperationID string        // ID of the executed operation
+	ScheduledAt time.Time     // Time at which the run was scheduled
+	StartedAt   time.Time     // Time at which the run started
+	Duration    time.Duration // Duration of the run
+	CatchUp     bool          // Whether the run catches up a run missed while the scheduler was stopped
+	Error       string        // Error returned by the operation, if any
+}
@@ -0,0 +1,126 @@
+package system
+
+import (
+	"sort"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	"github.com/edward1christian/block-forge/pkg/application/component"
+)
+
+// SystemHealthStatusType represents the aggregated health of the system.
+type SystemHealthStatusType int
+
+const (
+	// SystemHealthyType indicates that the system and all its services are live and ready.
+	SystemHealthyType SystemHealthStatusType = iota
+
+	// SystemDegradedType indicates that every service is live but at least one is not ready.
+	SystemDegradedType
+
+	// SystemUnhealthyType indicates that the system is not started or a service is not live.
+	SystemUnhealthyType
+)
+
+// String returns the string representation of the health status.
+func (t SystemHealthStatusType) String() string {
+	switch t {
+	case SystemHealthyType:
+		return "healthy"
+	case SystemDegradedType:
+		return "degraded"
+	case SystemUnhealthyType:
+		return "unhealthy"
+	default:
+		return "unknown"
+	}
+}
+
+// ServiceHealth represents the health reported for a single service.
+type ServiceHealth struct {
+	component.HealthStatus
+	ID       string // ID of the service
+	Required bool   // Required reports whether the health of the service counts towards the health of the system
+}
+
+// SystemHealth represents the aggregated health of the system and its services.
+type SystemHealth struct {
+	Status       SystemHealthStatusType // Aggregated health of the system
+	SystemStatus SystemStatusType       // Lifecycle status of the system
+	Services     []ServiceHealth        // Health of each registered service, sorted by ID
+}
+
+// Service returns the health of the service with the given ID.
+func (h *SystemHealth) Service(id string) (ServiceHealth, bool) {
+	for _, service := range h.Services {
+		if service.ID == id {
+			return service, true
+		}
+	}
+	return ServiceHealth{}, false
+}
+
+// Health returns the aggregated health of the system and all registered services.
+// Services that do not implement HealthReporterInterface are considered live and ready
+// if they were started, by the system or with StartService. The services configured not to
+// start with the system are not required while they are not started, and do not count
+// towards the health of the system.
+func (s *SystemImpl) Health(ctx *context.Context) *SystemHealth {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	health := &SystemHealth{
+		Status:       SystemHealthyType,
+		SystemStatus: s.status,
+	}
+	if s.status != SystemStartedType {
+		health.Status = SystemUnhealthyType
+	}
+
+	for _, comp := range s.ComponentRegistry().GetComponentsByType(component.ServiceType) {
+		service, ok := comp.(SystemServiceInterface)
+		if !ok {
+			continue
+		}
+
+		status := s.serviceHealth(ctx, service)
+		required := s.startsWithSystem(service.ID()) || s.isStarted(service.ID())
+		health.Services = append(health.Services, ServiceHealth{HealthStatus: status, ID: service.ID(), Required: required})
+
+		switch {
+		case !required:
+		case !status.Live:
+			health.Status = SystemUnhealthyType
+		case !status.Ready && health.Status == SystemHealthyType:
+			health.Status = SystemDegradedType
+		}
+	}
+
+	sort.Slice(health.Services, func(i, j int) bool {
+		return health.Services[i].ID < health.Services[j].ID
+	})
+	return health
+}
+
+// serviceHealth returns the health of the given service.
+func (s *SystemImpl) serviceHealth(ctx *context.Context, service SystemServiceInterface) component.HealthStatus {
+	if reporter, ok := service.(component.HealthReporterInterface); ok {
+		return reporter.Health(ctx)
+	}
+
+	if s.isStarted(service.ID()) {
+		return component.HealthStatus{Live: true, Ready: true, Reason: "started"}
+	}
+	return component.HealthStatus{Reason: "not started"}
+}
+
+// isStarted returns whether the service with the given ID was started by the system, or is
+// recorded as started by the component registry.
+func (s *SystemImpl) isStarted(id string) bool {
+	for _, started := range s.started {
+		if started.ID() == id {
+			return true
+		}
+	}
+	tracker, ok := s.ComponentRegistry().(component.StartTrackerInterface)
+	return ok && tracker.Started(id)
+}
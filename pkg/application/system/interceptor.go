@@ -0,0 +1,139 @@
+package system
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	"github.com/edward1christian/block-forge/pkg/application/common/logger"
+)
+
+// OperationInvoker invokes the given operation with the given input.
+type OperationInvoker func(
+	ctx *context.Context,
+	operation SystemOperationInterface,
+	input *SystemOperationInput) (*SystemOperationOutput, error)
+
+// OperationInterceptor intercepts the execution of an operation.
+// An interceptor continues the execution by calling next, and may inspect or replace
+// the input before the call and the output or error after it.
+// Returning without calling next short-circuits the execution.
+type OperationInterceptor func(
+	ctx *context.Context,
+	operation SystemOperationInterface,
+	input *SystemOperationInput,
+	next OperationInvoker) (*SystemOperationOutput, error)
+
+// ChainOperationInterceptors combines the given interceptors into a single interceptor.
+// The first interceptor is the outermost one.
+func ChainOperationInterceptors(interceptors ...OperationInterceptor) OperationInterceptor {
+	return func(
+		ctx *context.Context,
+		operation SystemOperationInterface,
+		input *SystemOperationInput,
+		next OperationInvoker) (*SystemOperationOutput, error) {
+		return chainInvoker(interceptors, next)(ctx, operation, input)
+	}
+}
+
+// chainInvoker wraps the given invoker with the given interceptors, the first being the outermost.
+func chainInvoker(interceptors []OperationInterceptor, invoker OperationInvoker) OperationInvoker {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], invoker
+		invoker = func(
+			ctx *context.Context,
+			operation SystemOperationInterface,
+			input *SystemOperationInput) (*SystemOperationOutput, error) {
+			return interceptor(ctx, operation, input, next)
+		}
+	}
+	return invoker
+}
+
+// invokeOperation is the invoker at the end of every chain, executing the operation.
+// The input and output are validated against the schemas of the operation, if any.
+func invokeOperation(
+	ctx *context.Context,
+	operation SystemOperationInterface,
+	input *SystemOperationInput) (*SystemOperationOutput, error) {
+	if err := validateOperationInput(operation, input); err != nil {
+		return nil, err
+	}
+
+	output, err := operation.Execute(ctx, input)
+	if err != nil {
+		return output, err
+	}
+
+	if err := validateOperationOutput(operation, output); err != nil {
+		return nil, err
+	}
+	return output, nil
+}
+
+// BeforeOperation creates an interceptor calling fn before the operation is executed.
+// The operation is not executed if fn returns an error.
+func BeforeOperation(
+	fn func(ctx *context.Context, operation SystemOperationInterface, input *SystemOperationInput) error) OperationInterceptor {
+	return func(
+		ctx *context.Context,
+		operation SystemOperationInterface,
+		input *SystemOperationInput,
+		next OperationInvoker) (*SystemOperationOutput, error) {
+		if err := fn(ctx, operation, input); err != nil {
+			return nil, err
+		}
+		return next(ctx, operation, input)
+	}
+}
+
+// AfterOperation creates an interceptor calling fn after the operation is executed.
+// The output and error returned by fn replace the ones returned by the operation.
+func AfterOperation(
+	fn func(
+		ctx *context.Context,
+		operation SystemOperationInterface,
+		input *SystemOperationInput,
+		output *SystemOperationOutput,
+		err error) (*SystemOperationOutput, error)) OperationInterceptor {
+	return func(
+		ctx *context.Context,
+		operation SystemOperationInterface,
+		input *SystemOperationInput,
+		next OperationInvoker) (*SystemOperationOutput, error) {
+		output, err := next(ctx, operation, input)
+		return fn(ctx, operation, input, output, err)
+	}
+}
+
+// RecoveryInterceptor creates an interceptor converting panics raised by the operation
+// into errors wrapping ErrOperationPanicked.
+func RecoveryInterceptor() OperationInterceptor {
+	return func(
+		ctx *context.Context,
+		operation SystemOperationInterface,
+		input *SystemOperationInput,
+		next OperationInvoker) (output *SystemOperationOutput, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				output = nil
+				err = fmt.Errorf("%w: operation %s: %v", ErrOperationPanicked, operation.ID(), r)
+			}
+		}()
+		return next(ctx, operation, input)
+	}
+}
+
+// TimingInterceptor creates an interceptor logging the duration of every operation.
+func TimingInterceptor(log logger.LoggerInterface) OperationInterceptor {
+	return func(
+		ctx *context.Context,
+		operation SystemOperationInterface,
+		input *SystemOperationInput,
+		next OperationInvoker) (*SystemOperationOutput, error) {
+		start := time.Now()
+		output, err := next(ctx, operation, input)
+		log.Logf(logger.LevelDebug, "Operation %s completed in %s (error: %v)", operation.ID(), time.Since(start), err)
+		return output, err
+	}
+}
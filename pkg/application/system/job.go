@@ -0,0 +1,325 @@
+package system
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/context"
+)
+
+const (
+	// DefaultMaxConcurrentOperations is the number of workers executing asynchronous operations.
+	DefaultMaxConcurrentOperations = 4
+
+	// DefaultOperationQueueSize is the number of asynchronous operations that can wait for a worker.
+	DefaultOperationQueueSize = 64
+
+	// DefaultJobRetention is how long completed jobs can be retrieved by their ID.
+	DefaultJobRetention = 10 * time.Minute
+)
+
+// JobStatusType represents the status of an asynchronous operation.
+type JobStatusType int
+
+const (
+	// JobPendingType indicates that the job is waiting for a worker.
+	JobPendingType JobStatusType = iota
+
+	// JobRunningType indicates that the operation is being executed.
+	JobRunningType
+
+	// JobSucceededType indicates that the operation completed successfully.
+	JobSucceededType
+
+	// JobFailedType indicates that the operation returned an error.
+	JobFailedType
+
+	// JobCanceledType indicates that the job was canceled before the operation completed.
+	JobCanceledType
+)
+
+// String returns the string representation of the job status.
+func (t JobStatusType) String() string {
+	switch t {
+	case JobPendingType:
+		return "pending"
+	case JobRunningType:
+		return "running"
+	case JobSucceededType:
+		return "succeeded"
+	case JobFailedType:
+		return "failed"
+	case JobCanceledType:
+		return "canceled"
+	default:
+		return "unknown"
+	}
+}
+
+// jobContextKey is the context key under which the running job is stored.
+type jobContextKey struct{}
+
+// Job is a handle on an operation executed asynchronously.
+type Job struct {
+	mutex       sync.RWMutex
+	id          string
+	operationID string
+	status      JobStatusType
+	progress    float64
+	output      *SystemOperationOutput
+	err         error
+	ctx         *context.Context
+	cancel      func()
+	done        chan struct{}
+}
+
+// newJob creates a new pending job executing the given operation with a cancelable context,
+// derived from the background context if ctx is nil.
+func newJob(ctx *context.Context, id, operationID string) *Job {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	job := &Job{
+		id:          id,
+		operationID: operationID,
+		done:        make(chan struct{}),
+	}
+
+	jobCtx, cancel := context.WithCancel(ctx)
+	job.ctx = jobCtx.WithValue(jobContextKey{}, job)
+	job.cancel = cancel
+	return job
+}
+
+// ID returns the unique identifier of the job.
+func (j *Job) ID() string {
+	return j.id
+}
+
+// OperationID returns the ID of the operation executed by the job.
+func (j *Job) OperationID() string {
+	return j.operationID
+}
+
+// Status returns the current status of the job.
+func (j *Job) Status() JobStatusType {
+	j.mutex.RLock()
+	defer j.mutex.RUnlock()
+	return j.status
+}
+
+// Progress returns the progress reported by the operation, between 0 and 1.
+func (j *Job) Progress() float64 {
+	j.mutex.RLock()
+	defer j.mutex.RUnlock()
+	return j.progress
+}
+
+// Cancel cancels the context of the operation. A pending job is not executed.
+func (j *Job) Cancel() {
+	j.cancel()
+}
+
+// Done returns a channel that is closed when the job completes.
+func (j *Job) Done() <-chan struct{} {
+	return j.done
+}
+
+// Wait blocks until the job completes and returns the output and error of the operation.
+func (j *Job) Wait() (*SystemOperationOutput, error) {
+	<-j.done
+
+	j.mutex.RLock()
+	defer j.mutex.RUnlock()
+	return j.output, j.err
+}
+
+// run executes the operation of the job with the given invoker.
+func (j *Job) run(operation SystemOperationInterface, input *SystemOperationInput, invoker OperationInvoker) {
+	defer close(j.done)
+	defer j.cancel()
+
+	if err := j.ctx.Err(); err != nil {
+		j.finish(nil, err)
+		return
+	}
+
+	j.mutex.Lock()
+	j.status = JobRunningType
+	j.mutex.Unlock()
+
+	j.finish(invoker(j.ctx, operation, input))
+}
+
+// finish records the result of the operation.
+func (j *Job) finish(output *SystemOperationOutput, err error) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	j.output, j.err = output, err
+	switch {
+	case err != nil && j.ctx.Err() != nil:
+		j.status = JobCanceledType
+	case err != nil:
+		j.status = JobFailedType
+	default:
+		j.status = JobSucceededType
+		j.progress = 1
+	}
+}
+
+// setProgress records the progress of the operation, clamped between 0 and 1.
+func (j *Job) setProgress(progress float64) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	switch {
+	case progress < 0:
+		progress = 0
+	case progress > 1:
+		progress = 1
+	}
+	j.progress = progress
+}
+
+// JobFromContext returns the job executing the operation with the given context.
+func JobFromContext(ctx *context.Context) (*Job, bool) {
+	job, ok := ctx.Value(jobContextKey{}).(*Job)
+	return job, ok
+}
+
+// ReportProgress reports the progress, between 0 and 1, of the asynchronous operation
+// executed with the given context. It does nothing for synchronous executions.
+func ReportProgress(ctx *context.Context, progress float64) {
+	if job, ok := JobFromContext(ctx); ok {
+		job.setProgress(progress)
+	}
+}
+
+// jobTask is a job queued for execution.
+type jobTask struct {
+	job       *Job
+	operation SystemOperationInterface
+	input     *SystemOperationInput
+	invoker   OperationInvoker
+	release   func() // Called once the job completes
+}
+
+// jobPool executes jobs with a bounded number of workers.
+// Completed jobs are forgotten once the retention period has elapsed.
+type jobPool struct {
+	mutex     sync.Mutex
+	workers   int
+	retention time.Duration
+	queue     chan jobTask
+	jobs      map[string]*Job
+	nextID    uint64
+	started   bool
+}
+
+// newJobPool creates a new job pool. Workers are started with the first submitted job.
+func newJobPool(workers, queueSize int, retention time.Duration) *jobPool {
+	if workers <= 0 {
+		workers = DefaultMaxConcurrentOperations
+	}
+	if queueSize <= 0 {
+		queueSize = DefaultOperationQueueSize
+	}
+	if retention <= 0 {
+		retention = DefaultJobRetention
+	}
+
+	return &jobPool{
+		workers:   workers,
+		retention: retention,
+		queue:     make(chan jobTask, queueSize),
+		jobs:      make(map[string]*Job),
+	}
+}
+
+// submit queues the given operation for execution and returns its job.
+// The release function is called once the job completes.
+// Returns an error if the queue is full.
+func (p *jobPool) submit(
+	ctx *context.Context,
+	operation SystemOperationInterface,
+	input *SystemOperationInput,
+	invoker OperationInvoker,
+	release func()) (*Job, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if !p.started {
+		for i := 0; i < p.workers; i++ {
+			go p.work(p.queue)
+		}
+		p.started = true
+	}
+
+	p.nextID++
+	job := newJob(ctx, fmt.Sprintf("job-%d", p.nextID), operation.ID())
+
+	select {
+	case p.queue <- jobTask{job: job, operation: operation, input: input, invoker: invoker, release: release}:
+	default:
+		job.cancel()
+		return nil, fmt.Errorf("%w: operation %s", ErrJobQueueFull, operation.ID())
+	}
+
+	p.jobs[job.ID()] = job
+	return job, nil
+}
+
+// get returns the job with the given ID.
+func (p *jobPool) get(jobID string) (*Job, bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	job, ok := p.jobs[jobID]
+	return job, ok
+}
+
+// cancelAll cancels every job that has not completed.
+func (p *jobPool) cancelAll() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for _, job := range p.jobs {
+		job.Cancel()
+	}
+}
+
+// stop stops the workers once they have executed the queued jobs.
+// Workers are started again with the next submitted job.
+func (p *jobPool) stop() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if !p.started {
+		return
+	}
+	close(p.queue)
+	p.queue = make(chan jobTask, cap(p.queue))
+	p.started = false
+}
+
+// forget removes the job with the given ID.
+func (p *jobPool) forget(jobID string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	delete(p.jobs, jobID)
+}
+
+// work executes the jobs of the queue until it is closed.
+// Completed jobs are forgotten once the retention period has elapsed.
+func (p *jobPool) work(queue <-chan jobTask) {
+	for task := range queue {
+		task.job.run(task.operation, task.input, task.invoker)
+		task.release()
+
+		jobID := task.job.ID()
+		time.AfterFunc(p.retention, func() { p.forget(jobID) })
+	}
+}
@@ -1,12 +1,13 @@
 package system
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"sync"
 
-	"errors"
-
 	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	"github.com/edward1christian/block-forge/pkg/application/component"
 )
 
 // PluginInterface represents a plugin in the system.
@@ -31,18 +32,34 @@ type PluginManagerInterface interface {
 	// RemovePlugin removes a plugin from the plugin manager.
 	RemovePlugin(plugin PluginInterface) error
 
+	// UnloadPlugin stops the plugin with the given ID, unregisters the components and factories
+	// it contributed and removes it from the plugin manager.
+	UnloadPlugin(ctx *context.Context, id string) error
+
+	// ReloadPlugin replaces the plugin with the given ID with the version described by its manifest.
+	ReloadPlugin(ctx *context.Context, id string) error
+
 	// GetPlugin returns the plugin with the given name.
 	GetPlugin(name string) (PluginInterface, error)
 
+	// GetPluginManifest returns the manifest of the plugin with the given ID.
+	GetPluginManifest(id string) (*PluginManifest, error)
+
 	// StartPlugins starts all plugins managed by the plugin manager.
 	StartPlugins(ctx *context.Context) error
 
 	// StopPlugins stops all plugins managed by the plugin manager.
 	StopPlugins(ctx *context.Context) error
 
+	// RegisterPluginLoader registers the loader of the plugins of the given kind.
+	RegisterPluginLoader(kind string, loader PluginLoader) error
+
 	// DiscoverPlugins discovers available plugins within the system.
 	DiscoverPlugins(ctx *context.Context) ([]PluginInterface, error)
 
+	// LoadPlugin loads the plugin described by the manifest at the given path, without adding it.
+	LoadPlugin(ctx *context.Context, manifestPath string) (PluginInterface, error)
+
 	// LoadRemotePlugin loads a plugin from a remote source.
 	LoadRemotePlugin(ctx *context.Context, pluginURL string) (PluginInterface, error)
 }
@@ -50,16 +67,26 @@ type PluginManagerInterface interface {
 // PluginManager represents functionality for managing plugins.
 type PluginManager struct {
 	PluginManagerInterface
-	mu      sync.RWMutex               // Mutex for synchronizing access to plugins map
-	plugins map[string]PluginInterface // Map to store plugins by ID
-	started bool                       // Flag to track whether the plugins have been started
-	System  SystemInterface
+	mu         sync.RWMutex               // Mutex for synchronizing access to plugins map
+	plugins    map[string]PluginInterface // Map to store plugins by ID
+	order      []string                   // IDs of the plugins, in the order they were added
+	started    bool                       // Flag to track whether the plugins have been started
+	startOrder []string                   // IDs of the plugins, in the order they were started
+	running    map[string]bool            // IDs of the plugins that started successfully
+	loaders    map[string]PluginLoader    // Loaders of the discovered plugins, by kind
+	manifests  map[string]*PluginManifest // Manifests of the discovered and added plugins, by ID
+	System     SystemInterface
 }
 
 // NewPluginManager creates a new instance of PluginManager.
 func NewPluginManager() PluginManagerInterface {
 	return &PluginManager{
 		plugins: make(map[string]PluginInterface),
+		loaders: map[string]PluginLoader{
+			GoPluginKind:      LoadGoPlugin,
+			ProcessPluginKind: LoadProcessPlugin,
+		},
+		manifests: make(map[string]*PluginManifest),
 	}
 }
 
@@ -71,45 +98,263 @@ func (m *PluginManager) Initialize(ctx *context.Context, system SystemInterface)
 }
 
 // AddPlugin adds a plugin to the plugin manager, initializes it, and registers its resources.
+// The components created by the plugin are recorded as owned by the plugin.
+// A plugin with a manifest is refused if it does not support the core API version of the system.
+// A plugin added once the plugins are started is started as well, after its dependencies.
+// A plugin failing to be initialized or to register its resources is discarded: the factories and
+// components it registered are unregistered and it is disposed if it holds resources.
+// The manager is locked while the plugin is initialized and registers its resources, so the
+// plugin must not call the plugin manager back from Initialize or RegisterResources.
 func (m *PluginManager) AddPlugin(ctx *context.Context, plugin PluginInterface) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	return m.addPlugin(ctx, plugin)
+}
+
+// addPlugin adds, initializes and starts the plugin if the plugins are started. The mutex must be held.
+func (m *PluginManager) addPlugin(ctx *context.Context, plugin PluginInterface) error {
 	// Check if the plugin with the same ID already exists
 	if _, exists := m.plugins[plugin.ID()]; exists {
 		return fmt.Errorf("plugin with ID %s already exists", plugin.ID())
 	}
 
-	// Initialize the plugin
-	if err := plugin.Initialize(ctx, m.System); err != nil {
-		return fmt.Errorf("failed to initialize plugin %s: %w", plugin.ID(), err)
+	// Check that the plugin is compatible with the system
+	manifest := m.manifests[plugin.ID()]
+	if manifested, ok := plugin.(ManifestedPluginInterface); ok {
+		manifest = manifested.Manifest()
 	}
-
-	// Register resources for the plugin
-	if err := plugin.RegisterResources(ctx); err != nil {
-		return fmt.Errorf("failed to register resources for plugin %s: %w", plugin.ID(), err)
+	previous, hadManifest := m.manifests[plugin.ID()]
+	if manifest != nil {
+		if manifest.ID != plugin.ID() {
+			return fmt.Errorf("%w: plugin %s declares ID %s", ErrInvalidPluginManifest, plugin.ID(), manifest.ID)
+		}
+		if err := manifest.CheckCompatibility(); err != nil {
+			return err
+		}
+		if m.manifests == nil {
+			m.manifests = make(map[string]*PluginManifest)
+		}
+		m.manifests[plugin.ID()] = manifest
+	}
+	ctx = component.WithOwner(ctx, plugin.ID())
+
+	// Initialize the plugin and register its resources, discarding it if either fails
+	err := plugin.Initialize(ctx, m.System)
+	if err != nil {
+		err = fmt.Errorf("failed to initialize plugin %s: %w", plugin.ID(), err)
+	} else if err = plugin.RegisterResources(ctx); err != nil {
+		err = fmt.Errorf("failed to register resources for plugin %s: %w", plugin.ID(), err)
+	}
+	if err != nil {
+		if discardErr := m.discardPlugin(ctx, plugin); discardErr != nil {
+			err = errors.Join(err, discardErr)
+		}
+		if hadManifest {
+			m.manifests[plugin.ID()] = previous
+		} else {
+			delete(m.manifests, plugin.ID())
+		}
+		return err
 	}
 
 	// Add the plugin to the plugins map
 	m.plugins[plugin.ID()] = plugin
+	m.order = append(m.order, plugin.ID())
+
+	// Start the plugin if the plugins have already been started
+	if m.started {
+		return m.startPlugin(ctx, plugin.ID())
+	}
 	return nil
 }
 
-// RemovePlugin removes a plugin from the plugin manager.
+// RemovePlugin unloads a plugin from the plugin manager, see UnloadPlugin.
 func (m *PluginManager) RemovePlugin(plugin PluginInterface) error {
+	return m.UnloadPlugin(context.Background(), plugin.ID())
+}
+
+// UnloadPlugin stops the plugin with the given ID if it is started, unregisters the components
+// and factories it owns, disposes it if it holds resources and removes it from the plugin manager.
+// Returns ErrPluginInUse if other plugins depend on the plugin. The plugin is removed even if it
+// fails to stop or some of its components cannot be removed, and the failures are returned.
+func (m *PluginManager) UnloadPlugin(ctx *context.Context, id string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	// Get the ID of the plugin to remove
-	id := plugin.ID()
-
 	// Check if the plugin exists
 	if _, exists := m.plugins[id]; !exists {
 		return fmt.Errorf("plugin with ID %s not found", id)
 	}
 
+	// Check that no other plugin requires it
+	var dependents []string
+	for _, pluginID := range m.order {
+		if manifest := m.manifests[pluginID]; manifest != nil && pluginID != id {
+			for _, dependency := range manifest.DependsOn {
+				if dependency.ID == id {
+					dependents = append(dependents, pluginID)
+					break
+				}
+			}
+		}
+	}
+	if len(dependents) > 0 {
+		return fmt.Errorf("%w: %s is required by %s", ErrPluginInUse, id, strings.Join(dependents, ", "))
+	}
+
+	return m.unloadPlugin(ctx, id)
+}
+
+// unloadPlugin stops the plugin, unregisters what it contributed and removes it. The mutex must be held.
+func (m *PluginManager) unloadPlugin(ctx *context.Context, id string) error {
+	plugin := m.plugins[id]
+	ctx = component.WithOwner(ctx, id)
+
+	var errs []error
+	if err := m.stopPlugin(ctx, id); err != nil {
+		errs = append(errs, err)
+	}
+
+	// Unregister the factories and components contributed by the plugin
+	if m.System != nil && m.System.ComponentRegistry() != nil {
+		if err := m.System.ComponentRegistry().UnregisterOwner(ctx, id); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	// Release the resources held by the plugin
+	if disposable, ok := plugin.(component.DisposableInterface); ok {
+		if err := disposable.Dispose(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("error disposing plugin %s: %w", id, err))
+		}
+	}
+
 	// Remove the plugin from the plugins map
 	delete(m.plugins, id)
+	delete(m.manifests, id)
+	for i, pluginID := range m.order {
+		if pluginID == id {
+			m.order = append(m.order[:i], m.order[i+1:]...)
+			break
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("errors unloading plugin %s: %w", id, errors.Join(errs...))
+	}
+	return nil
+}
+
+// discardPlugin releases the resources of a plugin that failed to be added, unregistering the
+// factories and components it registered before failing.
+func (m *PluginManager) discardPlugin(ctx *context.Context, plugin PluginInterface) error {
+	ctx = component.WithOwner(ctx, plugin.ID())
+
+	var errs []error
+	if m.System != nil && m.System.ComponentRegistry() != nil {
+		if err := m.System.ComponentRegistry().UnregisterOwner(ctx, plugin.ID()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if disposable, ok := plugin.(component.DisposableInterface); ok {
+		if err := disposable.Dispose(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("error disposing plugin %s: %w", plugin.ID(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ReloadPlugin replaces the plugin with the given ID with the version described by the manifest
+// file it was loaded from, without restarting the system. The new version is loaded before the
+// current one is unloaded, so that a version failing to load leaves the current one in place.
+// The started plugins depending on the plugin, directly or not, are stopped while it is replaced
+// and started again after the new version. If the new version fails to be initialized or to
+// register its resources, it is discarded and the current version is added again in its place.
+// Returns ErrPluginNotReloadable if the plugin was not loaded from a manifest file.
+//
+// Go plugins cannot be unloaded from the process: a Go plugin is only reloaded with new code
+// when its manifest points to a new library.
+func (m *PluginManager) ReloadPlugin(ctx *context.Context, id string) error {
+	m.mu.RLock()
+	_, exists := m.plugins[id]
+	manifest := m.manifests[id]
+	m.mu.RUnlock()
+
+	// Check if the plugin exists and can be reloaded
+	if !exists {
+		return fmt.Errorf("plugin with ID %s not found", id)
+	}
+	if manifest == nil || manifest.File == "" {
+		return fmt.Errorf("%w: %s was not loaded from a manifest file", ErrPluginNotReloadable, id)
+	}
+
+	// Load the new version
+	plugin, manifest, err := m.loadPlugin(ctx, manifest.File)
+	if err != nil {
+		return fmt.Errorf("failed to reload plugin %s: %w", id, err)
+	}
+	if manifest.ID != id {
+		return fmt.Errorf("%w: plugin %s now declares ID %s", ErrInvalidPluginManifest, id, manifest.ID)
+	}
+	if err := manifest.CheckCompatibility(); err != nil {
+		return fmt.Errorf("failed to reload plugin %s: %w", id, err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Stop the started plugins depending on the plugin, dependents first
+	affected := map[string]bool{id: true}
+	var dependents []string
+	for _, startedID := range m.startOrder {
+		if startedManifest := m.manifests[startedID]; startedManifest != nil {
+			for _, dependency := range startedManifest.DependsOn {
+				if affected[dependency.ID] {
+					affected[startedID] = true
+					dependents = append(dependents, startedID)
+					break
+				}
+			}
+		}
+	}
+	var errs []error
+	for i := len(dependents) - 1; i >= 0; i-- {
+		if err := m.stopPlugin(ctx, dependents[i]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	// Replace the plugin, then start its dependents again
+	current, currentManifest := m.plugins[id], m.manifests[id]
+	if err := m.unloadPlugin(ctx, id); err != nil {
+		errs = append(errs, err)
+	}
+	m.manifests[id] = manifest
+	if err := m.addPlugin(ctx, plugin); err != nil {
+		errs = append(errs, err)
+		if _, added := m.plugins[id]; !added {
+			// The new version was discarded, restore the current one
+			m.manifests[id] = currentManifest
+			if err := m.addPlugin(ctx, current); err != nil {
+				errs = append(errs, fmt.Errorf("failed to restore plugin %s: %w", id, err))
+				if _, added := m.plugins[id]; !added {
+					delete(m.manifests, id)
+				}
+			}
+		}
+	}
+	if _, added := m.plugins[id]; added && m.started {
+		for _, dependentID := range dependents {
+			if err := m.startPlugin(ctx, dependentID); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("errors reloading plugin %s: %w", id, errors.Join(errs...))
+	}
 	return nil
 }
 
@@ -127,7 +372,23 @@ func (m *PluginManager) GetPlugin(id string) (PluginInterface, error) {
 	return plugin, nil
 }
 
-// StartPlugins starts all plugins managed by the plugin manager.
+// GetPluginManifest returns the manifest of the plugin with the given ID, whether it was
+// added or only discovered.
+func (m *PluginManager) GetPluginManifest(id string) (*PluginManifest, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	manifest, exists := m.manifests[id]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrPluginManifestNotFound, id)
+	}
+	return manifest, nil
+}
+
+// StartPlugins starts all plugins managed by the plugin manager, after the plugins they depend on.
+// Plugins without dependencies between them are started in the order they were added.
+// Plugins whose dependencies are missing, have an incompatible version or failed to start are
+// not started, and are reported in the returned error along with the plugins that failed to start.
 func (m *PluginManager) StartPlugins(ctx *context.Context) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -137,11 +398,20 @@ func (m *PluginManager) StartPlugins(ctx *context.Context) error {
 		return nil
 	}
 
-	// Iterate through all plugins and start each one
+	// Iterate through the plugins in dependency order and start each one
+	ordered, refused := m.resolvePluginOrder()
 	var errs []error
-	for _, plugin := range m.plugins {
-		if err := plugin.Start(ctx); err != nil {
-			errs = append(errs, fmt.Errorf("error starting plugin %s: %w", plugin.ID(), err))
+	for _, id := range m.order {
+		if err, ok := refused[id]; ok {
+			errs = append(errs, fmt.Errorf("error starting plugin %s: %w", id, err))
+		}
+	}
+
+	m.running = make(map[string]bool, len(ordered))
+	m.startOrder = nil
+	for _, id := range ordered {
+		if err := m.startPlugin(ctx, id); err != nil {
+			errs = append(errs, err)
 		}
 	}
 
@@ -150,13 +420,150 @@ func (m *PluginManager) StartPlugins(ctx *context.Context) error {
 
 	// Check if there were any errors starting plugins
 	if len(errs) > 0 {
-		return fmt.Errorf("errors starting plugins: %v", errs)
+		return fmt.Errorf("errors starting plugins: %w", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// startPlugin starts the plugin with the given ID if its dependencies are running with a compatible
+// version. The mutex must be held.
+func (m *PluginManager) startPlugin(ctx *context.Context, id string) error {
+	if dependency := m.stoppedDependency(id, m.running); dependency != "" {
+		return fmt.Errorf("error starting plugin %s: %w: %s was not started",
+			id, ErrPluginDependencyNotFound, dependency)
+	}
+	if manifest := m.manifests[id]; manifest != nil {
+		for _, dependency := range manifest.DependsOn {
+			if err := m.checkDependencyVersion(id, dependency); err != nil {
+				return fmt.Errorf("error starting plugin %s: %w", id, err)
+			}
+		}
+	}
+
+	if err := m.plugins[id].Start(component.WithOwner(ctx, id)); err != nil {
+		return fmt.Errorf("error starting plugin %s: %w", id, err)
+	}
+	m.startOrder = append(m.startOrder, id)
+	m.running[id] = true
+	return nil
+}
+
+// stopPlugin stops the plugin with the given ID if it was started. The mutex must be held.
+func (m *PluginManager) stopPlugin(ctx *context.Context, id string) error {
+	for i, startedID := range m.startOrder {
+		if startedID == id {
+			m.startOrder = append(m.startOrder[:i], m.startOrder[i+1:]...)
+			delete(m.running, id)
+			if err := m.plugins[id].Stop(ctx); err != nil {
+				return fmt.Errorf("error stopping plugin %s: %w", id, err)
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+// resolvePluginOrder orders the plugins so that every plugin comes after the plugins it depends on.
+// It returns the ordered plugin IDs, and the errors of the plugins that cannot be started because
+// a dependency is missing, has an incompatible version or belongs to a cycle.
+func (m *PluginManager) resolvePluginOrder() ([]string, map[string]error) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(m.order))
+	refused := make(map[string]error)
+	ordered := make([]string, 0, len(m.order))
+
+	// visit performs a depth-first traversal, appending a plugin once all its dependencies are appended
+	var visit func(id string, path []string) error
+	visit = func(id string, path []string) error {
+		switch state[id] {
+		case visited:
+			return refused[id]
+		case visiting:
+			return fmt.Errorf("%w: %s", ErrPluginDependencyCycle, strings.Join(append(path, id), " -> "))
+		}
+
+		state[id] = visiting
+		var err error
+		if manifest := m.manifests[id]; manifest != nil {
+			for _, dependency := range manifest.DependsOn {
+				if _, exists := m.plugins[dependency.ID]; !exists {
+					err = fmt.Errorf("%w: plugin %s requires %s", ErrPluginDependencyNotFound, id, dependency.ID)
+					break
+				}
+				if depErr := visit(dependency.ID, append(path, id)); depErr != nil {
+					err = depErr
+					if !errors.Is(depErr, ErrPluginDependencyCycle) {
+						err = fmt.Errorf("%w: plugin %s requires %s, which cannot be started",
+							ErrPluginDependencyNotFound, id, dependency.ID)
+					}
+					break
+				}
+				if err = m.checkDependencyVersion(id, dependency); err != nil {
+					break
+				}
+			}
+		}
+		state[id] = visited
+
+		if err != nil {
+			refused[id] = err
+			return err
+		}
+		ordered = append(ordered, id)
+		return nil
+	}
+
+	for _, id := range m.order {
+		visit(id, nil)
+	}
+	return ordered, refused
+}
+
+// checkDependencyVersion checks that the added plugin satisfying the dependency of the plugin
+// with the given ID has a version in the required range.
+func (m *PluginManager) checkDependencyVersion(id string, dependency PluginDependency) error {
+	if dependency.Version == "" {
+		return nil
+	}
+	required, err := ParseVersionRange(dependency.Version)
+	if err != nil {
+		return err
 	}
 
+	manifest := m.manifests[dependency.ID]
+	if manifest == nil || manifest.Version == "" {
+		return fmt.Errorf("%w: plugin %s requires %s %s, which does not declare its version",
+			ErrIncompatiblePlugin, id, dependency.ID, required)
+	}
+	version, err := ParseVersion(manifest.Version)
+	if err != nil {
+		return err
+	}
+	if !required.Contains(version) {
+		return fmt.Errorf("%w: plugin %s requires %s %s, found %s",
+			ErrIncompatiblePlugin, id, dependency.ID, required, version)
+	}
 	return nil
 }
 
-// StopPlugins stops all plugins managed by the plugin manager.
+// stoppedDependency returns the first dependency of the plugin with the given ID that is not running.
+func (m *PluginManager) stoppedDependency(id string, running map[string]bool) string {
+	if manifest := m.manifests[id]; manifest != nil {
+		for _, dependency := range manifest.DependsOn {
+			if !running[dependency.ID] {
+				return dependency.ID
+			}
+		}
+	}
+	return ""
+}
+
+// StopPlugins stops all plugins managed by the plugin manager, in the reverse order they were started.
 func (m *PluginManager) StopPlugins(ctx *context.Context) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
@@ -166,9 +573,13 @@ func (m *PluginManager) StopPlugins(ctx *context.Context) error {
 		return nil
 	}
 
-	// Iterate through all plugins and stop each one
+	// Iterate through the started plugins in reverse order and stop each one
 	var errs []error
-	for _, plugin := range m.plugins {
+	for i := len(m.startOrder) - 1; i >= 0; i-- {
+		plugin, exists := m.plugins[m.startOrder[i]]
+		if !exists {
+			continue
+		}
 		if err := plugin.Stop(ctx); err != nil {
 			errs = append(errs, fmt.Errorf("error stopping plugin %s: %w", plugin.ID(), err))
 		}
@@ -176,6 +587,8 @@ func (m *PluginManager) StopPlugins(ctx *context.Context) error {
 
 	// Update the started flag
 	m.started = false
+	m.startOrder = nil
+	m.running = nil
 
 	// Check if there were any errors stopping plugins
 	if len(errs) > 0 {
@@ -185,14 +598,94 @@ func (m *PluginManager) StopPlugins(ctx *context.Context) error {
 	return nil
 }
 
-// DiscoverPlugins discovers available plugins within the system.
+// RegisterPluginLoader registers the loader of the plugins of the given kind.
+// Returns an error if a loader is already registered for the kind.
+func (m *PluginManager) RegisterPluginLoader(kind string, loader PluginLoader) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.loaders[kind]; exists {
+		return fmt.Errorf("%w: %s", ErrPluginLoaderAlreadyExists, kind)
+	}
+	if m.loaders == nil {
+		m.loaders = make(map[string]PluginLoader)
+	}
+	m.loaders[kind] = loader
+	return nil
+}
+
+// DiscoverPlugins discovers the plugins described by the manifests found in the plugin paths
+// of the context and loads them with the loader registered for their kind, followed by the
+// plugin bundles at the remote plugin locations of the context, see LoadRemotePlugin.
+// The discovered plugins are not added to the manager. Plugins failing to load are skipped
+// and reported in the returned error, along with the plugins that were loaded.
 func (m *PluginManager) DiscoverPlugins(ctx *context.Context) ([]PluginInterface, error) {
-	// Implement logic to discover available plugins
-	return nil, errors.New("not implemented")
+	paths, err := FindPluginManifests(ctx.PluginPaths)
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover plugins: %w", err)
+	}
+
+	var plugins []PluginInterface
+	var errs []error
+	for _, path := range paths {
+		plugin, err := m.LoadPlugin(ctx, path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		plugins = append(plugins, plugin)
+	}
+	for _, location := range ctx.RemotePluginLocations {
+		plugin, err := m.LoadRemotePlugin(ctx, location)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		plugins = append(plugins, plugin)
+	}
+	return plugins, errors.Join(errs...)
+}
+
+// LoadPlugin loads the plugin described by the manifest at the given path with the loader
+// registered for its kind. The plugin is not added to the manager.
+func (m *PluginManager) LoadPlugin(ctx *context.Context, manifestPath string) (PluginInterface, error) {
+	plugin, manifest, err := m.loadPlugin(ctx, manifestPath)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the manifest for the plugins that do not provide one, unless a plugin with the same ID is added
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, added := m.plugins[manifest.ID]; !added {
+		if m.manifests == nil {
+			m.manifests = make(map[string]*PluginManifest)
+		}
+		m.manifests[manifest.ID] = manifest
+	}
+	return plugin, nil
 }
 
-// LoadRemotePlugin loads a plugin from a remote source.
-func (m *PluginManager) LoadRemotePlugin(ctx *context.Context, pluginURL string) (PluginInterface, error) {
-	// Implement logic to load a plugin from a remote source
-	return nil, errors.New("not implemented")
+// loadPlugin loads the plugin described by the manifest at the given path, and returns it with its manifest.
+func (m *PluginManager) loadPlugin(ctx *context.Context, path string) (PluginInterface, *PluginManifest, error) {
+	manifest, err := ReadPluginManifest(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	m.mu.RLock()
+	loader, exists := m.loaders[manifest.Kind]
+	m.mu.RUnlock()
+	if !exists {
+		return nil, nil, fmt.Errorf("%w: %s of plugin %s", ErrPluginLoaderNotFound, manifest.Kind, manifest.ID)
+	}
+
+	plugin, err := loader(ctx, manifest)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load plugin %s: %w", manifest.ID, err)
+	}
+	if plugin.ID() != manifest.ID {
+		return nil, nil, fmt.Errorf("%w: plugin %s declares ID %s", ErrInvalidPluginManifest, plugin.ID(), manifest.ID)
+	}
+	return plugin, manifest, nil
 }
@@ -0,0 +1,24 @@
+//go:build linux
+
+package system
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// applyPluginLimits applies the resource limits to the plugin process with the given ID.
+func applyPluginLimits(pid int, limits PluginLimits) error {
+	for resource, limit := range map[int]uint64{
+		unix.RLIMIT_AS:     limits.MemoryBytes,
+		unix.RLIMIT_CPU:    limits.CPUSeconds,
+		unix.RLIMIT_NOFILE: limits.OpenFiles,
+	} {
+		if limit == 0 {
+			continue
+		}
+		if err := unix.Prlimit(pid, resource, &unix.Rlimit{Cur: limit, Max: limit}, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+//go:build !linux
+
+package system
+
+// applyPluginLimits applies the resource limits to the plugin process with the given ID.
+// Limits are only supported on Linux.
+func applyPluginLimits(pid int, limits PluginLimits) error {
+	if limits != (PluginLimits{}) {
+		return ErrPluginLimitsUnsupported
+	}
+	return nil
+}
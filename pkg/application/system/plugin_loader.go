@@ -0,0 +1,219 @@
+package system
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	goplugin "plugin"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/context"
+)
+
+const (
+	// PluginManifestFile is the name of the manifest describing a plugin in its directory.
+	PluginManifestFile = "plugin.json"
+
+	// GoPluginKind is the kind of the plugins built as Go plugins (.so files).
+	GoPluginKind = "go"
+
+	// GoPluginSymbol is the symbol looked up in Go plugins when the manifest does not set one.
+	GoPluginSymbol = "NewPlugin"
+)
+
+// PluginManifest describes a plugin shipped outside of the core binary.
+type PluginManifest struct {
+	ID          string   `json:"id"`                    // ID of the plugin
+	Name        string   `json:"name,omitempty"`        // Name of the plugin
+	Description string   `json:"description,omitempty"` // Description of the plugin
+	Version     string   `json:"version,omitempty"`     // Semantic version of the plugin
+	Kind        string   `json:"kind"`                  // Kind of the plugin, selecting its loader
+	Path        string   `json:"path"`                  // Path of the plugin entry point, relative to the manifest
+	Symbol      string   `json:"symbol,omitempty"`      // Symbol exported by a Go plugin
+	Args        []string `json:"args,omitempty"`        // Arguments passed to the plugin entry point
+	Dir         string   `json:"-"`                     // Directory holding the manifest
+	File        string   `json:"-"`                     // Path of the manifest, if it was read from a file
+
+	// Compatibility of the plugin
+	CoreAPIVersion string             `json:"coreApiVersion,omitempty"` // Range of the core API versions supported by the plugin
+	DependsOn      []PluginDependency `json:"dependsOn,omitempty"`      // Plugins that must be started before the plugin
+
+	// Settings of the plugins running in a child process
+	Env           map[string]string `json:"env,omitempty"`           // Environment variables added to the process
+	RestartPolicy string            `json:"restartPolicy,omitempty"` // Restart policy of the process: never, on-failure or always
+	MaxRestarts   int               `json:"maxRestarts,omitempty"`   // Maximum number of restarts, 0 for unlimited
+	Limits        PluginLimits      `json:"limits,omitempty"`        // Resources the process may use
+}
+
+// PluginDependency declares a plugin required by another plugin.
+type PluginDependency struct {
+	ID      string `json:"id"`                // ID of the required plugin
+	Version string `json:"version,omitempty"` // Range of the versions of the required plugin, any version if empty
+}
+
+// ManifestedPluginInterface is implemented by plugins describing themselves with a manifest.
+// The manifest of the plugins that do not implement it is the one they were discovered with, if any.
+type ManifestedPluginInterface interface {
+	PluginInterface
+
+	// Manifest returns the manifest of the plugin.
+	Manifest() *PluginManifest
+}
+
+// PluginLoader loads the plugin described by the given manifest.
+type PluginLoader func(ctx *context.Context, manifest *PluginManifest) (PluginInterface, error)
+
+// ReadPluginManifest reads and validates the plugin manifest at the given path.
+func ReadPluginManifest(path string) (*PluginManifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest := &PluginManifest{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidPluginManifest, path, err)
+	}
+	manifest.Dir = filepath.Dir(path)
+	manifest.File = path
+
+	if err := manifest.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", err, path)
+	}
+	return manifest, nil
+}
+
+// Validate checks that the manifest sets the fields required to load the plugin,
+// and that its versions and version ranges are valid.
+func (m *PluginManifest) Validate() error {
+	switch {
+	case m.ID == "":
+		return fmt.Errorf("%w: missing id", ErrInvalidPluginManifest)
+	case m.Kind == "":
+		return fmt.Errorf("%w: missing kind of plugin %s", ErrInvalidPluginManifest, m.ID)
+	case m.Path == "":
+		return fmt.Errorf("%w: missing path of plugin %s", ErrInvalidPluginManifest, m.ID)
+	}
+	return m.validateVersions()
+}
+
+// validateVersions checks the version of the plugin and the version ranges it declares.
+func (m *PluginManifest) validateVersions() error {
+	if m.Version != "" {
+		if _, err := ParseVersion(m.Version); err != nil {
+			return fmt.Errorf("%w: version of plugin %s: %v", ErrInvalidPluginManifest, m.ID, err)
+		}
+	}
+	if _, err := ParseVersionRange(m.CoreAPIVersion); err != nil {
+		return fmt.Errorf("%w: core API version of plugin %s: %v", ErrInvalidPluginManifest, m.ID, err)
+	}
+	for _, dependency := range m.DependsOn {
+		if dependency.ID == "" {
+			return fmt.Errorf("%w: dependency without id in plugin %s", ErrInvalidPluginManifest, m.ID)
+		}
+		if _, err := ParseVersionRange(dependency.Version); err != nil {
+			return fmt.Errorf("%w: version of dependency %s of plugin %s: %v", ErrInvalidPluginManifest, dependency.ID, m.ID, err)
+		}
+	}
+	return nil
+}
+
+// CheckCompatibility returns an ErrIncompatiblePlugin error if the plugin does not support
+// the core API version of the system.
+func (m *PluginManifest) CheckCompatibility() error {
+	if err := m.validateVersions(); err != nil {
+		return err
+	}
+	supported, _ := ParseVersionRange(m.CoreAPIVersion)
+	core, _ := ParseVersion(CoreAPIVersion)
+	if !supported.Contains(core) {
+		return fmt.Errorf("%w: plugin %s requires core API %s, the system provides %s",
+			ErrIncompatiblePlugin, m.ID, supported, CoreAPIVersion)
+	}
+	return nil
+}
+
+// EntryPath returns the path of the plugin entry point, resolved against the manifest directory.
+func (m *PluginManifest) EntryPath() string {
+	if filepath.IsAbs(m.Path) {
+		return m.Path
+	}
+	return filepath.Join(m.Dir, m.Path)
+}
+
+// FindPluginManifests returns the paths of the plugin manifests found in the given paths.
+// A path may be a manifest, a plugin directory holding a manifest, or a directory whose
+// subdirectories are plugin directories. Paths that do not exist are ignored.
+func FindPluginManifests(paths []string) ([]string, error) {
+	var manifests []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		// The path is a manifest or a plugin directory
+		if !info.IsDir() {
+			manifests = append(manifests, path)
+			continue
+		}
+		if manifest := filepath.Join(path, PluginManifestFile); fileExists(manifest) {
+			manifests = append(manifests, manifest)
+			continue
+		}
+
+		// The path holds plugin directories, in lexical order
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			manifest := filepath.Join(path, entry.Name(), PluginManifestFile)
+			if entry.IsDir() && fileExists(manifest) {
+				manifests = append(manifests, manifest)
+			}
+		}
+	}
+	return manifests, nil
+}
+
+// LoadGoPlugin loads a plugin built as a Go plugin. The plugin exports a function returning the
+// plugin, with or without an error, or a variable holding it, under the symbol of the manifest.
+func LoadGoPlugin(ctx *context.Context, manifest *PluginManifest) (PluginInterface, error) {
+	library, err := goplugin.Open(manifest.EntryPath())
+	if err != nil {
+		return nil, err
+	}
+
+	name := manifest.Symbol
+	if name == "" {
+		name = GoPluginSymbol
+	}
+	symbol, err := library.Lookup(name)
+	if err != nil {
+		return nil, err
+	}
+
+	switch value := symbol.(type) {
+	case func() PluginInterface:
+		return value(), nil
+	case func() (PluginInterface, error):
+		return value()
+	case *PluginInterface:
+		return *value, nil
+	case PluginInterface:
+		return value, nil
+	default:
+		return nil, fmt.Errorf("symbol %s of plugin %s has unsupported type %T", name, manifest.ID, symbol)
+	}
+}
+
+// fileExists returns whether the given path exists and is not a directory.
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,421 @@
+package system
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	"github.com/edward1christian/block-forge/pkg/application/common/logger"
+	"github.com/edward1christian/block-forge/pkg/application/component"
+	"github.com/edward1christian/block-forge/pkg/application/config"
+)
+
+const (
+	// ProcessPluginKind is the kind of the plugins running in a child process.
+	ProcessPluginKind = "process"
+
+	// DefaultPluginStopTimeout is the time a plugin process is given to exit once stopped, before it is killed.
+	DefaultPluginStopTimeout = 5 * time.Second
+
+	// DefaultPluginRestartTimeout is the time a restarted plugin process is given to be initialized
+	// and started, before it is killed.
+	DefaultPluginRestartTimeout = 30 * time.Second
+)
+
+// PluginLimits defines the resources a plugin process may use. Zero values are unlimited.
+type PluginLimits struct {
+	MemoryBytes uint64 `json:"memoryBytes,omitempty"` // Maximum size of the address space of the process
+	CPUSeconds  uint64 `json:"cpuSeconds,omitempty"`  // Maximum CPU time of the process
+	OpenFiles   uint64 `json:"openFiles,omitempty"`   // Maximum number of files opened by the process
+}
+
+// ProcessPlugin is a plugin running in a child process, so that its code is not linked into the host.
+// It proxies the lifecycle of the plugin and the operations it contributes to the process over
+// the plugin protocol, on the standard input and output of the process. When the process exits
+// unexpectedly, pending calls fail with ErrPluginCrashed and the process is restarted according
+// to the restart policy.
+type ProcessPlugin struct {
+	BaseSystemService
+	manifest  *PluginManifest
+	policy    RestartPolicy
+	mutex     sync.Mutex
+	cmd       *exec.Cmd
+	stdin     io.WriteCloser
+	conn      *pluginConn
+	exited    chan struct{} // Closed when the current process exits
+	handshake PluginHandshake
+	started   bool // Whether the host started the plugin
+	stopping  bool // Whether the host is stopping the plugin
+	attempts  int  // Number of restarts since the last successful start
+
+	StopTimeout    time.Duration // Time the process is given to exit once stopped, before it is killed
+	RestartTimeout time.Duration // Time a restarted process is given to be initialized and started, before it is killed
+}
+
+// pluginProcess is a plugin process that completed the handshake.
+type pluginProcess struct {
+	cmd       *exec.Cmd
+	stdin     io.WriteCloser
+	conn      *pluginConn
+	handshake PluginHandshake
+}
+
+// kill kills the process and waits for it to exit.
+func (proc *pluginProcess) kill() {
+	proc.cmd.Process.Kill()
+	proc.cmd.Wait()
+}
+
+// NewProcessPlugin creates a plugin running the entry point of the given manifest in a child process.
+// Returns an error if the restart policy of the manifest is unknown.
+func NewProcessPlugin(manifest *PluginManifest) (*ProcessPlugin, error) {
+	policy, err := RestartPolicyFromConfig(&config.ServiceConfiguration{
+		ComponentConfig:    config.ComponentConfig{ID: manifest.ID},
+		RestartPolicy:      manifest.RestartPolicy,
+		MaxRestartAttempts: manifest.MaxRestarts,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ProcessPlugin{
+		BaseSystemService: *NewBaseSystemService(manifest.ID, manifest.Name, manifest.Description),
+		manifest:          manifest,
+		policy:            policy,
+		StopTimeout:       DefaultPluginStopTimeout,
+		RestartTimeout:    DefaultPluginRestartTimeout,
+	}, nil
+}
+
+// LoadProcessPlugin is the loader of the plugins of kind ProcessPluginKind.
+func LoadProcessPlugin(ctx *context.Context, manifest *PluginManifest) (PluginInterface, error) {
+	return NewProcessPlugin(manifest)
+}
+
+// Initialize starts the plugin process, checks that it speaks the protocol of the host
+// and initializes the plugin. The process of a previous initialization is terminated first.
+func (p *ProcessPlugin) Initialize(ctx *context.Context, system SystemInterface) error {
+	p.System = system
+	if err := p.shutdown(ctx, false); err != nil {
+		return err
+	}
+
+	process, err := p.spawn(ctx)
+	if err != nil {
+		return err
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.attach(process)
+	return nil
+}
+
+// RegisterResources registers the operations contributed by the plugin with the component registrar,
+// each created by a factory of its own.
+func (p *ProcessPlugin) RegisterResources(ctx *context.Context) error {
+	p.mutex.Lock()
+	operations := p.handshake.Operations
+	p.mutex.Unlock()
+
+	for _, info := range operations {
+		cfg := &config.ComponentConfig{
+			ID:          info.ID,
+			Name:        info.Name,
+			Description: info.Description,
+			FactoryID:   p.ID() + "/" + info.ID,
+		}
+		if err := RegisterComponent(ctx, p.System, cfg, &processOperationFactory{plugin: p}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Start starts the plugin. As the process exits when the plugin is stopped, a stopped plugin
+// is started in a new process, initialized again.
+func (p *ProcessPlugin) Start(ctx *context.Context) error {
+	p.mutex.Lock()
+	conn, stopping := p.conn, p.stopping
+	p.mutex.Unlock()
+
+	if conn == nil {
+		if !stopping {
+			return fmt.Errorf("%w: %s", ErrPluginNotRunning, p.ID())
+		}
+		process, err := p.spawn(ctx)
+		if err != nil {
+			return err
+		}
+
+		p.mutex.Lock()
+		if p.conn != nil {
+			// The plugin was started concurrently
+			p.mutex.Unlock()
+			process.kill()
+			return p.Start(ctx)
+		}
+		p.attach(process)
+		conn = p.conn
+		p.mutex.Unlock()
+	}
+
+	if err := conn.call(ctx, pluginMethodStart, nil, nil); err != nil {
+		return err
+	}
+	p.mutex.Lock()
+	p.started = true
+	p.attempts = 0
+	p.mutex.Unlock()
+	return nil
+}
+
+// Stop stops the plugin and waits for its process to exit, killing it after StopTimeout.
+func (p *ProcessPlugin) Stop(ctx *context.Context) error {
+	return p.shutdown(ctx, true)
+}
+
+// Dispose terminates the plugin process if it is still running, such as when the plugin is
+// unloaded without having been started.
+func (p *ProcessPlugin) Dispose(ctx *context.Context) error {
+	return p.shutdown(ctx, false)
+}
+
+// shutdown waits for the plugin process to exit after closing its input, killing it after
+// StopTimeout. The plugin is asked to stop first if requested.
+func (p *ProcessPlugin) shutdown(ctx *context.Context, stop bool) error {
+	p.mutex.Lock()
+	p.stopping = true
+	p.started = false
+	conn, stdin, exited, cmd := p.conn, p.stdin, p.exited, p.cmd
+	p.mutex.Unlock()
+
+	if conn == nil {
+		return nil
+	}
+	var err error
+	if stop {
+		err = conn.call(ctx, pluginMethodStop, nil, nil)
+	}
+
+	// Closing the input of the process asks it to exit
+	stdin.Close()
+	select {
+	case <-exited:
+	case <-time.After(p.StopTimeout):
+		cmd.Process.Kill()
+		<-exited
+	}
+	return err
+}
+
+// Manifest returns the manifest the plugin was loaded from.
+func (p *ProcessPlugin) Manifest() *PluginManifest {
+	return p.manifest
+}
+
+// Operations returns the operations contributed by the plugin, sorted by ID.
+func (p *ProcessPlugin) Operations() []PluginOperationInfo {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	operations := append([]PluginOperationInfo{}, p.handshake.Operations...)
+	sort.Slice(operations, func(i, j int) bool { return operations[i].ID < operations[j].ID })
+	return operations
+}
+
+// Pid returns the ID of the plugin process, 0 if the process is not running.
+func (p *ProcessPlugin) Pid() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.conn == nil {
+		return 0
+	}
+	return p.cmd.Process.Pid
+}
+
+// execute executes an operation of the plugin in the plugin process.
+func (p *ProcessPlugin) execute(ctx *context.Context, operationID string, input *SystemOperationInput) (*SystemOperationOutput, error) {
+	p.mutex.Lock()
+	conn := p.conn
+	p.mutex.Unlock()
+	if conn == nil {
+		return nil, fmt.Errorf("%w: %s", ErrPluginNotRunning, p.ID())
+	}
+
+	params := &pluginExecuteParams{OperationID: operationID}
+	if input != nil && input.Data != nil {
+		data, err := json.Marshal(input.Data)
+		if err != nil {
+			return nil, &OperationValidationError{Err: err}
+		}
+		params.Input = data
+	}
+
+	var output interface{}
+	if err := conn.call(ctx, pluginMethodExecute, params, &output); err != nil {
+		return nil, err
+	}
+	return &SystemOperationOutput{Data: output}, nil
+}
+
+// spawn starts a plugin process and initializes the plugin. The mutex must not be held, as the
+// process may take until the deadline of the context to answer.
+func (p *ProcessPlugin) spawn(ctx *context.Context) (*pluginProcess, error) {
+	cmd := exec.Command(p.manifest.EntryPath(), p.manifest.Args...)
+	cmd.Dir = p.manifest.Dir
+	cmd.Env = os.Environ()
+	for key, value := range p.manifest.Env {
+		cmd.Env = append(cmd.Env, key+"="+value)
+	}
+	cmd.Stderr = os.Stderr
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start plugin %s: %w", p.ID(), err)
+	}
+
+	process := &pluginProcess{cmd: cmd, stdin: stdin, conn: newPluginConn(stdout, stdin)}
+	conn := process.conn
+	if err := applyPluginLimits(cmd.Process.Pid, p.manifest.Limits); err != nil {
+		process.kill()
+		return nil, fmt.Errorf("failed to limit plugin %s: %w", p.ID(), err)
+	}
+
+	// Check that both ends speak the same protocol
+	handshake := PluginHandshake{}
+	err = conn.call(ctx, pluginMethodHandshake, &PluginHandshake{ProtocolVersion: PluginProtocolVersion, PluginID: p.ID()}, &handshake)
+	if err == nil && handshake.ProtocolVersion != PluginProtocolVersion {
+		err = fmt.Errorf("%w: plugin %s speaks version %d, host speaks version %d",
+			ErrPluginProtocolMismatch, p.ID(), handshake.ProtocolVersion, PluginProtocolVersion)
+	}
+	if err == nil && handshake.PluginID != p.ID() {
+		err = fmt.Errorf("%w: plugin %s declares ID %s", ErrInvalidPluginManifest, handshake.PluginID, p.ID())
+	}
+	if err == nil {
+		err = conn.call(ctx, pluginMethodInitialize, nil, nil)
+	}
+	if err != nil {
+		process.kill()
+		return nil, fmt.Errorf("failed to initialize plugin %s: %w", p.ID(), err)
+	}
+	process.handshake = handshake
+	return process, nil
+}
+
+// attach makes the process the current process of the plugin and monitors it. The mutex must be held.
+func (p *ProcessPlugin) attach(process *pluginProcess) {
+	exited := make(chan struct{})
+	p.cmd, p.stdin, p.conn, p.exited = process.cmd, process.stdin, process.conn, exited
+	p.handshake = process.handshake
+	p.stopping = false
+	go p.monitor(process.cmd, exited)
+}
+
+// monitor waits for the plugin process to exit and restarts it if it exited unexpectedly.
+// The plugin is restarted with a context of its own, as the context it was initialized with may be
+// done, bounded by RestartTimeout so that a process that never answers does not block the restart.
+// The new process replaces the exited one once it is initialized and started again.
+func (p *ProcessPlugin) monitor(cmd *exec.Cmd, exited chan struct{}) {
+	err := cmd.Wait()
+
+	p.mutex.Lock()
+	p.conn = nil
+	close(exited)
+	if p.stopping || (err == nil && p.policy.Policy != RestartAlways) || p.policy.Policy == RestartNever {
+		p.mutex.Unlock()
+		return
+	}
+	p.mutex.Unlock()
+	p.log(logger.LevelError, "Plugin %s exited unexpectedly: %v", p.ID(), err)
+
+	for {
+		p.mutex.Lock()
+		if p.stopping || (p.policy.MaxAttempts > 0 && p.attempts >= p.policy.MaxAttempts) {
+			p.mutex.Unlock()
+			p.log(logger.LevelError, "Plugin %s is not restarted", p.ID())
+			return
+		}
+		p.attempts++
+		delay := p.policy.Delay(p.attempts)
+		p.mutex.Unlock()
+
+		time.Sleep(delay)
+
+		p.mutex.Lock()
+		stopping, started := p.stopping, p.started
+		p.mutex.Unlock()
+		if stopping {
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), p.RestartTimeout)
+		process, err := p.spawn(ctx)
+		if err == nil && started {
+			if err = process.conn.call(ctx, pluginMethodStart, nil, nil); err != nil {
+				process.kill()
+			}
+		}
+		cancel()
+
+		if err == nil {
+			p.mutex.Lock()
+			if p.stopping {
+				// The plugin was stopped during the restart
+				p.mutex.Unlock()
+				process.kill()
+				return
+			}
+			p.attach(process)
+			p.mutex.Unlock()
+			p.log(logger.LevelInfo, "Plugin %s restarted", p.ID())
+			return
+		}
+		p.log(logger.LevelError, "Failed to restart plugin %s: %v", p.ID(), err)
+	}
+}
+
+// log logs a message with the system logger, if any.
+func (p *ProcessPlugin) log(level logger.Level, format string, args ...interface{}) {
+	if p.System != nil && p.System.Logger() != nil {
+		p.System.Logger().Logf(level, format, args...)
+	}
+}
+
+// processOperationFactory creates the proxies of the operations of a process plugin.
+type processOperationFactory struct {
+	plugin *ProcessPlugin
+}
+
+// CreateComponent creates the proxy of the configured operation.
+func (f *processOperationFactory) CreateComponent(cfg *config.ComponentConfig) (component.ComponentInterface, error) {
+	return &processOperation{
+		BaseSystemOperation: *NewBaseSystemOperation(cfg.ID, cfg.Name, cfg.Description),
+		plugin:              f.plugin,
+	}, nil
+}
+
+// processOperation is an operation executed by a process plugin.
+type processOperation struct {
+	BaseSystemOperation
+	plugin *ProcessPlugin
+}
+
+// Execute executes the operation in the plugin process.
+func (o *processOperation) Execute(ctx *context.Context, input *SystemOperationInput) (*SystemOperationOutput, error) {
+	return o.plugin.execute(ctx, o.ID(), input)
+}
@@ -0,0 +1,156 @@
+package system
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/context"
+)
+
+// PluginProtocolVersion is the version of the contract between the host and out-of-process plugins.
+// The host refuses plugins speaking another version.
+const PluginProtocolVersion = 1
+
+// Methods of the plugin protocol.
+const (
+	pluginMethodHandshake  = "handshake"
+	pluginMethodInitialize = "initialize"
+	pluginMethodStart      = "start"
+	pluginMethodStop       = "stop"
+	pluginMethodExecute    = "execute"
+)
+
+// pluginMessage is a request or a response exchanged with an out-of-process plugin.
+// Messages are encoded as JSON, one per line.
+type pluginMessage struct {
+	ID     uint64          `json:"id"`               // ID of the request, repeated in its response
+	Method string          `json:"method,omitempty"` // Method of a request
+	Params json.RawMessage `json:"params,omitempty"` // Parameters of a request
+	Result json.RawMessage `json:"result,omitempty"` // Result of a successful request
+	Error  string          `json:"error,omitempty"`  // Error of a failed request
+}
+
+// PluginHandshake is exchanged when the host connects to an out-of-process plugin.
+// The host sends its protocol version and the expected plugin ID, and the plugin
+// replies with its own version and ID, and the operations it contributes.
+type PluginHandshake struct {
+	ProtocolVersion int                   `json:"protocolVersion"`      // Version of the protocol
+	PluginID        string                `json:"pluginId"`             // ID of the plugin
+	Operations      []PluginOperationInfo `json:"operations,omitempty"` // Operations contributed by the plugin
+}
+
+// PluginOperationInfo describes an operation contributed by an out-of-process plugin.
+type PluginOperationInfo struct {
+	ID          string `json:"id"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// pluginExecuteParams holds the parameters of the execute method.
+type pluginExecuteParams struct {
+	OperationID string          `json:"operationId"`
+	Input       json.RawMessage `json:"input,omitempty"`
+}
+
+// pluginConn sends requests to the other end of a plugin connection and dispatches the responses.
+type pluginConn struct {
+	writeMutex sync.Mutex
+	encoder    *json.Encoder
+	mutex      sync.Mutex
+	nextID     uint64
+	pending    map[uint64]chan *pluginMessage
+	closed     chan struct{} // Closed when the connection stops reading responses
+	err        error         // Error that closed the connection
+}
+
+// newPluginConn creates a connection reading responses from r and writing requests to w.
+func newPluginConn(r io.Reader, w io.Writer) *pluginConn {
+	conn := &pluginConn{
+		encoder: json.NewEncoder(w),
+		pending: make(map[uint64]chan *pluginMessage),
+		closed:  make(chan struct{}),
+	}
+	go conn.read(json.NewDecoder(r))
+	return conn
+}
+
+// read dispatches the responses until the connection fails.
+func (c *pluginConn) read(decoder *json.Decoder) {
+	for {
+		message := &pluginMessage{}
+		if err := decoder.Decode(message); err != nil {
+			c.mutex.Lock()
+			c.err = fmt.Errorf("%w: %v", ErrPluginCrashed, err)
+			c.mutex.Unlock()
+			close(c.closed)
+			return
+		}
+
+		c.mutex.Lock()
+		response, exists := c.pending[message.ID]
+		delete(c.pending, message.ID)
+		c.mutex.Unlock()
+		if exists {
+			response <- message
+		}
+	}
+}
+
+// call sends a request and decodes the result of its response into result, if not nil.
+// Returns an error if the request fails, the connection closes or the context is done.
+func (c *pluginConn) call(ctx *context.Context, method string, params, result interface{}) error {
+	request := &pluginMessage{Method: method}
+	if params != nil {
+		data, err := json.Marshal(params)
+		if err != nil {
+			return fmt.Errorf("failed to encode %s request: %w", method, err)
+		}
+		request.Params = data
+	}
+
+	response := make(chan *pluginMessage, 1)
+	c.mutex.Lock()
+	c.nextID++
+	request.ID = c.nextID
+	c.pending[request.ID] = response
+	c.mutex.Unlock()
+	defer func() {
+		c.mutex.Lock()
+		delete(c.pending, request.ID)
+		c.mutex.Unlock()
+	}()
+
+	c.writeMutex.Lock()
+	err := c.encoder.Encode(request)
+	c.writeMutex.Unlock()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrPluginCrashed, err)
+	}
+
+	var done <-chan struct{}
+	if ctx != nil && ctx.Context != nil {
+		done = ctx.Done()
+	}
+
+	select {
+	case message := <-response:
+		if message.Error != "" {
+			return errors.New(message.Error)
+		}
+		if result != nil && len(message.Result) > 0 {
+			if err := json.Unmarshal(message.Result, result); err != nil {
+				return fmt.Errorf("failed to decode %s response: %w", method, err)
+			}
+		}
+		return nil
+	case <-c.closed:
+		c.mutex.Lock()
+		defer c.mutex.Unlock()
+		return c.err
+	case <-done:
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,352 @@
+package system
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	"github.com/edward1christian/block-forge/pkg/application/common/logger"
+	"github.com/edward1christian/block-forge/pkg/application/config"
+)
+
+const (
+	// PluginChecksumSuffix is appended to the location of a plugin bundle to find its SHA-256 checksum.
+	PluginChecksumSuffix = ".sha256"
+
+	// PluginSignatureSuffix is appended to the location of a plugin bundle to find its ed25519 signature.
+	PluginSignatureSuffix = ".sig"
+
+	// MaxPluginBundleSize is the maximum size of a plugin bundle, compressed or not.
+	MaxPluginBundleSize = 256 << 20
+
+	// pluginBundleFile, pluginSignatureFile and pluginContentDir are the names of the verified bundle,
+	// of its signature and of its extracted content in the cache directory of a bundle.
+	pluginBundleFile    = "bundle.tar.gz"
+	pluginSignatureFile = "bundle.sig"
+	pluginContentDir    = "plugin"
+)
+
+// RemotePluginSource fetches plugin bundles and their checksums and signatures.
+// It verifies each bundle against its SHA-256 checksum, then against an ed25519 signature made
+// by one of the trusted keys, and caches the verified bundles by checksum.
+type RemotePluginSource struct {
+	CacheDir      string              // Directory caching the verified bundles
+	TrustedKeys   []ed25519.PublicKey // Keys trusted to sign plugin bundles
+	AllowUnsigned bool                // Whether bundles without a signature are accepted
+	Client        *http.Client        // Client fetching the http and https locations
+}
+
+// NewRemotePluginSource creates a source of plugin bundles from the given configuration.
+// The trusted keys are base64 encoded ed25519 public keys. The cache directory defaults to
+// a block-forge directory in the cache directory of the user.
+func NewRemotePluginSource(cfg config.RemotePluginConfiguration) (*RemotePluginSource, error) {
+	source := &RemotePluginSource{
+		CacheDir:      cfg.CacheDir,
+		AllowUnsigned: cfg.AllowUnsigned,
+		Client:        http.DefaultClient,
+	}
+	if source.CacheDir == "" {
+		cacheDir, err := os.UserCacheDir()
+		if err != nil {
+			return nil, err
+		}
+		source.CacheDir = filepath.Join(cacheDir, "block-forge", "plugins")
+	}
+
+	for _, encoded := range cfg.TrustedKeys {
+		key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+		if err != nil || len(key) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("%w: %q is not a base64 ed25519 public key", ErrInvalidPluginKey, encoded)
+		}
+		source.TrustedKeys = append(source.TrustedKeys, ed25519.PublicKey(key))
+	}
+	return source, nil
+}
+
+// Fetch fetches the plugin bundle at the given location, verifies it and extracts it in the cache.
+// It returns the directory holding the content of the bundle, and whether the bundle is signed.
+//
+// A location is an http or https URL, a file URL or a path. The expected checksum is the value of
+// a "sha256" fragment of the location, such as "#sha256=<hex>", or the content of the location
+// followed by PluginChecksumSuffix. The signature is the base64 content of the location followed
+// by PluginSignatureSuffix. Bundles already cached are verified again without being fetched,
+// unless the cached bundle is not signed and unsigned bundles are refused.
+func (s *RemotePluginSource) Fetch(ctx *context.Context, location string) (string, bool, error) {
+	location, checksum, err := s.checksum(ctx, location)
+	if err != nil {
+		return "", false, err
+	}
+	dir := filepath.Join(s.CacheDir, hex.EncodeToString(checksum))
+	content := filepath.Join(dir, pluginContentDir)
+
+	// Use the cached bundle, or fetch it
+	bundle, err := os.ReadFile(filepath.Join(dir, pluginBundleFile))
+	signature, errSignature := os.ReadFile(filepath.Join(dir, pluginSignatureFile))
+	cached := err == nil && (errSignature == nil || (errors.Is(errSignature, os.ErrNotExist) && s.AllowUnsigned)) && dirExists(content)
+	if !cached {
+		if bundle, err = s.fetch(ctx, location); err != nil {
+			return "", false, fmt.Errorf("failed to fetch plugin bundle %s: %w", location, err)
+		}
+		signature, err = s.fetch(ctx, location+PluginSignatureSuffix)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return "", false, fmt.Errorf("failed to fetch signature of plugin bundle %s: %w", location, err)
+		}
+	}
+	if err := s.verify(location, bundle, checksum, signature); err != nil {
+		return "", false, err
+	}
+
+	// Cache the verified bundle
+	if !cached {
+		if err := s.store(dir, bundle, signature); err != nil {
+			return "", false, fmt.Errorf("failed to cache plugin bundle %s: %w", location, err)
+		}
+	}
+	return content, signature != nil, nil
+}
+
+// checksum returns the location without its checksum fragment, and the expected checksum of the bundle.
+func (s *RemotePluginSource) checksum(ctx *context.Context, location string) (string, []byte, error) {
+	encoded := ""
+	if base, fragment, found := strings.Cut(location, "#"); found {
+		value, ok := strings.CutPrefix(fragment, "sha256=")
+		if !ok {
+			return "", nil, fmt.Errorf("%w: unsupported fragment in %s", ErrInvalidPluginBundle, location)
+		}
+		location, encoded = base, value
+	} else {
+		data, err := s.fetch(ctx, location+PluginChecksumSuffix)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to fetch checksum of plugin bundle %s: %w", location, err)
+		}
+		// The checksum may be followed by the name of the bundle, as printed by sha256sum
+		if fields := strings.Fields(string(data)); len(fields) > 0 {
+			encoded = fields[0]
+		}
+	}
+
+	checksum, err := hex.DecodeString(encoded)
+	if err != nil || len(checksum) != sha256.Size {
+		return "", nil, fmt.Errorf("%w: invalid checksum %q of %s", ErrInvalidPluginBundle, encoded, location)
+	}
+	return location, checksum, nil
+}
+
+// verify checks the bundle against its checksum, and its signature against the trusted keys.
+func (s *RemotePluginSource) verify(location string, bundle, checksum, signature []byte) error {
+	if sum := sha256.Sum256(bundle); !bytes.Equal(sum[:], checksum) {
+		return fmt.Errorf("%w: %s has checksum %x, expected %x", ErrPluginChecksumMismatch, location, sum, checksum)
+	}
+
+	if signature == nil {
+		if s.AllowUnsigned {
+			return nil
+		}
+		return fmt.Errorf("%w: %s", ErrUnsignedPlugin, location)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil {
+		return fmt.Errorf("%w: %s: %v", ErrPluginSignatureInvalid, location, err)
+	}
+	for _, key := range s.TrustedKeys {
+		if ed25519.Verify(key, bundle, decoded) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s is not signed by a trusted key", ErrPluginSignatureInvalid, location)
+}
+
+// store writes the verified bundle and its signature in the given cache directory and extracts it.
+// The directory is prepared aside and renamed, so that a bundle is never partially cached.
+func (s *RemotePluginSource) store(dir string, bundle, signature []byte) error {
+	if err := os.MkdirAll(s.CacheDir, 0755); err != nil {
+		return err
+	}
+	tmp, err := os.MkdirTemp(s.CacheDir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	if err := os.WriteFile(filepath.Join(tmp, pluginBundleFile), bundle, 0644); err != nil {
+		return err
+	}
+	if signature != nil {
+		if err := os.WriteFile(filepath.Join(tmp, pluginSignatureFile), signature, 0644); err != nil {
+			return err
+		}
+	}
+	if err := extractPluginBundle(bundle, filepath.Join(tmp, pluginContentDir)); err != nil {
+		return err
+	}
+
+	// Replace an incomplete cache entry
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+	return os.Rename(tmp, dir)
+}
+
+// fetch returns the content at the given location. Missing content is reported with os.ErrNotExist.
+func (s *RemotePluginSource) fetch(ctx *context.Context, location string) ([]byte, error) {
+	parsed, err := url.Parse(location)
+	if err != nil || parsed.Scheme == "" || len(parsed.Scheme) == 1 {
+		// A path, possibly with a Windows volume name
+		return readLimited(os.Open(location))
+	}
+
+	switch parsed.Scheme {
+	case "file":
+		return readLimited(os.Open(parsed.Path))
+	case "http", "https":
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+		if err != nil {
+			return nil, err
+		}
+		client := s.Client
+		if client == nil {
+			client = http.DefaultClient
+		}
+		response, err := client.Do(request)
+		if err != nil {
+			return nil, err
+		}
+		defer response.Body.Close()
+
+		switch {
+		case response.StatusCode == http.StatusNotFound:
+			return nil, fmt.Errorf("%w: %s", os.ErrNotExist, location)
+		case response.StatusCode != http.StatusOK:
+			return nil, fmt.Errorf("unexpected status %s fetching %s", response.Status, location)
+		}
+		return readLimited(response.Body, nil)
+	default:
+		return nil, fmt.Errorf("%w: unsupported scheme %s", ErrInvalidPluginBundle, parsed.Scheme)
+	}
+}
+
+// dirExists returns whether the given path exists and is a directory.
+func dirExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// readLimited reads the given reader up to MaxPluginBundleSize, and closes it.
+func readLimited(r io.ReadCloser, err error) ([]byte, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(io.LimitReader(r, MaxPluginBundleSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxPluginBundleSize {
+		return nil, fmt.Errorf("%w: larger than %d bytes", ErrInvalidPluginBundle, MaxPluginBundleSize)
+	}
+	return data, nil
+}
+
+// extractPluginBundle extracts a gzipped tar archive in the given directory. Only directories and
+// regular files are extracted; entries escaping the directory, links and other entries are refused.
+func extractPluginBundle(bundle []byte, dir string) error {
+	compressed, err := gzip.NewReader(bytes.NewReader(bundle))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPluginBundle, err)
+	}
+	defer compressed.Close()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	archive := tar.NewReader(compressed)
+	var size int64
+	for {
+		header, err := archive.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidPluginBundle, err)
+		}
+
+		name := filepath.Clean(filepath.FromSlash(header.Name))
+		if filepath.IsAbs(name) || name == ".." || strings.HasPrefix(name, ".."+string(filepath.Separator)) || filepath.VolumeName(name) != "" {
+			return fmt.Errorf("%w: entry %s escapes the bundle", ErrInvalidPluginBundle, header.Name)
+		}
+		target := filepath.Join(dir, name)
+
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0755); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if size += header.Size; size > MaxPluginBundleSize {
+				return fmt.Errorf("%w: content larger than %d bytes", ErrInvalidPluginBundle, MaxPluginBundleSize)
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			// Only the executable bits of the entry are kept
+			file, err := os.OpenFile(target, os.O_CREATE|os.O_EXCL|os.O_WRONLY, os.FileMode(0644|header.Mode&0111))
+			if err != nil {
+				return err
+			}
+			_, err = io.Copy(file, io.LimitReader(archive, header.Size))
+			if closeErr := file.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("%w: entry %s is not a file or a directory", ErrInvalidPluginBundle, header.Name)
+		}
+	}
+}
+
+// LoadRemotePlugin fetches and verifies the plugin bundle at the given location, as described by
+// RemotePluginSource.Fetch, and loads the plugin described by the manifest it holds. The source is
+// configured by the RemotePlugins of the system configuration. The plugin is not added to the manager.
+func (m *PluginManager) LoadRemotePlugin(ctx *context.Context, pluginURL string) (PluginInterface, error) {
+	var cfg config.RemotePluginConfiguration
+	if m.System != nil && m.System.Configuration() != nil {
+		cfg = m.System.Configuration().RemotePlugins
+	}
+	source, err := NewRemotePluginSource(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, signed, err := source.Fetch(ctx, pluginURL)
+	if err != nil {
+		return nil, err
+	}
+	if !signed && m.System != nil && m.System.Logger() != nil {
+		m.System.Logger().Logf(logger.LevelWarn, "Loading unsigned plugin bundle %s", pluginURL)
+	}
+
+	manifests, err := FindPluginManifests([]string{dir})
+	if err != nil {
+		return nil, err
+	}
+	if len(manifests) != 1 {
+		return nil, fmt.Errorf("%w: %s holds %d plugin manifests, expected 1", ErrInvalidPluginBundle, pluginURL, len(manifests))
+	}
+	return m.LoadPlugin(ctx, manifests[0])
+}
@@ -0,0 +1,163 @@
+package system
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sync"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/context"
+)
+
+// PluginServer serves the operations of an out-of-process plugin to the host that started it.
+// The operations are initialized without a system, as the system lives in the host.
+type PluginServer struct {
+	ID         string                           // ID of the plugin, matching its manifest
+	Operations []SystemOperationInterface       // Operations contributed by the plugin
+	OnStart    func(ctx *context.Context) error // Called when the host starts the plugin, if set
+	OnStop     func(ctx *context.Context) error // Called when the host stops the plugin, if set
+}
+
+// ServePlugin serves the plugin to its host over the standard input and output, until the host
+// closes them. Anything the plugin prints to the standard output is redirected to the standard error.
+func ServePlugin(server *PluginServer) error {
+	out := os.Stdout
+	os.Stdout = os.Stderr
+	return server.Serve(context.Background(), os.Stdin, out)
+}
+
+// Serve reads the requests of the host from r and writes the responses to w until r is closed.
+// Requests are handled concurrently.
+func (s *PluginServer) Serve(ctx *context.Context, r io.Reader, w io.Writer) error {
+	decoder := json.NewDecoder(r)
+	encoder := json.NewEncoder(w)
+	var writeMutex sync.Mutex
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	for {
+		request := &pluginMessage{}
+		if err := decoder.Decode(request); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			response := &pluginMessage{ID: request.ID}
+			result, err := s.handle(ctx, request)
+			if err == nil && result != nil {
+				response.Result, err = json.Marshal(result)
+			}
+			if err != nil {
+				response.Error = err.Error()
+			}
+
+			writeMutex.Lock()
+			defer writeMutex.Unlock()
+			encoder.Encode(response)
+		}()
+	}
+}
+
+// handle handles a request of the host and returns its result.
+func (s *PluginServer) handle(ctx *context.Context, request *pluginMessage) (interface{}, error) {
+	switch request.Method {
+	case pluginMethodHandshake:
+		handshake := &PluginHandshake{}
+		if err := json.Unmarshal(request.Params, handshake); err != nil {
+			return nil, err
+		}
+		if handshake.ProtocolVersion != PluginProtocolVersion {
+			return nil, fmt.Errorf("%w: plugin %s speaks version %d, host speaks version %d",
+				ErrPluginProtocolMismatch, s.ID, PluginProtocolVersion, handshake.ProtocolVersion)
+		}
+		return s.handshake(), nil
+
+	case pluginMethodInitialize:
+		for _, operation := range s.Operations {
+			if err := operation.Initialize(ctx, nil); err != nil {
+				return nil, fmt.Errorf("failed to initialize operation %s: %w", operation.ID(), err)
+			}
+		}
+		return nil, nil
+
+	case pluginMethodStart:
+		if s.OnStart != nil {
+			return nil, s.OnStart(ctx)
+		}
+		return nil, nil
+
+	case pluginMethodStop:
+		if s.OnStop != nil {
+			return nil, s.OnStop(ctx)
+		}
+		return nil, nil
+
+	case pluginMethodExecute:
+		params := &pluginExecuteParams{}
+		if err := json.Unmarshal(request.Params, params); err != nil {
+			return nil, err
+		}
+		return s.execute(ctx, params)
+
+	default:
+		return nil, fmt.Errorf("unknown plugin method %s", request.Method)
+	}
+}
+
+// handshake returns the handshake describing the plugin and its operations.
+func (s *PluginServer) handshake() *PluginHandshake {
+	handshake := &PluginHandshake{ProtocolVersion: PluginProtocolVersion, PluginID: s.ID}
+	for _, operation := range s.Operations {
+		handshake.Operations = append(handshake.Operations, PluginOperationInfo{
+			ID:          operation.ID(),
+			Name:        operation.Name(),
+			Description: operation.Description(),
+		})
+	}
+	return handshake
+}
+
+// execute executes an operation of the plugin. The input is decoded into the type of the input
+// schema of the operation, if it declares one.
+func (s *PluginServer) execute(ctx *context.Context, params *pluginExecuteParams) (interface{}, error) {
+	var operation SystemOperationInterface
+	for _, candidate := range s.Operations {
+		if candidate.ID() == params.OperationID {
+			operation = candidate
+		}
+	}
+	if operation == nil {
+		return nil, fmt.Errorf("%w: %s", ErrOperationNotRegistered, params.OperationID)
+	}
+
+	input := &SystemOperationInput{}
+	if len(params.Input) > 0 && string(params.Input) != "null" {
+		var data interface{}
+		if schemaOperation, ok := operation.(SchemaOperationInterface); ok && schemaOperation.InputSchema() != nil &&
+			schemaOperation.InputSchema().Type != nil {
+			value := reflect.New(schemaOperation.InputSchema().Type)
+			if err := json.Unmarshal(params.Input, value.Interface()); err != nil {
+				return nil, &OperationValidationError{Err: err}
+			}
+			data = value.Elem().Interface()
+		} else if err := json.Unmarshal(params.Input, &data); err != nil {
+			return nil, err
+		}
+		input.Data = data
+	}
+
+	output, err := operation.Execute(ctx, input)
+	if err != nil || output == nil {
+		return nil, err
+	}
+	return output.Data, nil
+}
@@ -0,0 +1,225 @@
+package system
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// CoreAPIVersion is the version of the API the system offers to plugins.
+// Plugins declare the range of core API versions they support in their manifest.
+const CoreAPIVersion = "1.0.0"
+
+// Version is a semantic version.
+type Version struct {
+	Major      int
+	Minor      int
+	Patch      int
+	Prerelease string // Pre-release identifiers, without the leading '-'
+}
+
+// ParseVersion parses a semantic version such as "1.2.3", "v1.2.3" or "1.2.3-beta.1".
+// Build metadata is ignored.
+func ParseVersion(s string) (Version, error) {
+	v := "v" + strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if !semver.IsValid(v) || strings.TrimSuffix(v, semver.Build(v)) != semver.Canonical(v) {
+		return Version{}, fmt.Errorf("%w: %q is not a major.minor.patch version", ErrInvalidVersion, s)
+	}
+	return versionOf(semver.Canonical(v)), nil
+}
+
+// versionOf returns the version of a valid canonical semver string, such as "v1.2.3-beta.1".
+func versionOf(canonical string) Version {
+	numbers := strings.SplitN(strings.TrimPrefix(canonical, "v"), ".", 3)
+	numbers[2] = strings.TrimSuffix(numbers[2], semver.Prerelease(canonical))
+
+	var version Version
+	version.Major, _ = strconv.Atoi(numbers[0])
+	version.Minor, _ = strconv.Atoi(numbers[1])
+	version.Patch, _ = strconv.Atoi(numbers[2])
+	version.Prerelease = strings.TrimPrefix(semver.Prerelease(canonical), "-")
+	return version
+}
+
+// String returns the version in its canonical form.
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Prerelease != "" {
+		s += "-" + v.Prerelease
+	}
+	return s
+}
+
+// Compare returns -1, 0 or 1 depending on whether v is lower than, equal to or greater than other.
+// A pre-release version is lower than the release of the same version.
+func (v Version) Compare(other Version) int {
+	return semver.Compare("v"+v.String(), "v"+other.String())
+}
+
+// versionComparator is a single condition of a version range.
+type versionComparator struct {
+	operator string
+	version  Version
+}
+
+// VersionRange is a set of versions, such as ">=1.2.0 <2.0.0", "^1.2", "~1.2.3" or "1.x".
+// Comparators separated by spaces must all match, and alternatives are separated by "||".
+// The zero VersionRange contains every version.
+type VersionRange struct {
+	expression   string
+	alternatives [][]versionComparator
+}
+
+// ParseVersionRange parses a version range. An empty range, "*" or "x" contain every version.
+func ParseVersionRange(s string) (VersionRange, error) {
+	versionRange := VersionRange{expression: strings.TrimSpace(s)}
+	for _, alternative := range strings.Split(s, "||") {
+		var comparators []versionComparator
+		for _, field := range strings.Fields(alternative) {
+			parsed, err := parseVersionComparator(field)
+			if err != nil {
+				return VersionRange{}, fmt.Errorf("%w in range %q", err, s)
+			}
+			comparators = append(comparators, parsed...)
+		}
+		versionRange.alternatives = append(versionRange.alternatives, comparators)
+	}
+	return versionRange, nil
+}
+
+// Contains returns whether the version belongs to the range.
+func (r VersionRange) Contains(v Version) bool {
+	if len(r.alternatives) == 0 {
+		return true
+	}
+	for _, comparators := range r.alternatives {
+		matches := true
+		for _, comparator := range comparators {
+			if !comparator.matches(v) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the range as it was parsed.
+func (r VersionRange) String() string {
+	if r.expression == "" {
+		return "*"
+	}
+	return r.expression
+}
+
+// matches returns whether the version satisfies the comparator.
+func (c versionComparator) matches(v Version) bool {
+	comparison := v.Compare(c.version)
+	switch c.operator {
+	case ">":
+		return comparison > 0
+	case ">=":
+		return comparison >= 0
+	case "<":
+		return comparison < 0
+	case "<=":
+		return comparison <= 0
+	default:
+		return comparison == 0
+	}
+}
+
+// parseVersionComparator parses a comparator of a range into the equivalent basic comparators.
+// Partial versions match every version they prefix, and caret and tilde ranges allow changes
+// that do not modify the left-most non-zero part, or the minor version, respectively.
+func parseVersionComparator(s string) ([]versionComparator, error) {
+	if s == "*" || s == "x" || s == "X" {
+		return nil, nil
+	}
+
+	operator := ""
+	for _, candidate := range []string{">=", "<=", ">", "<", "=", "^", "~"} {
+		if strings.HasPrefix(s, candidate) {
+			operator, s = candidate, s[len(candidate):]
+			break
+		}
+	}
+	version, parts, err := parsePartialVersion(s)
+	if err != nil {
+		return nil, err
+	}
+
+	// upper returns the lowest version above the partial version when bumping the given part
+	upper := func(part int) Version {
+		switch part {
+		case 0:
+			return Version{Major: version.Major + 1, Prerelease: "0"}
+		case 1:
+			return Version{Major: version.Major, Minor: version.Minor + 1, Prerelease: "0"}
+		default:
+			return Version{Major: version.Major, Minor: version.Minor, Patch: version.Patch + 1, Prerelease: "0"}
+		}
+	}
+
+	switch operator {
+	case "^":
+		part := 0
+		if version.Major == 0 && parts > 1 {
+			part = 1
+			if version.Minor == 0 && parts > 2 {
+				part = 2
+			}
+		}
+		return []versionComparator{{">=", version}, {"<", upper(part)}}, nil
+	case "~":
+		part := 1
+		if parts == 1 {
+			part = 0
+		}
+		return []versionComparator{{">=", version}, {"<", upper(part)}}, nil
+	case "", "=":
+		if parts == 3 {
+			return []versionComparator{{"=", version}}, nil
+		}
+		return []versionComparator{{">=", version}, {"<", upper(parts - 1)}}, nil
+	case ">":
+		if parts < 3 {
+			return []versionComparator{{">=", upper(parts - 1)}}, nil
+		}
+	case "<=":
+		if parts < 3 {
+			return []versionComparator{{"<", upper(parts - 1)}}, nil
+		}
+	}
+	return []versionComparator{{operator, version}}, nil
+}
+
+// parsePartialVersion parses a version whose minor and patch parts may be missing or wildcards,
+// and returns the number of parts that were set.
+func parsePartialVersion(s string) (Version, int, error) {
+	v := "v" + strings.TrimPrefix(strings.TrimSpace(s), "v")
+	core := strings.TrimSuffix(strings.TrimSuffix(v, semver.Build(v)), semver.Prerelease(v))
+	fields := strings.Split(core, ".")
+	parts := len(fields)
+	for i, field := range fields {
+		if field == "x" || field == "X" || field == "*" {
+			parts = i
+			break
+		}
+	}
+	if parts == 0 || parts < len(fields) && semver.Prerelease(v) != "" {
+		return Version{}, 0, fmt.Errorf("%w: %q", ErrInvalidVersion, s)
+	}
+
+	// Shorthands such as "v1.2" are valid semver strings, completed by their canonical form
+	partial := strings.Join(fields[:parts], ".") + semver.Prerelease(v)
+	if !semver.IsValid(partial) {
+		return Version{}, 0, fmt.Errorf("%w: %q", ErrInvalidVersion, s)
+	}
+	return versionOf(semver.Canonical(partial)), parts, nil
+}
@@ -0,0 +1,225 @@
+package system
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	"github.com/edward1christian/block-forge/pkg/application/common/logger"
+)
+
+// DefaultPluginWatchInterval is the interval at which the plugin watcher polls the plugin paths.
+const DefaultPluginWatchInterval = 2 * time.Second
+
+// pluginSnapshot is the state of a plugin manifest and of the entry point it describes.
+type pluginSnapshot struct {
+	id    string   // ID of the plugin, kept from the previous state while the manifest is invalid
+	state [4]int64 // Modification times and sizes of the manifest and of the entry point
+	err   error    // Error reading the manifest
+}
+
+// PluginWatcher keeps the plugins of a plugin manager in sync with the manifests found in the plugin
+// paths, so that plugins can be updated without restarting the system. Plugins whose manifest or
+// entry point changes are reloaded, plugins whose manifest is removed are unloaded, and plugins
+// whose manifest appears are loaded and added. The paths are polled, which works on every platform.
+type PluginWatcher struct {
+	Manager  PluginManagerInterface // Manager of the watched plugins
+	Paths    []string               // Plugin paths, as accepted by FindPluginManifests
+	Interval time.Duration          // Interval between two polls
+	Logger   logger.LoggerInterface // Logger of the changes and of the errors of the polls, if set
+
+	mutex     sync.Mutex
+	snapshots map[string]pluginSnapshot // State of the plugins at the last poll, by manifest path
+	cancel    func()
+	done      chan struct{}
+}
+
+// NewPluginWatcher creates a watcher of the plugins found in the given paths.
+func NewPluginWatcher(manager PluginManagerInterface, paths []string, interval time.Duration) *PluginWatcher {
+	if interval <= 0 {
+		interval = DefaultPluginWatchInterval
+	}
+	return &PluginWatcher{
+		Manager:  manager,
+		Paths:    paths,
+		Interval: interval,
+	}
+}
+
+// Start records the state of the plugin paths and polls them every Interval until the watcher is stopped.
+func (w *PluginWatcher) Start(ctx *context.Context) error {
+	w.mutex.Lock()
+	if w.done != nil {
+		w.mutex.Unlock()
+		return nil
+	}
+	runCtx, cancel := context.WithCancel(context.Background())
+	w.cancel, w.done = cancel, make(chan struct{})
+	done := w.done
+	w.mutex.Unlock()
+
+	err := w.Poll(ctx)
+	go w.loop(runCtx, done)
+	return err
+}
+
+// Stop stops polling and waits for the current poll to complete.
+func (w *PluginWatcher) Stop(ctx *context.Context) error {
+	w.mutex.Lock()
+	if w.done == nil {
+		w.mutex.Unlock()
+		return nil
+	}
+	cancel, done := w.cancel, w.done
+	w.cancel, w.done = nil, nil
+	w.mutex.Unlock()
+
+	cancel()
+	<-done
+	return nil
+}
+
+// Poll compares the plugin paths with their state at the previous poll and reloads, unloads and
+// adds the plugins that changed. The first poll only records the state of the plugin paths.
+// Invalid manifests are reported once per change, and leave the plugin they described in place.
+func (w *PluginWatcher) Poll(ctx *context.Context) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	paths, err := FindPluginManifests(w.Paths)
+	if err != nil {
+		return fmt.Errorf("failed to watch plugins: %w", err)
+	}
+	current := make(map[string]pluginSnapshot, len(paths))
+	for _, path := range paths {
+		current[path] = snapshotPlugin(path)
+	}
+
+	// The first poll records the state of the plugin paths
+	previous := w.snapshots
+	w.snapshots = current
+	if previous == nil {
+		return nil
+	}
+
+	// Unload the plugins whose manifest was removed or now describes another plugin
+	var errs []error
+	for _, path := range sortedSnapshotPaths(previous) {
+		old := previous[path]
+		snapshot, exists := current[path]
+		if old.id == "" || (exists && (snapshot.err != nil || snapshot.id == old.id)) {
+			continue
+		}
+		if err := w.unload(ctx, old.id); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	// Reload the plugins that changed and add the new ones
+	for _, path := range sortedSnapshotPaths(current) {
+		snapshot := current[path]
+		old, existed := previous[path]
+		if existed && old.state == snapshot.state {
+			current[path] = old
+			continue
+		}
+		if snapshot.err != nil {
+			snapshot.id = old.id
+			current[path] = snapshot
+			errs = append(errs, snapshot.err)
+			continue
+		}
+
+		if _, err := w.Manager.GetPlugin(snapshot.id); err == nil && existed && old.id == snapshot.id {
+			err = w.Manager.ReloadPlugin(ctx, snapshot.id)
+			w.logChange("Reloaded plugin", snapshot.id, err)
+			if err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		if err := w.add(ctx, path); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// loop polls the plugin paths until the context is canceled.
+func (w *PluginWatcher) loop(ctx *context.Context, done chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(w.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := w.Poll(ctx); err != nil && w.Logger != nil {
+				w.Logger.Log(logger.LevelWarn, "Failed to update plugins:", err)
+			}
+		}
+	}
+}
+
+// unload unloads the plugin with the given ID, if it is added.
+func (w *PluginWatcher) unload(ctx *context.Context, id string) error {
+	if _, err := w.Manager.GetPlugin(id); err != nil {
+		return nil
+	}
+	err := w.Manager.UnloadPlugin(ctx, id)
+	w.logChange("Unloaded plugin", id, err)
+	return err
+}
+
+// add loads and adds the plugin described by the manifest at the given path.
+func (w *PluginWatcher) add(ctx *context.Context, path string) error {
+	plugin, err := w.Manager.LoadPlugin(ctx, path)
+	if err != nil {
+		return err
+	}
+	err = w.Manager.AddPlugin(ctx, plugin)
+	w.logChange("Added plugin", plugin.ID(), err)
+	return err
+}
+
+// logChange logs a change of the plugins, unless it failed.
+func (w *PluginWatcher) logChange(message, id string, err error) {
+	if w.Logger != nil && err == nil {
+		w.Logger.Logf(logger.LevelInfo, "%s %s", message, id)
+	}
+}
+
+// snapshotPlugin returns the state of the plugin manifest at the given path.
+func snapshotPlugin(path string) pluginSnapshot {
+	snapshot := pluginSnapshot{}
+	if info, err := os.Stat(path); err == nil {
+		snapshot.state[0], snapshot.state[1] = info.ModTime().UnixNano(), info.Size()
+	}
+
+	manifest, err := ReadPluginManifest(path)
+	if err != nil {
+		snapshot.err = err
+		return snapshot
+	}
+	snapshot.id = manifest.ID
+	if info, err := os.Stat(manifest.EntryPath()); err == nil {
+		snapshot.state[2], snapshot.state[3] = info.ModTime().UnixNano(), info.Size()
+	}
+	return snapshot
+}
+
+// sortedSnapshotPaths returns the manifest paths of the snapshots in lexical order.
+func sortedSnapshotPaths(snapshots map[string]pluginSnapshot) []string {
+	paths := make([]string, 0, len(snapshots))
+	for path := range snapshots {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
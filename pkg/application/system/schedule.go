@@ -0,0 +1,220 @@
+package system
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Schedule determines when a scheduled operation is executed.
+type Schedule interface {
+	// Next returns the first activation time strictly after the given time.
+	// Returns the zero time if the schedule never activates again.
+	Next(after time.Time) time.Time
+}
+
+// IntervalSchedule activates at a fixed interval.
+type IntervalSchedule struct {
+	Interval time.Duration
+}
+
+// Next returns the given time plus the interval.
+func (s IntervalSchedule) Next(after time.Time) time.Time {
+	if s.Interval <= 0 {
+		return time.Time{}
+	}
+	return after.Add(s.Interval)
+}
+
+// cronField describes the range of values of a cron field.
+type cronField struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	cronMinute = cronField{name: "minute", min: 0, max: 59}
+	cronHour   = cronField{name: "hour", min: 0, max: 23}
+	cronDay    = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth  = cronField{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	cronWeekday = cronField{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// cronDescriptors are the predefined cron expressions.
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronSearchLimit bounds the search of the next activation of a cron schedule.
+const cronSearchLimit = 5 * 366 * 24 * time.Hour
+
+// CronSchedule activates at the times matching a standard 5-field cron expression.
+type CronSchedule struct {
+	minutes, hours, days, months, weekdays uint64
+	anyDay, anyWeekday                     bool
+}
+
+// ParseSchedule parses a cron expression, a predefined descriptor such as @daily,
+// or a fixed interval written as "@every <duration>".
+func ParseSchedule(expression string) (Schedule, error) {
+	expression = strings.TrimSpace(expression)
+	if rest, ok := strings.CutPrefix(expression, "@every "); ok {
+		interval, err := time.ParseDuration(strings.TrimSpace(rest))
+		if err != nil || interval <= 0 {
+			return nil, fmt.Errorf("%w: invalid interval %q", ErrInvalidSchedule, rest)
+		}
+		return IntervalSchedule{Interval: interval}, nil
+	}
+	return ParseCronSchedule(expression)
+}
+
+// ParseCronSchedule parses a cron expression made of the minute, hour, day of month,
+// month and day of week fields. Fields accept *, values, ranges, lists and steps,
+// and month and day names. Predefined descriptors such as @hourly are accepted too.
+func ParseCronSchedule(expression string) (*CronSchedule, error) {
+	if descriptor, ok := cronDescriptors[strings.ToLower(strings.TrimSpace(expression))]; ok {
+		expression = descriptor
+	}
+
+	fields := strings.Fields(expression)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("%w: expected 5 fields, got %d in %q", ErrInvalidSchedule, len(fields), expression)
+	}
+
+	schedule := &CronSchedule{
+		anyDay:     fields[2] == "*" || fields[2] == "?",
+		anyWeekday: fields[4] == "*" || fields[4] == "?",
+	}
+	var err error
+	if schedule.minutes, err = parseCronField(fields[0], cronMinute); err != nil {
+		return nil, err
+	}
+	if schedule.hours, err = parseCronField(fields[1], cronHour); err != nil {
+		return nil, err
+	}
+	if schedule.days, err = parseCronField(fields[2], cronDay); err != nil {
+		return nil, err
+	}
+	if schedule.months, err = parseCronField(fields[3], cronMonth); err != nil {
+		return nil, err
+	}
+	if schedule.weekdays, err = parseCronField(fields[4], cronWeekday); err != nil {
+		return nil, err
+	}
+
+	// Sunday can be written as 0 or 7
+	if schedule.weekdays&(1<<7) != 0 {
+		schedule.weekdays |= 1
+	}
+	return schedule, nil
+}
+
+// parseCronField parses a cron field into a bit set of the matching values.
+func parseCronField(value string, field cronField) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(value, ",") {
+		rangePart, step := part, 1
+		if before, after, ok := strings.Cut(part, "/"); ok {
+			n, err := strconv.Atoi(after)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("%w: invalid step %q in %s field", ErrInvalidSchedule, after, field.name)
+			}
+			rangePart, step = before, n
+		}
+
+		start, end := field.min, field.max
+		switch {
+		case rangePart == "*" || rangePart == "?":
+		case strings.Contains(rangePart, "-"):
+			before, after, _ := strings.Cut(rangePart, "-")
+			var err error
+			if start, err = parseCronValue(before, field); err != nil {
+				return 0, err
+			}
+			if end, err = parseCronValue(after, field); err != nil {
+				return 0, err
+			}
+			if start > end {
+				return 0, fmt.Errorf("%w: invalid range %q in %s field", ErrInvalidSchedule, rangePart, field.name)
+			}
+		default:
+			n, err := parseCronValue(rangePart, field)
+			if err != nil {
+				return 0, err
+			}
+			start = n
+			if step == 1 {
+				end = n
+			}
+		}
+
+		for i := start; i <= end; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+	return bits, nil
+}
+
+// parseCronValue parses a single value or name of a cron field.
+func parseCronValue(value string, field cronField) (int, error) {
+	if n, ok := field.names[strings.ToLower(value)]; ok {
+		return n, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < field.min || n > field.max {
+		return 0, fmt.Errorf("%w: invalid value %q in %s field", ErrInvalidSchedule, value, field.name)
+	}
+	return n, nil
+}
+
+// Next returns the first time after the given time matching the cron expression,
+// in the location of the given time.
+func (s *CronSchedule) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.Add(cronSearchLimit)
+
+	for t.Before(limit) {
+		if s.months&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !s.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.hours&(1<<uint(t.Hour())) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if s.minutes&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// matchesDay returns whether the day of the given time matches the expression. When both the
+// day of month and the day of week are restricted, a day matching either of them matches.
+func (s *CronSchedule) matchesDay(t time.Time) bool {
+	day := s.days&(1<<uint(t.Day())) != 0
+	weekday := s.weekdays&(1<<uint(t.Weekday())) != 0
+	if s.anyDay || s.anyWeekday {
+		return day && weekday
+	}
+	return day || weekday
+}
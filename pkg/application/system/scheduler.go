@@ -0,0 +1,526 @@
+package system
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	"github.com/edward1christian/block-forge/pkg/application/common/event"
+	"github.com/edward1christian/block-forge/pkg/application/common/logger"
+	"github.com/edward1christian/block-forge/pkg/application/component"
+	configApi "github.com/edward1christian/block-forge/pkg/application/config"
+	"github.com/edward1christian/block-forge/pkg/application/store"
+)
+
+const (
+	// SchedulerServiceID is the conventional ID of the scheduler service.
+	SchedulerServiceID = "SchedulerService"
+
+	// SchedulerServiceFactoryID is the conventional ID of the factory of the scheduler service.
+	SchedulerServiceFactoryID = "SchedulerServiceFactory"
+
+	// SchedulerStoreName is the name of the store in which the scheduler persists its schedules.
+	SchedulerStoreName = "scheduler"
+
+	// DefaultMaxCatchUpRuns is the maximum number of missed runs caught up per schedule.
+	DefaultMaxCatchUpRuns = 100
+)
+
+// CatchUpPolicyType determines what happens to the runs missed while the scheduler was stopped.
+type CatchUpPolicyType string
+
+const (
+	// CatchUpSkip skips the missed runs.
+	CatchUpSkip CatchUpPolicyType = "skip"
+
+	// CatchUpRunOnce executes the operation once if any run was missed.
+	CatchUpRunOnce CatchUpPolicyType = "run-once"
+
+	// CatchUpRunAll executes the operation once for every missed run, up to MaxCatchUpRuns.
+	CatchUpRunAll CatchUpPolicyType = "run-all"
+)
+
+// ParseCatchUpPolicy parses the given catch-up policy. An empty value means CatchUpSkip.
+func ParseCatchUpPolicy(value string) (CatchUpPolicyType, error) {
+	switch policy := CatchUpPolicyType(value); policy {
+	case "":
+		return CatchUpSkip, nil
+	case CatchUpSkip, CatchUpRunOnce, CatchUpRunAll:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrInvalidCatchUpPolicy, value)
+	}
+}
+
+// ScheduledOperation describes a scheduled operation and the state of its runs.
+type ScheduledOperation struct {
+	ID          string            `json:"id"`              // ID of the schedule
+	OperationID string            `json:"operationId"`     // ID of the operation to execute
+	Expression  string            `json:"expression"`      // Schedule expression
+	CatchUp     CatchUpPolicyType `json:"catchUp"`         // Policy applied to missed runs
+	Input       interface{}       `json:"input,omitempty"` // Input data of the operation
+	LastRun     time.Time         `json:"lastRun"`         // Start time of the last run
+	NextRun     time.Time         `json:"nextRun"`         // Time of the next run
+	LastError   string            `json:"lastError"`       // Error of the last run, if any
+	Runs        int               `json:"runs"`            // Number of runs
+	Configured  bool              `json:"configured"`      // Whether the schedule is declared in the system configuration
+}
+
+// scheduleEntry is a scheduled operation managed by the scheduler.
+type scheduleEntry struct {
+	ScheduledOperation
+	schedule Schedule
+	running  bool
+}
+
+// SchedulerServiceFactory is responsible for creating instances of SchedulerService.
+type SchedulerServiceFactory struct {
+}
+
+// CreateComponent creates a new instance of SchedulerService.
+func (f *SchedulerServiceFactory) CreateComponent(config *configApi.ComponentConfig) (component.ComponentInterface, error) {
+	return NewSchedulerService(config.ID, config.Name, config.Description), nil
+}
+
+// SchedulerService executes registered operations on a cron expression or at a fixed interval.
+// Schedules are read from the system configuration or added with AddSchedule, and are persisted
+// with the state of their runs in the MultiStore of the system, if any. A run is skipped while
+// the previous run of the same schedule is still executing.
+type SchedulerService struct {
+	BaseSystemService
+	MaxCatchUpRuns int // Maximum number of missed runs caught up per schedule
+
+	mutex   sync.Mutex
+	entries map[string]*scheduleEntry
+	store   store.Store
+	wake    chan struct{}
+	cancel  func()
+	done    chan struct{}
+	runs    sync.WaitGroup
+}
+
+// NewSchedulerService creates a new scheduler service.
+func NewSchedulerService(id, name, description string) *SchedulerService {
+	return &SchedulerService{
+		BaseSystemService: *NewBaseSystemService(id, name, description),
+		MaxCatchUpRuns:    DefaultMaxCatchUpRuns,
+		entries:           make(map[string]*scheduleEntry),
+		wake:              make(chan struct{}, 1),
+	}
+}
+
+// Start loads the persisted schedules and the schedules of the system configuration,
+// applies the catch-up policy of the schedules that missed runs and starts scheduling.
+// Persisted schedules that were declared in the configuration but no longer are, are removed.
+func (s *SchedulerService) Start(ctx *context.Context) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.System == nil {
+		return ErrSystemNotInitialized
+	}
+	if s.done != nil {
+		return nil
+	}
+
+	if err := s.openStore(); err != nil {
+		return err
+	}
+	if err := s.loadSchedules(); err != nil {
+		return err
+	}
+	declared := make(map[string]bool)
+	if configuration := s.System.Configuration(); configuration != nil {
+		for _, scheduleConfig := range configuration.Schedules {
+			if scheduleConfig == nil {
+				continue
+			}
+			if _, err := s.define(scheduleConfig, true); err != nil {
+				return err
+			}
+			declared[scheduleConfig.ID] = true
+		}
+	}
+
+	// Remove the schedules removed from the configuration
+	for id, entry := range s.entries {
+		if entry.Configured && !declared[id] {
+			if err := s.remove(id); err != nil {
+				return err
+			}
+		}
+	}
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	s.cancel, s.done = cancel, make(chan struct{})
+
+	now := time.Now()
+	for _, entry := range s.entries {
+		s.catchUp(runCtx, entry, now)
+		s.persist(entry)
+	}
+
+	go s.loop(runCtx, s.done)
+	return nil
+}
+
+// Stop stops scheduling, cancels the running operations and waits for them to return.
+func (s *SchedulerService) Stop(ctx *context.Context) error {
+	s.mutex.Lock()
+	if s.done == nil {
+		s.mutex.Unlock()
+		return nil
+	}
+	cancel, done := s.cancel, s.done
+	s.cancel, s.done = nil, nil
+	s.mutex.Unlock()
+
+	cancel()
+	<-done
+	s.runs.Wait()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, entry := range s.entries {
+		s.persist(entry)
+	}
+	return nil
+}
+
+// AddSchedule schedules an operation. Adding a schedule with the ID of an existing
+// schedule replaces its definition and keeps the state of its runs.
+func (s *SchedulerService) AddSchedule(scheduleConfig *configApi.ScheduleConfiguration) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	entry, err := s.define(scheduleConfig, false)
+	if err != nil {
+		return err
+	}
+	if s.done != nil && entry.NextRun.IsZero() {
+		entry.NextRun = entry.schedule.Next(time.Now())
+	}
+	s.persist(entry)
+	s.signal()
+	return nil
+}
+
+// RemoveSchedule removes the schedule with the given ID. A running operation is not canceled.
+func (s *SchedulerService) RemoveSchedule(id string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, ok := s.entries[id]; !ok {
+		return fmt.Errorf("%w: %s", ErrScheduleNotFound, id)
+	}
+	if err := s.remove(id); err != nil {
+		return err
+	}
+	s.signal()
+	return nil
+}
+
+// Schedules returns the scheduled operations, sorted by ID.
+func (s *SchedulerService) Schedules() []ScheduledOperation {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	schedules := make([]ScheduledOperation, 0, len(s.entries))
+	for _, entry := range s.entries {
+		schedules = append(schedules, entry.ScheduledOperation)
+	}
+	sort.Slice(schedules, func(i, j int) bool {
+		return schedules[i].ID < schedules[j].ID
+	})
+	return schedules
+}
+
+// openStore opens the store of the scheduler in the MultiStore of the system, if any.
+func (s *SchedulerService) openStore() error {
+	multiStore := s.System.MultiStore()
+	if multiStore == nil {
+		return nil
+	}
+
+	schedulerStore, _, err := multiStore.CreateStore(SchedulerStoreName)
+	if err != nil {
+		return fmt.Errorf("failed to create scheduler store: %w", err)
+	}
+	if _, err := schedulerStore.Load(); err != nil {
+		return fmt.Errorf("failed to load scheduler store: %w", err)
+	}
+	s.store = schedulerStore
+	return nil
+}
+
+// loadSchedules loads the schedules persisted in the store.
+func (s *SchedulerService) loadSchedules() error {
+	if s.store == nil {
+		return nil
+	}
+
+	var loadErr error
+	err := s.store.Iterate(func(key, value []byte) bool {
+		var scheduled ScheduledOperation
+		if err := json.Unmarshal(value, &scheduled); err != nil {
+			loadErr = fmt.Errorf("failed to decode schedule %s: %w", key, err)
+			return true
+		}
+		schedule, err := ParseSchedule(scheduled.Expression)
+		if err != nil {
+			loadErr = fmt.Errorf("failed to load schedule %s: %w", key, err)
+			return true
+		}
+		s.entries[scheduled.ID] = &scheduleEntry{ScheduledOperation: scheduled, schedule: schedule}
+		return false
+	})
+	if err != nil {
+		return fmt.Errorf("failed to load schedules: %w", err)
+	}
+	return loadErr
+}
+
+// remove removes the schedule with the given ID and deletes it from the store, if any.
+func (s *SchedulerService) remove(id string) error {
+	delete(s.entries, id)
+
+	if s.store != nil {
+		if err := s.store.Delete([]byte(id)); err != nil {
+			return fmt.Errorf("failed to delete schedule %s: %w", id, err)
+		}
+		if _, _, err := s.store.SaveVersion(); err != nil {
+			return fmt.Errorf("failed to delete schedule %s: %w", id, err)
+		}
+	}
+	return nil
+}
+
+// define creates or updates the schedule with the given configuration, declared in the system
+// configuration if configured.
+func (s *SchedulerService) define(scheduleConfig *configApi.ScheduleConfiguration, configured bool) (*scheduleEntry, error) {
+	schedule, err := ParseSchedule(scheduleConfig.Schedule)
+	if err != nil {
+		return nil, fmt.Errorf("failed to schedule %s: %w", scheduleConfig.ID, err)
+	}
+	catchUp, err := ParseCatchUpPolicy(scheduleConfig.CatchUp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to schedule %s: %w", scheduleConfig.ID, err)
+	}
+
+	entry, ok := s.entries[scheduleConfig.ID]
+	if !ok {
+		entry = &scheduleEntry{}
+		s.entries[scheduleConfig.ID] = entry
+	} else if entry.Expression != scheduleConfig.Schedule {
+		// The next run of the previous expression no longer applies
+		entry.NextRun = time.Time{}
+	}
+
+	entry.ID = scheduleConfig.ID
+	entry.OperationID = scheduleConfig.OperationID
+	entry.Expression = scheduleConfig.Schedule
+	entry.CatchUp = catchUp
+	entry.Input = scheduleConfig.Input
+	entry.Configured = configured
+	entry.schedule = schedule
+	return entry, nil
+}
+
+// catchUp applies the catch-up policy of the given schedule if it missed runs before now,
+// and moves its next run after now.
+func (s *SchedulerService) catchUp(ctx *context.Context, entry *scheduleEntry, now time.Time) {
+	if entry.NextRun.IsZero() || entry.NextRun.After(now) {
+		if entry.NextRun.IsZero() {
+			entry.NextRun = entry.schedule.Next(now)
+		}
+		return
+	}
+
+	var missed []time.Time
+	for t := entry.NextRun; !t.IsZero() && !t.After(now) && len(missed) < s.MaxCatchUpRuns; t = entry.schedule.Next(t) {
+		missed = append(missed, t)
+	}
+	entry.NextRun = entry.schedule.Next(now)
+
+	switch entry.CatchUp {
+	case CatchUpRunOnce:
+		s.dispatch(ctx, entry, missed[:1], true)
+	case CatchUpRunAll:
+		s.dispatch(ctx, entry, missed, true)
+	}
+}
+
+// loop runs the due schedules until the context is canceled.
+func (s *SchedulerService) loop(ctx *context.Context, done chan struct{}) {
+	defer close(done)
+
+	for {
+		var timer *time.Timer
+		var fire <-chan time.Time
+		if next := s.nextRun(); !next.IsZero() {
+			timer = time.NewTimer(time.Until(next))
+			fire = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-s.wake:
+		case <-fire:
+			s.runDue(ctx)
+		}
+
+		if timer != nil {
+			timer.Stop()
+		}
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// nextRun returns the earliest next run of the schedules that are not running.
+func (s *SchedulerService) nextRun() time.Time {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var next time.Time
+	for _, entry := range s.entries {
+		if entry.running || entry.NextRun.IsZero() {
+			continue
+		}
+		if next.IsZero() || entry.NextRun.Before(next) {
+			next = entry.NextRun
+		}
+	}
+	return next
+}
+
+// runDue executes the schedules whose next run is due.
+func (s *SchedulerService) runDue(ctx *context.Context) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	now := time.Now()
+	for _, entry := range s.entries {
+		if entry.running || entry.NextRun.IsZero() || entry.NextRun.After(now) {
+			continue
+		}
+		scheduledAt := entry.NextRun
+		entry.NextRun = entry.schedule.Next(now)
+		s.dispatch(ctx, entry, []time.Time{scheduledAt}, false)
+	}
+}
+
+// dispatch executes the operation of the schedule once for each of the given times, in the background.
+func (s *SchedulerService) dispatch(ctx *context.Context, entry *scheduleEntry, times []time.Time, catchUp bool) {
+	entry.running = true
+	s.runs.Add(1)
+
+	go func() {
+		defer s.runs.Done()
+		for _, scheduledAt := range times {
+			if ctx.Err() != nil {
+				break
+			}
+			s.execute(ctx, entry, scheduledAt, catchUp)
+		}
+
+		s.mutex.Lock()
+		entry.running = false
+		s.skipOverlapped(entry, time.Now())
+		s.signal()
+		s.mutex.Unlock()
+	}()
+}
+
+// skipOverlapped moves the next run of the schedule after now if it became due while the previous
+// run was executing, so that the overlapping run is skipped rather than executed late.
+// Must be called with the mutex held.
+func (s *SchedulerService) skipOverlapped(entry *scheduleEntry, now time.Time) {
+	if entry.NextRun.IsZero() || entry.NextRun.After(now) {
+		return
+	}
+	entry.NextRun = entry.schedule.Next(now)
+	s.persist(entry)
+}
+
+// execute executes the operation of the schedule and records the run.
+func (s *SchedulerService) execute(ctx *context.Context, entry *scheduleEntry, scheduledAt time.Time, catchUp bool) {
+	s.mutex.Lock()
+	operationID, input := entry.OperationID, entry.Input
+	s.mutex.Unlock()
+
+	startedAt := time.Now()
+	_, err := s.System.ExecuteOperation(ctx, operationID, &SystemOperationInput{Data: input})
+	runEvent := ScheduledRunEvent{
+		ScheduleID:  entry.ID,
+		OperationID: operationID,
+		ScheduledAt: scheduledAt,
+		StartedAt:   startedAt,
+		Duration:    time.Since(startedAt),
+		CatchUp:     catchUp,
+	}
+	if err != nil {
+		runEvent.Error = err.Error()
+	}
+
+	s.mutex.Lock()
+	entry.LastRun = startedAt
+	entry.LastError = runEvent.Error
+	entry.Runs++
+	s.persist(entry)
+	s.mutex.Unlock()
+
+	if err != nil {
+		s.logError("Scheduled operation failed:", entry.ID, err)
+		s.publish(EventTypeScheduledOperationFailed, runEvent)
+	} else {
+		s.publish(EventTypeScheduledOperationSucceeded, runEvent)
+	}
+}
+
+// persist saves the given schedule in the store, if any, unless it was removed.
+// Must be called with the mutex held.
+func (s *SchedulerService) persist(entry *scheduleEntry) {
+	if s.store == nil || s.entries[entry.ID] != entry {
+		return
+	}
+
+	data, err := json.Marshal(entry.ScheduledOperation)
+	if err == nil {
+		err = s.store.Set([]byte(entry.ID), data)
+	}
+	if err == nil {
+		_, _, err = s.store.SaveVersion()
+	}
+	if err != nil {
+		s.logError("Error persisting schedule:", entry.ID, err)
+	}
+}
+
+// signal wakes the scheduling loop up.
+func (s *SchedulerService) signal() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// publish publishes the given scheduler event on the system event bus, if any.
+func (s *SchedulerService) publish(eventType string, data ScheduledRunEvent) {
+	if bus := s.System.EventBus(); bus != nil {
+		if err := bus.Publish(event.Event{Type: eventType, Data: data}); err != nil {
+			s.logError("Failed to publish scheduler event:", data.ScheduleID, err)
+		}
+	}
+}
+
+// logError logs the given scheduler error with the system logger, if any.
+func (s *SchedulerService) logError(message, scheduleID string, err error) {
+	if log := s.System.Logger(); log != nil {
+		log.Log(logger.LevelError, message, scheduleID, err)
+	}
+}
@@ -0,0 +1,215 @@
+package system
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/asaskevich/govalidator"
+)
+
+// SchemaOperationInterface represents an operation declaring the data it expects and produces.
+// The system validates the input before the operation is executed and the output after.
+type SchemaOperationInterface interface {
+	SystemOperationInterface
+
+	// InputSchema returns the schema of the input data, nil if the input is not validated.
+	InputSchema() *OperationSchema
+
+	// OutputSchema returns the schema of the output data, nil if the output is not validated.
+	OutputSchema() *OperationSchema
+}
+
+// OperationSchema describes the data expected or produced by an operation.
+type OperationSchema struct {
+	Type        reflect.Type            // Go type of the data, nil if any type is accepted
+	Description string                  // Description of the data
+	Required    bool                    // Whether the data must not be nil
+	Constraint  func(interface{}) error // Additional validation applied to the data, if any
+}
+
+// SchemaField describes a field of a struct schema.
+type SchemaField struct {
+	Name        string // Name of the field, taken from its json tag if any
+	Type        string // Go type of the field
+	Required    bool   // Whether the field is required by its valid tag
+	Description string // Description taken from the description tag
+}
+
+// NewOperationSchema creates a schema requiring data of type T.
+// Struct data is validated with the govalidator tags of its fields.
+func NewOperationSchema[T any](description string) *OperationSchema {
+	return &OperationSchema{
+		Type:        reflect.TypeOf((*T)(nil)).Elem(),
+		Description: description,
+		Required:    true,
+	}
+}
+
+// WithConstraint returns the schema with the given additional validation.
+func (s *OperationSchema) WithConstraint(constraint func(interface{}) error) *OperationSchema {
+	s.Constraint = constraint
+	return s
+}
+
+// Optional returns the schema accepting nil data.
+func (s *OperationSchema) Optional() *OperationSchema {
+	s.Required = false
+	return s
+}
+
+// TypeName returns the name of the type described by the schema.
+func (s *OperationSchema) TypeName() string {
+	if s.Type == nil {
+		return "any"
+	}
+	return s.Type.String()
+}
+
+// Validate checks that the given data matches the schema.
+func (s *OperationSchema) Validate(data interface{}) error {
+	if data == nil {
+		if s.Required {
+			return fmt.Errorf("%s is required", s.TypeName())
+		}
+		return nil
+	}
+
+	if s.Type != nil && !reflect.TypeOf(data).AssignableTo(s.Type) {
+		return fmt.Errorf("expected %s, got %T", s.TypeName(), data)
+	}
+
+	if structType(reflect.TypeOf(data)) != nil {
+		if _, err := govalidator.ValidateStruct(data); err != nil {
+			return err
+		}
+	}
+
+	if s.Constraint != nil {
+		return s.Constraint(data)
+	}
+	return nil
+}
+
+// Fields returns the fields of a struct schema, nil for other types.
+func (s *OperationSchema) Fields() []SchemaField {
+	if s.Type == nil {
+		return nil
+	}
+	t := structType(s.Type)
+	if t == nil {
+		return nil
+	}
+
+	fields := make([]SchemaField, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name := field.Name
+		if tag := strings.Split(field.Tag.Get("json"), ",")[0]; tag == "-" {
+			continue
+		} else if tag != "" {
+			name = tag
+		}
+
+		fields = append(fields, SchemaField{
+			Name:        name,
+			Type:        field.Type.String(),
+			Required:    strings.Contains(field.Tag.Get("valid"), "required"),
+			Description: field.Tag.Get("description"),
+		})
+	}
+	return fields
+}
+
+// structType returns the struct type of t or of the type t points to, nil otherwise.
+func structType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	return t
+}
+
+// OperationValidationError reports input or output data of an operation not matching its schema.
+type OperationValidationError struct {
+	OperationID string // ID of the operation, empty if unknown
+	Output      bool   // Whether the output, rather than the input, is invalid
+	Err         error  // Reason of the validation failure
+}
+
+// Error returns the error message.
+func (e *OperationValidationError) Error() string {
+	kind := "input"
+	if e.Output {
+		kind = "output"
+	}
+	if e.OperationID == "" {
+		return fmt.Sprintf("invalid operation %s: %v", kind, e.Err)
+	}
+	return fmt.Sprintf("invalid %s for operation %s: %v", kind, e.OperationID, e.Err)
+}
+
+// Unwrap returns ErrInvalidOperationInput or ErrInvalidOperationOutput, and the reason of the failure.
+func (e *OperationValidationError) Unwrap() []error {
+	if e.Output {
+		return []error{ErrInvalidOperationOutput, e.Err}
+	}
+	return []error{ErrInvalidOperationInput, e.Err}
+}
+
+// InputAs returns the input data of an operation as a T.
+// Returns an OperationValidationError if the data is not a T.
+func InputAs[T any](input *SystemOperationInput) (T, error) {
+	var data interface{}
+	if input != nil {
+		data = input.Data
+	}
+
+	value, ok := data.(T)
+	if !ok {
+		return value, &OperationValidationError{
+			Err: fmt.Errorf("expected %s, got %T", reflect.TypeOf((*T)(nil)).Elem(), data),
+		}
+	}
+	return value, nil
+}
+
+// validateOperationInput validates the input of the operation against its schema, if any.
+func validateOperationInput(operation SystemOperationInterface, input *SystemOperationInput) error {
+	schemaOp, ok := operation.(SchemaOperationInterface)
+	if !ok || schemaOp.InputSchema() == nil {
+		return nil
+	}
+
+	var data interface{}
+	if input != nil {
+		data = input.Data
+	}
+	if err := schemaOp.InputSchema().Validate(data); err != nil {
+		return &OperationValidationError{OperationID: operation.ID(), Err: err}
+	}
+	return nil
+}
+
+// validateOperationOutput validates the output of the operation against its schema, if any.
+func validateOperationOutput(operation SystemOperationInterface, output *SystemOperationOutput) error {
+	schemaOp, ok := operation.(SchemaOperationInterface)
+	if !ok || schemaOp.OutputSchema() == nil {
+		return nil
+	}
+
+	var data interface{}
+	if output != nil {
+		data = output.Data
+	}
+	if err := schemaOp.OutputSchema().Validate(data); err != nil {
+		return &OperationValidationError{OperationID: operation.ID(), Output: true, Err: err}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package system
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SortServicesByDependencies orders the given services so that every service comes after
+// the services it depends on. Dependencies are looked up by service ID in the dependencies map.
+// Dependencies on IDs that are not part of the given services are ignored.
+// Returns an error if the dependencies contain a cycle.
+func SortServicesByDependencies(
+	services []SystemServiceInterface,
+	dependencies map[string][]string) ([]SystemServiceInterface, error) {
+
+	// Index the services by ID and visit them in a stable order
+	byID := make(map[string]SystemServiceInterface, len(services))
+	ids := make([]string, 0, len(services))
+	for _, service := range services {
+		if _, exists := byID[service.ID()]; exists {
+			continue
+		}
+		byID[service.ID()] = service
+		ids = append(ids, service.ID())
+	}
+	sort.Strings(ids)
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(ids))
+	ordered := make([]SystemServiceInterface, 0, len(ids))
+
+	// visit performs a depth-first traversal, appending a service once all its dependencies are appended
+	var visit func(id string, path []string) error
+	visit = func(id string, path []string) error {
+		switch state[id] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("%w: %s", ErrServiceDependencyCycle, strings.Join(append(path, id), " -> "))
+		}
+
+		state[id] = visiting
+		deps := append([]string(nil), dependencies[id]...)
+		sort.Strings(deps)
+		for _, dep := range deps {
+			if _, ok := byID[dep]; !ok {
+				continue
+			}
+			if err := visit(dep, append(path, id)); err != nil {
+				return err
+			}
+		}
+		state[id] = visited
+		ordered = append(ordered, byID[id])
+		return nil
+	}
+
+	for _, id := range ids {
+		if err := visit(id, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
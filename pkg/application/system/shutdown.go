@@ -0,0 +1,130 @@
+package system
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/context"
+)
+
+// ShutdownFailure describes a step of the shutdown that failed.
+type ShutdownFailure struct {
+	Component string // Name of the component that failed to shut down
+	Err       error  // Error returned by the component
+}
+
+// ShutdownError reports every failure that occurred while shutting down the system.
+type ShutdownError struct {
+	Failures []ShutdownFailure
+}
+
+// Error returns the error message.
+func (e *ShutdownError) Error() string {
+	messages := make([]string, 0, len(e.Failures))
+	for _, failure := range e.Failures {
+		messages = append(messages, fmt.Sprintf("%s: %v", failure.Component, failure.Err))
+	}
+	return fmt.Sprintf("system shutdown failed: %s", strings.Join(messages, "; "))
+}
+
+// Unwrap returns the errors of the failures.
+func (e *ShutdownError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failures))
+	for _, failure := range e.Failures {
+		errs = append(errs, failure.Err)
+	}
+	return errs
+}
+
+// add records the failure of the given component, if err is not nil.
+func (e *ShutdownError) add(component string, err error) {
+	if err != nil {
+		e.Failures = append(e.Failures, ShutdownFailure{Component: component, Err: err})
+	}
+}
+
+// errOrNil returns the error if any failure was recorded, nil otherwise.
+func (e *ShutdownError) errOrNil() error {
+	if len(e.Failures) == 0 {
+		return nil
+	}
+	return e
+}
+
+// operationCounter counts the operations in flight. Unlike a sync.WaitGroup, it can be waited on
+// while operations are added, such as when the system is started again after a shutdown whose
+// deadline was exceeded.
+type operationCounter struct {
+	mutex sync.Mutex
+	cond  *sync.Cond
+	count int
+}
+
+// newOperationCounter creates a counter without operations in flight.
+func newOperationCounter() *operationCounter {
+	counter := &operationCounter{}
+	counter.cond = sync.NewCond(&counter.mutex)
+	return counter
+}
+
+// add counts a new operation in flight.
+func (c *operationCounter) add() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.count++
+}
+
+// done counts an operation as completed.
+func (c *operationCounter) done() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.count--
+	if c.count == 0 {
+		c.cond.Broadcast()
+	}
+}
+
+// wait waits for the operations in flight to complete, or for the deadline to be reached.
+// Returns false if the deadline was reached first.
+func (c *operationCounter) wait(deadline <-chan struct{}) bool {
+	waited := make(chan struct{})
+	defer close(waited)
+	go func() {
+		select {
+		case <-deadline:
+			// Wake the waiter up so that it notices the deadline
+			c.mutex.Lock()
+			c.cond.Broadcast()
+			c.mutex.Unlock()
+		case <-waited:
+		}
+	}()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for c.count > 0 {
+		select {
+		case <-deadline:
+			return false
+		default:
+		}
+		c.cond.Wait()
+	}
+	return true
+}
+
+// drainOperations waits for the in-flight operations to complete, up to the deadline of the context.
+// Asynchronous jobs still running when the deadline is reached are canceled.
+func (s *SystemImpl) drainOperations(ctx *context.Context) error {
+	var deadline <-chan struct{}
+	if ctx != nil && ctx.Context != nil {
+		deadline = ctx.Done()
+	}
+
+	if !s.inflight.wait(deadline) {
+		s.jobs.cancelAll()
+		return fmt.Errorf("%w: %v", ErrShutdownDeadlineExceeded, ctx.Err())
+	}
+	return nil
+}
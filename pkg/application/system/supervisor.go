@@ -0,0 +1,340 @@
+package system
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	"github.com/edward1christian/block-forge/pkg/application/common/event"
+	"github.com/edward1christian/block-forge/pkg/application/common/logger"
+	"github.com/edward1christian/block-forge/pkg/application/component"
+	"github.com/edward1christian/block-forge/pkg/application/config"
+)
+
+const (
+	// DefaultRestartBackoff is the backoff used when a restart policy does not define one.
+	DefaultRestartBackoff = time.Second
+
+	// DefaultHealthCheckInterval is the interval at which the supervisor polls the health of services.
+	DefaultHealthCheckInterval = 5 * time.Second
+
+	// DefaultRestartStablePeriod is the stable period used when a restart policy does not define one.
+	DefaultRestartStablePeriod = time.Minute
+)
+
+// RestartPolicyType represents when a supervised service is restarted.
+type RestartPolicyType string
+
+const (
+	// RestartNever indicates that the service is never restarted.
+	RestartNever RestartPolicyType = "never"
+
+	// RestartOnFailure indicates that the service is restarted when it fails.
+	RestartOnFailure RestartPolicyType = "on-failure"
+
+	// RestartAlways indicates that the service is restarted whenever it exits.
+	RestartAlways RestartPolicyType = "always"
+)
+
+// RestartPolicy defines how the supervisor restarts a service.
+type RestartPolicy struct {
+	Policy       RestartPolicyType // When the service is restarted
+	MaxAttempts  int               // Maximum number of restarts, 0 for unlimited
+	Backoff      time.Duration     // Delay before the first restart, doubled on every attempt
+	MaxBackoff   time.Duration     // Upper bound of the delay, 0 for unbounded
+	StablePeriod time.Duration     // Running time after which the attempts and the delay are reset, DefaultRestartStablePeriod if 0
+}
+
+// RestartPolicyFromConfig creates the restart policy defined by the service configuration.
+// Returns an error if the configured policy is unknown.
+func RestartPolicyFromConfig(cfg *config.ServiceConfiguration) (RestartPolicy, error) {
+	policy := RestartPolicy{
+		Policy:       RestartPolicyType(cfg.RestartPolicy),
+		MaxAttempts:  cfg.MaxRestartAttempts,
+		Backoff:      time.Duration(cfg.RetryInterval),
+		MaxBackoff:   time.Duration(cfg.MaxRetryInterval),
+		StablePeriod: time.Duration(cfg.RestartStablePeriod),
+	}
+
+	switch policy.Policy {
+	case "":
+		policy.Policy = RestartNever
+	case RestartNever, RestartOnFailure, RestartAlways:
+	default:
+		return RestartPolicy{}, fmt.Errorf("%w: %q for service %s", ErrInvalidRestartPolicy, cfg.RestartPolicy, cfg.ID)
+	}
+	return policy, nil
+}
+
+// Delay returns the backoff applied before the given restart attempt, starting at 1.
+func (p RestartPolicy) Delay(attempt int) time.Duration {
+	delay := p.Backoff
+	if delay <= 0 {
+		delay = DefaultRestartBackoff
+	}
+
+	for i := 1; i < attempt; i++ {
+		if p.MaxBackoff > 0 && delay >= p.MaxBackoff {
+			break
+		}
+		delay *= 2
+	}
+
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return delay
+}
+
+// stable returns whether a service running since the given time has been running long enough
+// for its restart attempts to be reset.
+func (p RestartPolicy) stable(since time.Time) bool {
+	period := p.StablePeriod
+	if period <= 0 {
+		period = DefaultRestartStablePeriod
+	}
+	return !since.IsZero() && time.Since(since) >= period
+}
+
+// SupervisedServiceInterface represents a service that reports when it exits.
+type SupervisedServiceInterface interface {
+	SystemServiceInterface
+
+	// Done returns a channel that receives the error the service exited with, or is closed
+	// when the service exits cleanly. A new channel is returned after every start.
+	Done() <-chan error
+}
+
+// supervisedEntry holds the state of a supervised service.
+type supervisedEntry struct {
+	service  SystemServiceInterface
+	policy   RestartPolicy
+	attempts int
+	running  time.Time // Time of the last restart
+	cancel   func()
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+// Supervisor watches services and restarts them according to their restart policy.
+// A service is watched through its Done channel if it implements SupervisedServiceInterface,
+// and through its health if it implements component.HealthReporterInterface.
+type Supervisor struct {
+	mutex               sync.Mutex
+	system              SystemInterface
+	entries             map[string]*supervisedEntry
+	HealthCheckInterval time.Duration // Interval at which the health of services is polled
+}
+
+// NewSupervisor creates a new supervisor publishing its events on the system event bus.
+func NewSupervisor(system SystemInterface) *Supervisor {
+	return &Supervisor{
+		system:              system,
+		entries:             make(map[string]*supervisedEntry),
+		HealthCheckInterval: DefaultHealthCheckInterval,
+	}
+}
+
+// Supervise starts watching the given running service using the given restart policy.
+// Supervising a service that is already supervised replaces its policy.
+// Returns an error if the service can neither report its exit nor its health.
+func (sv *Supervisor) Supervise(ctx *context.Context, service SystemServiceInterface, policy RestartPolicy) error {
+	_, exits := service.(SupervisedServiceInterface)
+	_, reports := service.(component.HealthReporterInterface)
+	if !exits && !reports {
+		return fmt.Errorf("%w: %s", ErrServiceNotSupervisable, service.ID())
+	}
+
+	sv.Unsupervise(service.ID())
+
+	entryCtx, cancel := context.WithCancel(ctx)
+	entry := &supervisedEntry{
+		service: service,
+		policy:  policy,
+		cancel:  cancel,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	sv.mutex.Lock()
+	sv.entries[service.ID()] = entry
+	interval := sv.HealthCheckInterval
+	sv.mutex.Unlock()
+
+	go sv.watch(entryCtx, entry, interval)
+	return nil
+}
+
+// Unsupervise stops watching the service with the given ID.
+func (sv *Supervisor) Unsupervise(serviceID string) {
+	sv.mutex.Lock()
+	entry, ok := sv.entries[serviceID]
+	delete(sv.entries, serviceID)
+	sv.mutex.Unlock()
+
+	if ok {
+		sv.release(entry)
+	}
+}
+
+// Stop stops watching all supervised services.
+func (sv *Supervisor) Stop() {
+	sv.mutex.Lock()
+	entries := sv.entries
+	sv.entries = make(map[string]*supervisedEntry)
+	sv.mutex.Unlock()
+
+	for _, entry := range entries {
+		sv.release(entry)
+	}
+}
+
+// Attempts returns the number of restarts attempted for the service with the given ID.
+func (sv *Supervisor) Attempts(serviceID string) int {
+	sv.mutex.Lock()
+	defer sv.mutex.Unlock()
+
+	if entry, ok := sv.entries[serviceID]; ok {
+		return entry.attempts
+	}
+	return 0
+}
+
+// release stops the watcher of the given entry and waits for it to return.
+func (sv *Supervisor) release(entry *supervisedEntry) {
+	close(entry.stop)
+	entry.cancel()
+	<-entry.done
+}
+
+// watch waits for the service to fail and restarts it until the policy gives up
+// or the service is no longer supervised.
+func (sv *Supervisor) watch(ctx *context.Context, entry *supervisedEntry, interval time.Duration) {
+	defer close(entry.done)
+
+	for {
+		failure := sv.waitForFailure(ctx, entry, interval)
+		if failure == nil {
+			return
+		}
+
+		// Failures of a service that ran for the stable period start a new series of attempts
+		sv.mutex.Lock()
+		if entry.policy.stable(entry.running) {
+			entry.attempts = 0
+		}
+		sv.mutex.Unlock()
+
+		if failure.err != nil {
+			sv.logError("Supervised service failed:", entry.service.ID(), failure.err)
+			sv.publish(EventTypeServiceFailed, ServiceRestartEvent{ServiceID: entry.service.ID(), Error: failure.err.Error()})
+		} else if entry.policy.Policy != RestartAlways {
+			// The service exited cleanly and does not need to be restarted
+			return
+		}
+
+		if entry.policy.Policy == RestartNever || !sv.restart(ctx, entry, failure.exited) {
+			return
+		}
+	}
+}
+
+// serviceFailure describes why a supervised service needs attention.
+type serviceFailure struct {
+	err    error // Error the service failed with, nil on a clean exit
+	exited bool  // Whether the service is no longer running
+}
+
+// waitForFailure blocks until the service exits or is no longer live.
+// Returns nil if supervision was stopped.
+func (sv *Supervisor) waitForFailure(ctx *context.Context, entry *supervisedEntry, interval time.Duration) *serviceFailure {
+	var done <-chan error
+	if supervised, ok := entry.service.(SupervisedServiceInterface); ok {
+		done = supervised.Done()
+	}
+
+	var tick <-chan time.Time
+	reporter, reports := entry.service.(component.HealthReporterInterface)
+	if reports {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-entry.stop:
+			return nil
+		case err := <-done:
+			return &serviceFailure{err: err, exited: true}
+		case <-tick:
+			if status := reporter.Health(ctx); !status.Live {
+				return &serviceFailure{err: fmt.Errorf("service is not live: %s", status.Reason)}
+			}
+		}
+	}
+}
+
+// restart restarts the service with backoff until it starts or the policy gives up.
+// Returns false if the service was not restarted.
+func (sv *Supervisor) restart(ctx *context.Context, entry *supervisedEntry, exited bool) bool {
+	id := entry.service.ID()
+
+	for {
+		sv.mutex.Lock()
+		entry.attempts++
+		attempt := entry.attempts
+		sv.mutex.Unlock()
+
+		if entry.policy.MaxAttempts > 0 && attempt > entry.policy.MaxAttempts {
+			sv.logError("Giving up restarting service:", id, fmt.Errorf("exceeded %d attempts", entry.policy.MaxAttempts))
+			sv.publish(EventTypeServiceRestartAbandoned, ServiceRestartEvent{ServiceID: id, Attempt: attempt - 1})
+			return false
+		}
+
+		delay := entry.policy.Delay(attempt)
+		select {
+		case <-entry.stop:
+			return false
+		case <-time.After(delay):
+		}
+
+		if !exited {
+			// The service is still running but unhealthy, stop it before starting it again
+			if err := entry.service.Stop(ctx); err != nil {
+				sv.logError("Error stopping service:", id, err)
+			}
+		}
+
+		if err := entry.service.Start(ctx); err != nil {
+			sv.logError("Error restarting service:", id, err)
+			sv.publish(EventTypeServiceFailed, ServiceRestartEvent{ServiceID: id, Attempt: attempt, Delay: delay, Error: err.Error()})
+			exited = true
+			continue
+		}
+
+		sv.mutex.Lock()
+		entry.running = time.Now()
+		sv.mutex.Unlock()
+
+		sv.publish(EventTypeServiceRestarted, ServiceRestartEvent{ServiceID: id, Attempt: attempt, Delay: delay})
+		return true
+	}
+}
+
+// publish publishes the given supervisor event on the system event bus, if any.
+func (sv *Supervisor) publish(eventType string, data ServiceRestartEvent) {
+	if bus := sv.system.EventBus(); bus != nil {
+		if err := bus.Publish(event.Event{Type: eventType, Data: data}); err != nil {
+			sv.logError("Failed to publish supervisor event:", data.ServiceID, err)
+		}
+	}
+}
+
+// logError logs the given supervisor error with the system logger, if any.
+func (sv *Supervisor) logError(message, serviceID string, err error) {
+	if log := sv.system.Logger(); log != nil {
+		log.Log(logger.LevelError, message, serviceID, err)
+	}
+}
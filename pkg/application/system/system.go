@@ -3,6 +3,7 @@ package system
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/edward1christian/block-forge/pkg/application/common/context"
 	"github.com/edward1christian/block-forge/pkg/application/common/event"
@@ -75,6 +76,14 @@ type SystemInterface interface {
 	// Returns the output of the operation and an error if the operation is not found or if execution fails.
 	ExecuteOperation(ctx *context.Context, operationID string, data *SystemOperationInput) (*SystemOperationOutput, error)
 
+	// ExecuteOperationAsync queues the operation with the given ID for asynchronous execution.
+	// Returns the job executing the operation and an error if the operation is not found or the queue is full.
+	ExecuteOperationAsync(ctx *context.Context, operationID string, data *SystemOperationInput) (*Job, error)
+
+	// GetJob returns the asynchronous job with the given ID.
+	// Returns an error if the job is not found.
+	GetJob(jobID string) (*Job, error)
+
 	// StartService starts the service with the given ID.
 	// Returns an error if the service ID is not found or other error.
 	StartService(ctx *context.Context, serviceID string) error
@@ -86,6 +95,19 @@ type SystemInterface interface {
 	// RestartService restarts the service with the given ID.
 	// Returns an error if the service ID is not found or other error.
 	RestartService(ctx *context.Context, serviceID string) error
+
+	// Health returns the aggregated health of the system and all registered services.
+	Health(ctx *context.Context) *SystemHealth
+
+	// Supervisor returns the supervisor restarting failed services.
+	Supervisor() *Supervisor
+
+	// AddOperationInterceptor adds an interceptor around the execution of every operation.
+	// Interceptors are applied in the order they are added, the first being the outermost.
+	AddOperationInterceptor(interceptor OperationInterceptor)
+
+	// Catalog returns the operations and services registered with the system, sorted by ID.
+	Catalog() []*CatalogEntry
 }
 
 // System status.
@@ -108,6 +130,12 @@ type SystemImpl struct {
 	pluginManager PluginManagerInterface
 	status        SystemStatusType
 	store         store.MultiStore
+	started       []SystemServiceInterface // Services started by the system, in start order
+	supervisor    *Supervisor
+	interceptors  []OperationInterceptor
+	jobs          *jobPool
+	shuttingDown  bool              // Whether new operations are rejected
+	inflight      *operationCounter // Operations being executed or queued
 }
 
 // NewSystem creates a new instance of the SystemImpl.
@@ -118,7 +146,7 @@ func NewSystem(
 	pluginManager PluginManagerInterface,
 	componentReg component.ComponentRegistrarInterface,
 	store store.MultiStore) *SystemImpl {
-	return &SystemImpl{
+	system := &SystemImpl{
 		logger:        logger,
 		eventBus:      eventBus,
 		componentReg:  componentReg,
@@ -126,6 +154,34 @@ func NewSystem(
 		pluginManager: pluginManager,
 		status:        SystemStoppedType,
 		store:         store,
+		inflight:      newOperationCounter(),
+	}
+	system.supervisor = NewSupervisor(system)
+	system.registerTopics()
+
+	// Publish the changes of the registry on the system event bus
+	if source, ok := componentReg.(component.EventSourceInterface); ok && eventBus != nil {
+		source.SetEventBus(eventBus)
+		source.SetLogger(logger)
+	}
+	if configuration != nil {
+		system.jobs = newJobPool(configuration.MaxConcurrentOperations, configuration.OperationQueueSize, time.Duration(configuration.JobRetention))
+	} else {
+		system.jobs = newJobPool(0, 0, 0)
+	}
+	return system
+}
+
+// registerTopics registers the payload types of the system events on the system event bus, so
+// that mismatched events are rejected.
+func (s *SystemImpl) registerTopics() {
+	if s.eventBus == nil {
+		return
+	}
+	if topics := s.eventBus.Topics(); topics != nil {
+		if err := RegisterTopics(topics); err != nil && s.logger != nil {
+			s.logger.Log(logger.LevelError, "Failed to register event topics:", err)
+		}
 	}
 }
 
@@ -159,124 +215,386 @@ func (s *SystemImpl) PluginManager() PluginManagerInterface {
 	return s.pluginManager
 }
 
+// Supervisor returns the supervisor restarting failed services.
+func (s *SystemImpl) Supervisor() *Supervisor {
+	return s.supervisor
+}
+
+// AddOperationInterceptor adds an interceptor around the execution of every operation.
+// Interceptors are applied in the order they are added, the first being the outermost.
+func (s *SystemImpl) AddOperationInterceptor(interceptor OperationInterceptor) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.interceptors = append(s.interceptors, interceptor)
+}
+
 // Initialize initializes the system component by executing the initialize operation.
+// The operations and services declared in the configuration are created from the factories
+// of the component registrar and initialized once the plugins are initialized.
 func (s *SystemImpl) Initialize(ctx *context.Context) error {
 	// Override this function to customize system initialization
 
-	s.status = SystemInitializedType
+	s.mutex.Lock()
+	s.shuttingDown = false
+	s.mutex.Unlock()
 	s.pluginManager.Initialize(ctx, s)
+
+	// Create the components declared in the configuration once the plugins registered their factories
+	if err := s.bootstrapComponents(ctx); err != nil {
+		return err
+	}
+
+	s.status = SystemInitializedType
 	return nil
 }
 
 // Start starts the system component along with all registered services.
+// Services are started in dependency order, before the plugins are started. If the plugins
+// fail to start, the plugins and services that were started are stopped.
+// Services with a restart policy are supervised once the system is started.
 func (s *SystemImpl) Start(ctx *context.Context) error {
-	s.mutex.RLock()
-	defer s.mutex.RUnlock()
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 
 	if s.status != SystemInitializedType {
 		return ErrSystemNotInitialized
 	}
 
+	policies, err := s.restartPolicies()
+	if err != nil {
+		return err
+	}
+
+	if err := s.startServices(ctx); err != nil {
+		s.logger.Log(logger.LevelError, "Error starting services:", err)
+		return err
+	}
+
 	if err := s.pluginManager.StartPlugins(ctx); err != nil {
-		// Log the error, but continue stopping other services
+		// Stop the plugins that were started and the services, which the system cannot stop once it failed to start
 		s.logger.Log(logger.LevelError, "Error starting plugin:", err)
+		if err := s.pluginManager.StopPlugins(ctx); err != nil {
+			s.logger.Log(logger.LevelError, "Error stopping plugins:", err)
+		}
+		s.stopServices(ctx)
 		return err
 	}
+
+	s.superviseServices(ctx, policies)
 	s.status = SystemStartedType
 	return nil
 }
 
-// Stop stops the system component along with all registered services.
+// Stop gracefully shuts down the system. New operations are rejected, in-flight operations
+// are drained up to the deadline of the context, then plugins and services are stopped in the
+// reverse order in which they were started and the MultiStore is saved and closed. The MultiStore
+// is left open if operations are still running once the deadline is reached.
+// Returns a ShutdownError reporting every step that failed.
 func (s *SystemImpl) Stop(ctx *context.Context) error {
-	s.mutex.RLock()
-	defer s.mutex.RUnlock()
-
+	s.mutex.Lock()
 	if s.status != SystemStartedType {
+		s.mutex.Unlock()
 		return ErrSystemNotStarted
 	}
+	if s.shuttingDown {
+		s.mutex.Unlock()
+		return ErrSystemShuttingDown
+	}
+	// Reject new operations while the in-flight ones are drained
+	s.shuttingDown = true
+	s.mutex.Unlock()
+
+	report := &ShutdownError{}
+	drainErr := s.drainOperations(ctx)
+	report.add("operations", drainErr)
+	s.jobs.stop()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// Stop supervising before stopping services so they are not restarted
+	s.supervisor.Stop()
+
+	report.add("plugins", s.pluginManager.StopPlugins(ctx))
+	for _, failure := range s.stopServices(ctx) {
+		report.Failures = append(report.Failures, failure)
+	}
+	if drainErr == nil {
+		s.closeStore(report)
+	} else {
+		// Operations still running may use the MultiStore, which is left open
+		s.logger.Log(logger.LevelError, "Not closing the MultiStore, operations are still running")
+	}
+
+	s.status = SystemStoppedType
+	if err := report.errOrNil(); err != nil {
+		s.logger.Log(logger.LevelError, "Error stopping system:", err)
+		return err
+	}
+	return nil
+}
+
+// closeStore saves the latest version of the MultiStore and closes it, if any.
+func (s *SystemImpl) closeStore(report *ShutdownError) {
+	if s.store == nil {
+		return
+	}
+	if _, _, err := s.store.SaveVersion(); err != nil {
+		report.add("multistore", fmt.Errorf("failed to save version: %w", err))
+	}
+	report.add("multistore", s.store.Close())
+}
+
+// startServices starts all registered services in dependency order.
+// Services whose configuration disables AutoStart are left to be started with StartService.
+// If a service fails to start, the services already started are stopped again.
+func (s *SystemImpl) startServices(ctx *context.Context) error {
+	ordered, err := s.orderServices()
+	if err != nil {
+		return err
+	}
+
+	for _, service := range ordered {
+		if !s.startsWithSystem(service.ID()) {
+			continue
+		}
+		if err := service.Start(ctx); err != nil {
+			// Roll back the services started so far
+			s.stopServices(ctx)
+			return fmt.Errorf("failed to start service %s: %w", service.ID(), err)
+		}
+		s.trackStarted(service, true)
+	}
+	return nil
+}
+
+// trackStarted records whether the service is started, so that the started services are stopped
+// with the system in reverse start order. The mutex must be held.
+func (s *SystemImpl) trackStarted(service SystemServiceInterface, started bool) {
+	for i, startedService := range s.started {
+		if startedService.ID() == service.ID() {
+			s.started = append(s.started[:i], s.started[i+1:]...)
+			break
+		}
+	}
+	if started {
+		s.started = append(s.started, service)
+	}
+	setStarted(s.ComponentRegistry(), service.ID(), started)
+}
+
+// setStarted records whether the component with the given ID is started in the given registry,
+// if the registry tracks started components.
+func setStarted(registry component.ComponentRegistrarInterface, id string, started bool) {
+	if tracker, ok := registry.(component.StartTrackerInterface); ok {
+		tracker.SetStarted(id, started)
+	}
+}
+
+// stopServices stops the services started by the system in reverse start order.
+// Returns the services that failed to stop.
+func (s *SystemImpl) stopServices(ctx *context.Context) []ShutdownFailure {
+	var failures []ShutdownFailure
+	for i := len(s.started) - 1; i >= 0; i-- {
+		if err := s.started[i].Stop(ctx); err != nil {
+			// Log the error, but continue stopping other services
+			s.logger.Log(logger.LevelError, "Error stopping service:", err)
+			failures = append(failures, ShutdownFailure{Component: s.started[i].ID(), Err: err})
+			continue
+		}
+		setStarted(s.ComponentRegistry(), s.started[i].ID(), false)
+	}
+	s.started = nil
+	return failures
+}
+
+// restartPolicies returns the restart policies of the configured services, by service ID.
+// Returns an error if a configured restart policy is invalid.
+func (s *SystemImpl) restartPolicies() (map[string]RestartPolicy, error) {
+	policies := make(map[string]RestartPolicy)
+	if s.configuration == nil {
+		return policies, nil
+	}
+
+	for _, serviceConfig := range s.configuration.Services {
+		if serviceConfig == nil {
+			continue
+		}
+		policy, err := RestartPolicyFromConfig(serviceConfig)
+		if err != nil {
+			return nil, err
+		}
+		if policy.Policy != RestartNever {
+			policies[serviceConfig.ID] = policy
+		}
+	}
+	return policies, nil
+}
+
+// superviseServices supervises the started services that have a restart policy.
+func (s *SystemImpl) superviseServices(ctx *context.Context, policies map[string]RestartPolicy) {
+	for _, service := range s.started {
+		policy, ok := policies[service.ID()]
+		if !ok {
+			continue
+		}
+		if err := s.supervisor.Supervise(ctx, service, policy); err != nil {
+			// Log the error, but continue supervising other services
+			s.logger.Log(logger.LevelWarn, "Error supervising service:", err)
+		}
+	}
+}
+
+// orderServices returns the registered services sorted by their dependencies.
+// Returns an error if a dependency is not registered or the dependencies contain a cycle.
+func (s *SystemImpl) orderServices() ([]SystemServiceInterface, error) {
 	// Retrieve all components of type ServiceType
 	components := s.ComponentRegistry().GetComponentsByType(component.ServiceType)
 
-	// Iterate over each service component and start it
-	for _, service := range components {
+	services := make([]SystemServiceInterface, 0, len(components))
+	dependencies := make(map[string][]string, len(components))
+	for _, comp := range components {
 		// Check if the component implements SystemServiceInterface
-		systemService, ok := service.(SystemServiceInterface)
-		if !ok {
-			return fmt.Errorf("failed to start service: component %v is not a service", service)
+		service, err := component.ComponentAs[SystemServiceInterface](comp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to start services: %w", err)
 		}
+		services = append(services, service)
+		dependencies[service.ID()] = s.serviceDependencies(service)
+	}
 
-		// Stop the service
-		if err := systemService.Stop(ctx); err != nil {
-			// Log the error, but continue stopping other services
-			s.logger.Log(logger.LevelError, "Error stopping service:", err)
+	// Every dependency must refer to a registered component
+	for id, deps := range dependencies {
+		for _, dep := range deps {
+			if _, err := s.ComponentRegistry().GetComponent(dep); err != nil {
+				return nil, fmt.Errorf("%w: service %s depends on %s", ErrServiceDependencyNotFound, id, dep)
+			}
 		}
 	}
 
-	s.status = SystemStoppedType
-	return nil
+	return SortServicesByDependencies(services, dependencies)
+}
+
+// serviceDependencies returns the IDs of the components the service depends on,
+// combining the dependencies declared by the service and by the system configuration.
+func (s *SystemImpl) serviceDependencies(service SystemServiceInterface) []string {
+	var deps []string
+	if dependent, ok := service.(component.DependentInterface); ok {
+		deps = append(deps, dependent.Dependencies()...)
+	}
+
+	if s.configuration != nil {
+		for _, serviceConfig := range s.configuration.Services {
+			if serviceConfig != nil && serviceConfig.ID == service.ID() {
+				deps = append(deps, serviceConfig.DependsOn...)
+			}
+		}
+	}
+	return deps
 }
 
 // ExecuteOperation executes the operation with the given ID and input data.
 // Returns the output of the operation and an error if the operation is not found or if execution fails.
 func (s *SystemImpl) ExecuteOperation(ctx *context.Context, operationID string, data *SystemOperationInput) (*SystemOperationOutput, error) {
-	s.mutex.RLock()
-	defer s.mutex.RUnlock()
+	operation, invoker, err := s.operationInvoker(operationID)
+	if err != nil {
+		return nil, err
+	}
+	defer s.inflight.done()
 
-	// Retrieve the operation by its ID
-	component, err := s.ComponentRegistry().GetComponent(operationID)
+	// Execute the operation through the registered interceptors, without holding the system lock
+	return invoker(ctx, operation, data)
+}
+
+// ExecuteOperationAsync queues the operation with the given ID for asynchronous execution.
+// Returns the job executing the operation and an error if the operation is not found or the queue is full.
+func (s *SystemImpl) ExecuteOperationAsync(ctx *context.Context, operationID string, data *SystemOperationInput) (*Job, error) {
+	operation, invoker, err := s.operationInvoker(operationID)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := s.jobs.submit(ctx, operation, data, invoker, s.inflight.done)
 	if err != nil {
+		s.inflight.done()
 		return nil, err
 	}
+	return job, nil
+}
 
-	// Check if the component implements Operation interface
-	operation, ok := component.(SystemOperationInterface)
+// GetJob returns the asynchronous job with the given ID. Completed jobs are forgotten once the
+// job retention of the configuration has elapsed.
+// Returns an error if the job is not found.
+func (s *SystemImpl) GetJob(jobID string) (*Job, error) {
+	job, ok := s.jobs.get(jobID)
 	if !ok {
-		return nil, fmt.Errorf("failed to execute operation: component %v is not an operation", operation)
+		return nil, fmt.Errorf("%w: %s", ErrJobNotFound, jobID)
 	}
-	// Execute the operation
-	return operation.Execute(ctx, data)
+	return job, nil
+}
+
+// operationInvoker returns the operation with the given ID and the invoker executing it
+// through the registered interceptors. On success, the operation is counted as in flight
+// and the caller must call s.inflight.done once it completes.
+// Returns ErrSystemShuttingDown if the system is shutting down.
+func (s *SystemImpl) operationInvoker(operationID string) (SystemOperationInterface, OperationInvoker, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if s.shuttingDown {
+		return nil, nil, fmt.Errorf("%w: operation %s rejected", ErrSystemShuttingDown, operationID)
+	}
+
+	// Retrieve the operation by its ID
+	operation, err := component.GetComponentAs[SystemOperationInterface](s.ComponentRegistry(), operationID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to execute operation: %w", err)
+	}
+
+	interceptors := append([]OperationInterceptor(nil), s.interceptors...)
+	s.inflight.add()
+	return operation, chainInvoker(interceptors, invokeOperation), nil
 }
 
 // StartService starts the service with the given ID.
 // Returns an error if the service ID is not found or other error
 func (s *SystemImpl) StartService(ctx *context.Context, serviceID string) error {
-	s.mutex.RLock()
-	defer s.mutex.RUnlock()
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 
 	// Retrieve the service by its ID
-	component, err := s.ComponentRegistry().GetComponent(serviceID)
+	service, err := component.GetComponentAs[SystemServiceInterface](s.ComponentRegistry(), serviceID)
 	if err != nil {
-		return err
-	}
-	// Check if the component implements SystemServiceInterface interface
-	service, ok := component.(SystemServiceInterface)
-	if !ok {
-		return fmt.Errorf("failed to start service: component %v is not a service", service)
+		return fmt.Errorf("failed to start service: %w", err)
 	}
 
-	// Start the service
-	return service.Start(ctx)
+	// Start the service, to be stopped with the system
+	if err := service.Start(ctx); err != nil {
+		return err
+	}
+	s.trackStarted(service, true)
+	return nil
 }
 
 // StopService stops the service with the given ID.
 // Returns an error if the service ID is not found or other error.
 func (s *SystemImpl) StopService(ctx *context.Context, serviceID string) error {
-	s.mutex.RLock()
-	defer s.mutex.RUnlock()
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
 
 	// Retrieve the service by its ID
-	component, err := s.ComponentRegistry().GetComponent(serviceID)
+	service, err := component.GetComponentAs[SystemServiceInterface](s.ComponentRegistry(), serviceID)
 	if err != nil {
-		return err
-	}
-	// Check if the component implements SystemServiceInterface interface
-	service, ok := component.(SystemServiceInterface)
-	if !ok {
-		return fmt.Errorf("failed to start service: component %v is not a service", service)
+		return fmt.Errorf("failed to stop service: %w", err)
 	}
 
-	// Start the service
-	return service.Stop(ctx)
+	// Stop the service
+	if err := service.Stop(ctx); err != nil {
+		return err
+	}
+	s.trackStarted(service, false)
+	return nil
 }
 
 // RestartService restarts the service with the given ID.
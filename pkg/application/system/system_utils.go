@@ -1,7 +1,6 @@
 package system
 
 import (
-	"errors"
 	"fmt"
 
 	"github.com/edward1christian/block-forge/pkg/application/common/context"
@@ -22,9 +21,9 @@ func StartService(
 		return fmt.Errorf("failed to start service. Could not create component %s", config.ID)
 	}
 
-	service, ok := component.(SystemServiceInterface)
-	if !ok {
-		return fmt.Errorf("failed to start service. Component %s is not a system service", component.ID())
+	service, err := compApi.ComponentAs[SystemServiceInterface](component)
+	if err != nil {
+		return fmt.Errorf("failed to start service: %w", err)
 	}
 
 	// Initialize the service
@@ -32,23 +31,25 @@ func StartService(
 		return fmt.Errorf("failed to initialize service: %s %v", component.ID(), err)
 	}
 
-	return service.Start(ctx)
+	if err := service.Start(ctx); err != nil {
+		return err
+	}
+	setStarted(registrar, config.ID, true)
+	return nil
 }
 
 func StopService(ctx *context.Context, system SystemInterface, id string) error {
-	// Retrieve the BuildService component from the ComponentRegistry
-	component, err := system.ComponentRegistry().GetComponent(id)
+	// Retrieve the service from the ComponentRegistry
+	service, err := compApi.GetComponentAs[SystemServiceInterface](system.ComponentRegistry(), id)
 	if err != nil {
-		return fmt.Errorf("failed to stop build service. Service not found: %v", err)
+		return fmt.Errorf("failed to stop service: %w", err)
 	}
 
-	// Check if the retrieved component implements the SystemServiceInterface
-	service, ok := component.(SystemServiceInterface)
-	if !ok {
-		return errors.New("failed to stop service. Service component is not a system service")
+	if err := service.Stop(ctx); err != nil {
+		return err
 	}
-
-	return service.Stop(ctx)
+	setStarted(system.ComponentRegistry(), id, false)
+	return nil
 }
 
 func RegisterComponent(ctx *context.Context, system SystemInterface, config *config.ComponentConfig, factory compApi.ComponentFactoryInterface) error {
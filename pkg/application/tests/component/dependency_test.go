@@ -0,0 +1,178 @@
+package component_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	"github.com/edward1christian/block-forge/pkg/application/component"
+	configApi "github.com/edward1christian/block-forge/pkg/application/config"
+)
+
+// greeter is the interface of the components injected in the tests.
+type greeter interface {
+	Greet() string
+}
+
+// greeterComponent is a component implementing greeter.
+type greeterComponent struct {
+	component.BaseComponent
+}
+
+// Greet returns a greeting from the component.
+func (c *greeterComponent) Greet() string {
+	return "hello from " + c.ID()
+}
+
+// greeterFactory creates greeterComponents.
+type greeterFactory struct{}
+
+// CreateComponent creates a greeterComponent with the configured ID.
+func (f *greeterFactory) CreateComponent(config *configApi.ComponentConfig) (component.ComponentInterface, error) {
+	return &greeterComponent{BaseComponent: component.BaseComponent{Id: config.ID}}, nil
+}
+
+// clientComponent is a component receiving its dependencies at creation time.
+type clientComponent struct {
+	component.BaseComponent
+	dependencies component.Dependencies
+}
+
+// clientFactory creates clientComponents with the dependencies configured by component ID.
+type clientFactory struct {
+	dependencies map[string][]component.Dependency
+}
+
+// CreateComponent creates a clientComponent without dependencies.
+func (f *clientFactory) CreateComponent(config *configApi.ComponentConfig) (component.ComponentInterface, error) {
+	return f.CreateInjectedComponent(config, nil)
+}
+
+// Dependencies returns the dependencies configured for the component.
+func (f *clientFactory) Dependencies(config *configApi.ComponentConfig) []component.Dependency {
+	return f.dependencies[config.ID]
+}
+
+// CreateInjectedComponent creates a clientComponent with the given dependencies.
+func (f *clientFactory) CreateInjectedComponent(config *configApi.ComponentConfig, dependencies component.Dependencies) (component.ComponentInterface, error) {
+	return &clientComponent{BaseComponent: component.BaseComponent{Id: config.ID}, dependencies: dependencies}, nil
+}
+
+// newDependencyRegistrar creates a registrar with the greeter and client factories.
+func newDependencyRegistrar(t *testing.T, dependencies map[string][]component.Dependency) *component.ComponentRegistrar {
+	registrar := component.NewComponentRegistrar()
+	assert.NoError(t, registrar.RegisterFactory(context.Background(), "greeter", &greeterFactory{}))
+	assert.NoError(t, registrar.RegisterFactory(context.Background(), "client", &clientFactory{dependencies: dependencies}))
+	return registrar
+}
+
+// TestComponentRegistrar_CreateComponent_InjectsDependencies tests that dependencies are resolved
+// by interface and by component ID.
+func TestComponentRegistrar_CreateComponent_InjectsDependencies(t *testing.T) {
+	ctx := context.Background()
+	registrar := newDependencyRegistrar(t, map[string][]component.Dependency{
+		"client": {
+			{Name: "greeter", Interface: component.InterfaceOf[greeter]()},
+			{Name: "same", ComponentID: "english", Interface: component.InterfaceOf[greeter]()},
+			{Name: "logger", ComponentID: "logger", Optional: true},
+		},
+	})
+	_, err := registrar.CreateComponent(ctx, &configApi.ComponentConfig{ID: "english", FactoryID: "greeter"})
+	assert.NoError(t, err)
+
+	comp, err := registrar.CreateComponent(ctx, &configApi.ComponentConfig{ID: "client", FactoryID: "client"})
+	assert.NoError(t, err)
+
+	client := comp.(*clientComponent)
+	assert.Len(t, client.dependencies, 2)
+	g, err := component.DependencyAs[greeter](client.dependencies, "greeter")
+	assert.NoError(t, err)
+	assert.Equal(t, "hello from english", g.Greet())
+	assert.Same(t, client.dependencies["greeter"], client.dependencies["same"])
+
+	_, err = component.DependencyAs[greeter](client.dependencies, "logger")
+	assert.True(t, errors.Is(err, component.ErrMissingDependency))
+}
+
+// TestComponentRegistrar_CreateComponent_MissingDependency tests that a component is not created
+// when a required dependency cannot be resolved.
+func TestComponentRegistrar_CreateComponent_MissingDependency(t *testing.T) {
+	ctx := context.Background()
+	registrar := newDependencyRegistrar(t, map[string][]component.Dependency{
+		"client": {{Name: "greeter", Interface: component.InterfaceOf[greeter]()}},
+		"other":  {{Name: "greeter", ComponentID: "client", Interface: component.InterfaceOf[greeter]()}},
+	})
+
+	_, err := registrar.CreateComponent(ctx, &configApi.ComponentConfig{ID: "client", FactoryID: "client"})
+	assert.True(t, errors.Is(err, component.ErrMissingDependency))
+	assert.Contains(t, err.Error(), "client requires greeter")
+	_, err = registrar.GetComponent("client")
+	assert.True(t, errors.Is(err, component.ErrComponentNotFound))
+
+	// A component registered under the ID must implement the interface
+	registrar = newDependencyRegistrar(t, map[string][]component.Dependency{
+		"other": {{Name: "greeter", ComponentID: "client", Interface: component.InterfaceOf[greeter]()}},
+	})
+	_, err = registrar.CreateComponent(ctx, &configApi.ComponentConfig{ID: "client", FactoryID: "client"})
+	assert.NoError(t, err)
+	_, err = registrar.CreateComponent(ctx, &configApi.ComponentConfig{ID: "other", FactoryID: "client"})
+	assert.True(t, errors.Is(err, component.ErrMissingDependency))
+	assert.Contains(t, err.Error(), "does not implement")
+}
+
+// TestComponentRegistrar_CreateComponent_AmbiguousDependency tests that a dependency resolved by
+// interface fails when several components implement it.
+func TestComponentRegistrar_CreateComponent_AmbiguousDependency(t *testing.T) {
+	ctx := context.Background()
+	registrar := newDependencyRegistrar(t, map[string][]component.Dependency{
+		"client": {{Name: "greeter", Interface: component.InterfaceOf[greeter]()}},
+	})
+	for _, id := range []string{"english", "french"} {
+		_, err := registrar.CreateComponent(ctx, &configApi.ComponentConfig{ID: id, FactoryID: "greeter"})
+		assert.NoError(t, err)
+	}
+
+	_, err := registrar.CreateComponent(ctx, &configApi.ComponentConfig{ID: "client", FactoryID: "client"})
+
+	assert.True(t, errors.Is(err, component.ErrAmbiguousDependency))
+	assert.Contains(t, err.Error(), "english, french")
+}
+
+// TestComponentRegistrar_CreateComponent_CreatesDependencies tests that unregistered dependencies
+// are created from their configuration, in the same scope.
+func TestComponentRegistrar_CreateComponent_CreatesDependencies(t *testing.T) {
+	ctx := context.Background()
+	registrar := newDependencyRegistrar(t, map[string][]component.Dependency{
+		"client": {{Name: "greeter", Interface: component.InterfaceOf[greeter](),
+			Config: &configApi.ComponentConfig{ID: "english", FactoryID: "greeter"}}},
+	})
+	scope, err := registrar.CreateScope("process")
+	assert.NoError(t, err)
+
+	comp, err := scope.CreateComponent(ctx, &configApi.ComponentConfig{ID: "client", FactoryID: "client"})
+	assert.NoError(t, err)
+
+	created, err := scope.GetComponent("english")
+	assert.NoError(t, err)
+	assert.Same(t, created, comp.(*clientComponent).dependencies["greeter"])
+	_, err = registrar.GetComponent("english")
+	assert.True(t, errors.Is(err, component.ErrComponentNotFound))
+}
+
+// TestComponentRegistrar_CreateComponent_DependencyCycle tests that dependency cycles are detected.
+func TestComponentRegistrar_CreateComponent_DependencyCycle(t *testing.T) {
+	ctx := context.Background()
+	registrar := newDependencyRegistrar(t, map[string][]component.Dependency{
+		"a": {{Name: "b", Config: &configApi.ComponentConfig{ID: "b", FactoryID: "client"}}},
+		"b": {{Name: "c", Config: &configApi.ComponentConfig{ID: "c", FactoryID: "client"}}},
+		"c": {{Name: "a", ComponentID: "a", Config: &configApi.ComponentConfig{ID: "a", FactoryID: "client"}}},
+	})
+
+	_, err := registrar.CreateComponent(ctx, &configApi.ComponentConfig{ID: "a", FactoryID: "client"})
+
+	assert.True(t, errors.Is(err, component.ErrDependencyCycle))
+	assert.Contains(t, err.Error(), "a -> b -> c -> a")
+	assert.Empty(t, registrar.GetAllComponents())
+}
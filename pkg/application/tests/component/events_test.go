@@ -0,0 +1,112 @@
+package component_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	"github.com/edward1christian/block-forge/pkg/application/common/event"
+	"github.com/edward1christian/block-forge/pkg/application/common/logger"
+	"github.com/edward1christian/block-forge/pkg/application/component"
+	configApi "github.com/edward1christian/block-forge/pkg/application/config"
+	"github.com/edward1christian/block-forge/pkg/application/mocks"
+)
+
+// recordRegistryEvents subscribes to the registry events of the given bus and returns the received events.
+func recordRegistryEvents(t *testing.T, bus event.EventBusInterface) *[]event.Event {
+	var events []event.Event
+	for _, topic := range []string{
+		component.EventTypeFactoryRegistered, component.EventTypeFactoryUnregistered,
+		component.EventTypeComponentCreated, component.EventTypeComponentRemoved,
+	} {
+		_, err := bus.Subscribe(event.BusSubscriptionParams{
+			Topic:        topic,
+			EventHandler: func(e event.Event) { events = append(events, e) },
+		})
+		assert.NoError(t, err)
+	}
+	return &events
+}
+
+// TestComponentRegistrar_PublishesEvents tests that registry changes are published on the event bus.
+func TestComponentRegistrar_PublishesEvents(t *testing.T) {
+	var lifecycle []string
+	bus := event.NewSystemEventBus()
+	events := recordRegistryEvents(t, bus)
+	registrar := component.NewComponentRegistrar()
+	registrar.SetEventBus(bus)
+	ctx := component.WithOwner(context.Background(), "plugin")
+
+	assert.NoError(t, registrar.RegisterFactory(ctx, "factory", &lifecycleFactory{events: &lifecycle}))
+	_, err := registrar.CreateComponent(ctx, &configApi.ComponentConfig{ID: "a", FactoryID: "factory"})
+	assert.NoError(t, err)
+	assert.NoError(t, registrar.UnregisterFactory(context.Background(), "factory"))
+
+	assert.Equal(t, []event.Event{
+		{Type: component.EventTypeFactoryRegistered, Data: component.RegistryEvent{FactoryID: "factory", Owner: "plugin"}},
+		{Type: component.EventTypeComponentCreated, Data: component.RegistryEvent{
+			FactoryID: "factory", ComponentID: "a", ComponentType: component.BasicComponentType, Owner: "plugin"}},
+		{Type: component.EventTypeComponentRemoved, Data: component.RegistryEvent{
+			FactoryID: "factory", ComponentID: "a", ComponentType: component.BasicComponentType, Owner: "plugin"}},
+		{Type: component.EventTypeFactoryUnregistered, Data: component.RegistryEvent{FactoryID: "factory", Owner: "plugin"}},
+	}, *events)
+}
+
+// TestComponentRegistrar_PublishesScopeEvents tests that scopes publish on the event bus of their parent.
+func TestComponentRegistrar_PublishesScopeEvents(t *testing.T) {
+	var lifecycle []string
+	bus := event.NewSystemEventBus()
+	registrar := newLifecycleRegistrar(t, &lifecycle)
+	registrar.SetEventBus(bus)
+	events := recordRegistryEvents(t, bus)
+
+	scope, err := registrar.CreateScope("process")
+	assert.NoError(t, err)
+	_, err = scope.CreateComponent(context.Background(), &configApi.ComponentConfig{ID: "a", FactoryID: "factoryA"})
+	assert.NoError(t, err)
+	assert.NoError(t, scope.TearDown(context.Background()))
+
+	assert.Len(t, *events, 2)
+	for _, e := range *events {
+		assert.Equal(t, "process", e.Data.(component.RegistryEvent).Scope)
+	}
+	assert.Equal(t, component.EventTypeComponentRemoved, (*events)[1].Type)
+}
+
+// recordingLogger records the messages logged at the error level.
+type recordingLogger struct {
+	errors []string
+}
+
+func (l *recordingLogger) Log(level logger.Level, args ...interface{}) {
+	if level == logger.LevelError {
+		l.errors = append(l.errors, fmt.Sprint(args...))
+	}
+}
+
+func (l *recordingLogger) Logf(level logger.Level, format string, args ...interface{}) {
+	l.Log(level, fmt.Sprintf(format, args...))
+}
+
+// TestComponentRegistrar_LogsPublishErrors tests that failures to publish registry events are logged,
+// with the logger of the parent for scopes.
+func TestComponentRegistrar_LogsPublishErrors(t *testing.T) {
+	bus := &mocks.MockEventBus{}
+	bus.On("Publish", mock.Anything).Return(errors.New("bus closed"))
+	log := &recordingLogger{}
+	registrar := component.NewComponentRegistrar()
+	registrar.SetEventBus(bus)
+	registrar.SetLogger(log)
+
+	scope, err := registrar.CreateScope("process")
+	assert.NoError(t, err)
+	assert.NoError(t, scope.RegisterFactory(context.Background(), "factory", &lifecycleFactory{events: &[]string{}}))
+
+	assert.Len(t, log.errors, 1)
+	assert.Contains(t, log.errors[0], component.EventTypeFactoryRegistered)
+	assert.Contains(t, log.errors[0], "bus closed")
+}
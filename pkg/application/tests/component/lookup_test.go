@@ -0,0 +1,62 @@
+package component_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	"github.com/edward1christian/block-forge/pkg/application/component"
+	configApi "github.com/edward1christian/block-forge/pkg/application/config"
+)
+
+// newLookupRegistrar creates a registrar with the greeters english and french and the client c.
+func newLookupRegistrar(t *testing.T) *component.ComponentRegistrar {
+	registrar := newDependencyRegistrar(t, nil)
+	for _, config := range []*configApi.ComponentConfig{
+		{ID: "french", FactoryID: "greeter"},
+		{ID: "c", FactoryID: "client"},
+		{ID: "english", FactoryID: "greeter"},
+	} {
+		_, err := registrar.CreateComponent(context.Background(), config)
+		assert.NoError(t, err)
+	}
+	return registrar
+}
+
+// TestGetComponentAs tests the typed retrieval of components.
+func TestGetComponentAs(t *testing.T) {
+	registrar := newLookupRegistrar(t)
+
+	g, err := component.GetComponentAs[greeter](registrar, "english")
+	assert.NoError(t, err)
+	assert.Equal(t, "hello from english", g.Greet())
+
+	_, err = component.GetComponentAs[greeter](registrar, "c")
+	assert.True(t, errors.Is(err, component.ErrComponentTypeMismatch))
+	assert.EqualError(t, err, "component type mismatch: c is not a component_test.greeter")
+
+	_, err = component.GetComponentAs[greeter](registrar, "unknown")
+	assert.True(t, errors.Is(err, component.ErrComponentNotFound))
+}
+
+// TestMustGetComponentAs tests that MustGetComponentAs panics on lookup errors.
+func TestMustGetComponentAs(t *testing.T) {
+	registrar := newLookupRegistrar(t)
+
+	assert.Equal(t, "french", component.MustGetComponentAs[greeter](registrar, "french").(*greeterComponent).ID())
+	assert.Panics(t, func() { component.MustGetComponentAs[greeter](registrar, "c") })
+}
+
+// TestListComponentsAs tests that the components of a type are listed by ID.
+func TestListComponentsAs(t *testing.T) {
+	registrar := newLookupRegistrar(t)
+
+	greeters := component.ListComponentsAs[greeter](registrar)
+
+	assert.Len(t, greeters, 2)
+	assert.Equal(t, "hello from english", greeters[0].Greet())
+	assert.Equal(t, "hello from french", greeters[1].Greet())
+	assert.Empty(t, component.ListComponentsAs[component.DisposableInterface](registrar))
+}
@@ -0,0 +1,328 @@
+package component_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	"github.com/edward1christian/block-forge/pkg/application/component"
+	configApi "github.com/edward1christian/block-forge/pkg/application/config"
+)
+
+// lifecycleComponent is a component recording its lifecycle events.
+type lifecycleComponent struct {
+	component.BaseComponent
+	live    bool
+	stopErr error
+	events  *[]string
+}
+
+// Start records the start of the component.
+func (c *lifecycleComponent) Start(ctx *context.Context) error {
+	*c.events = append(*c.events, "start:"+c.ID())
+	return nil
+}
+
+// Stop records the stop of the component.
+func (c *lifecycleComponent) Stop(ctx *context.Context) error {
+	if c.stopErr != nil {
+		return c.stopErr
+	}
+	*c.events = append(*c.events, "stop:"+c.ID())
+	return nil
+}
+
+// Health reports whether the component is live.
+func (c *lifecycleComponent) Health(ctx *context.Context) component.HealthStatus {
+	return component.HealthStatus{Live: c.live}
+}
+
+// Dispose records the disposal of the component.
+func (c *lifecycleComponent) Dispose(ctx *context.Context) error {
+	*c.events = append(*c.events, "dispose:"+c.ID())
+	return nil
+}
+
+// lifecycleFactory creates lifecycleComponents.
+type lifecycleFactory struct {
+	events *[]string
+	live   bool
+}
+
+// CreateComponent creates a lifecycleComponent with the configured ID.
+func (f *lifecycleFactory) CreateComponent(config *configApi.ComponentConfig) (component.ComponentInterface, error) {
+	return &lifecycleComponent{BaseComponent: component.BaseComponent{Id: config.ID}, live: f.live, events: f.events}, nil
+}
+
+// newLifecycleRegistrar creates a registrar with live components a and b created by factoryA,
+// and a component named factoryA created by factoryB.
+func newLifecycleRegistrar(t *testing.T, events *[]string) *component.ComponentRegistrar {
+	ctx := context.Background()
+	registrar := component.NewComponentRegistrar()
+	assert.NoError(t, registrar.RegisterFactory(ctx, "factoryA", &lifecycleFactory{events: events, live: true}))
+	assert.NoError(t, registrar.RegisterFactory(ctx, "factoryB", &lifecycleFactory{events: events, live: true}))
+
+	for _, config := range []*configApi.ComponentConfig{
+		{ID: "a", FactoryID: "factoryA"},
+		{ID: "factoryA", FactoryID: "factoryB"},
+		{ID: "b", FactoryID: "factoryA"},
+	} {
+		_, err := registrar.CreateComponent(ctx, config)
+		assert.NoError(t, err)
+	}
+	return registrar
+}
+
+// TestComponentRegistrar_CreateComponent_RecordsInfo tests that the factory and owner are recorded.
+func TestComponentRegistrar_CreateComponent_RecordsInfo(t *testing.T) {
+	var events []string
+	registrar := newLifecycleRegistrar(t, &events)
+	_, err := registrar.CreateComponent(component.WithOwner(context.Background(), "plugin"),
+		&configApi.ComponentConfig{ID: "c", FactoryID: "factoryB"})
+	assert.NoError(t, err)
+
+	info, err := registrar.GetComponentInfo("c")
+	assert.NoError(t, err)
+	assert.Equal(t, component.ComponentInfo{FactoryID: "factoryB", Owner: "plugin"}, info)
+
+	_, err = registrar.GetComponentInfo("unknown")
+	assert.True(t, errors.Is(err, component.ErrComponentNotFound))
+	_, err = registrar.CreateComponent(context.Background(), &configApi.ComponentConfig{ID: "d", FactoryID: "unknown"})
+	assert.True(t, errors.Is(err, component.ErrFactoryNotFound))
+	assert.True(t, errors.Is(registrar.RegisterFactory(context.Background(), "factoryA", &lifecycleFactory{}),
+		component.ErrFactoryAlreadyExists))
+}
+
+// TestComponentRegistrar_RemoveComponent tests that removed components are stopped and disposed.
+func TestComponentRegistrar_RemoveComponent(t *testing.T) {
+	var events []string
+	registrar := newLifecycleRegistrar(t, &events)
+
+	assert.NoError(t, registrar.RemoveComponent(context.Background(), "a"))
+
+	assert.Equal(t, []string{"stop:a", "dispose:a"}, events)
+	_, err := registrar.GetComponent("a")
+	assert.True(t, errors.Is(err, component.ErrComponentNotFound))
+	assert.True(t, errors.Is(registrar.RemoveComponent(context.Background(), "a"), component.ErrComponentNotFound))
+}
+
+// TestComponentRegistrar_RemoveComponent_NotLive tests that components that are not live are not stopped.
+func TestComponentRegistrar_RemoveComponent_NotLive(t *testing.T) {
+	var events []string
+	registrar := component.NewComponentRegistrar()
+	ctx := context.Background()
+	assert.NoError(t, registrar.RegisterFactory(ctx, "factory", &lifecycleFactory{events: &events}))
+	_, err := registrar.CreateComponent(ctx, &configApi.ComponentConfig{ID: "idle", FactoryID: "factory"})
+	assert.NoError(t, err)
+
+	assert.NoError(t, registrar.UnregisterComponent(ctx, "idle"))
+
+	assert.Equal(t, []string{"dispose:idle"}, events)
+}
+
+// startableComponent is a startable component that does not report its health.
+type startableComponent struct {
+	component.BaseComponent
+	events *[]string
+}
+
+// Start records the start of the component.
+func (c *startableComponent) Start(ctx *context.Context) error {
+	*c.events = append(*c.events, "start:"+c.ID())
+	return nil
+}
+
+// Stop fails unless the component was started.
+func (c *startableComponent) Stop(ctx *context.Context) error {
+	if len(*c.events) == 0 {
+		return errors.New("not started")
+	}
+	*c.events = append(*c.events, "stop:"+c.ID())
+	return nil
+}
+
+// startableFactory creates startableComponents.
+type startableFactory struct {
+	events *[]string
+}
+
+// CreateComponent creates a startableComponent with the configured ID.
+func (f *startableFactory) CreateComponent(config *configApi.ComponentConfig) (component.ComponentInterface, error) {
+	return &startableComponent{BaseComponent: component.BaseComponent{Id: config.ID}, events: f.events}, nil
+}
+
+// TestComponentRegistrar_RemoveComponent_NeverStarted tests that components that do not report
+// their health are only stopped if they were recorded as started.
+func TestComponentRegistrar_RemoveComponent_NeverStarted(t *testing.T) {
+	var events []string
+	registrar := component.NewComponentRegistrar()
+	ctx := context.Background()
+	assert.NoError(t, registrar.RegisterFactory(ctx, "factory", &startableFactory{events: &events}))
+	for _, id := range []string{"idle", "running"} {
+		_, err := registrar.CreateComponent(ctx, &configApi.ComponentConfig{ID: id, FactoryID: "factory"})
+		assert.NoError(t, err)
+	}
+	running, err := registrar.GetComponent("running")
+	assert.NoError(t, err)
+	assert.NoError(t, running.(component.StartableInterface).Start(ctx))
+	registrar.SetStarted("running", true)
+
+	assert.NoError(t, registrar.RemoveComponent(ctx, "idle"), "a component that was never started must not be stopped")
+	assert.NoError(t, registrar.RemoveComponent(ctx, "running"))
+
+	assert.Equal(t, []string{"start:running", "stop:running"}, events)
+	_, err = registrar.GetComponent("idle")
+	assert.True(t, errors.Is(err, component.ErrComponentNotFound))
+}
+
+// TestComponentRegistrar_RemoveComponent_StopFailed tests that a component failing to stop stays registered.
+func TestComponentRegistrar_RemoveComponent_StopFailed(t *testing.T) {
+	var events []string
+	registrar := newLifecycleRegistrar(t, &events)
+	comp, err := registrar.GetComponent("a")
+	assert.NoError(t, err)
+	comp.(*lifecycleComponent).stopErr = errors.New("busy")
+
+	err = registrar.RemoveComponent(context.Background(), "a")
+
+	assert.True(t, errors.Is(err, component.ErrComponentStopFailed))
+	assert.Contains(t, err.Error(), "busy")
+	_, err = registrar.GetComponent("a")
+	assert.NoError(t, err)
+}
+
+// TestComponentRegistrar_UnregisterFactory tests that unregistering a factory removes the components
+// it created, most recent first, and only those.
+func TestComponentRegistrar_UnregisterFactory(t *testing.T) {
+	var events []string
+	registrar := newLifecycleRegistrar(t, &events)
+
+	assert.NoError(t, registrar.UnregisterFactory(context.Background(), "factoryA"))
+
+	assert.Equal(t, []string{"stop:b", "dispose:b", "stop:a", "dispose:a"}, events)
+	_, err := registrar.GetComponent("factoryA")
+	assert.NoError(t, err, "components named after the factory must not be removed")
+	_, err = registrar.GetFactory("factoryA")
+	assert.True(t, errors.Is(err, component.ErrFactoryNotFound))
+	assert.True(t, errors.Is(registrar.UnregisterFactory(context.Background(), "factoryA"), component.ErrFactoryNotFound))
+}
+
+// TestComponentRegistrar_UnregisterFactory_RemovalFailed tests that the factory stays registered
+// if one of its components cannot be removed.
+func TestComponentRegistrar_UnregisterFactory_RemovalFailed(t *testing.T) {
+	var events []string
+	registrar := newLifecycleRegistrar(t, &events)
+	comp, err := registrar.GetComponent("b")
+	assert.NoError(t, err)
+	comp.(*lifecycleComponent).stopErr = errors.New("busy")
+
+	err = registrar.UnregisterFactory(context.Background(), "factoryA")
+
+	assert.True(t, errors.Is(err, component.ErrComponentStopFailed))
+	_, err = registrar.GetFactory("factoryA")
+	assert.NoError(t, err)
+	_, err = registrar.GetComponent("a")
+	assert.True(t, errors.Is(err, component.ErrComponentNotFound))
+}
+
+// TestComponentRegistrar_UnregisterOwner tests that unregistering an owner removes the components
+// it owns, most recent first, then its factories along with the components they created.
+func TestComponentRegistrar_UnregisterOwner(t *testing.T) {
+	var events []string
+	registrar := newLifecycleRegistrar(t, &events)
+	ctx := component.WithOwner(context.Background(), "plugin")
+	assert.NoError(t, registrar.RegisterFactory(ctx, "pluginFactory", &lifecycleFactory{events: &events, live: true}))
+	for _, config := range []*configApi.ComponentConfig{
+		{ID: "c", FactoryID: "factoryB"},
+		{ID: "d", FactoryID: "factoryA"},
+	} {
+		_, err := registrar.CreateComponent(ctx, config)
+		assert.NoError(t, err)
+	}
+	_, err := registrar.CreateComponent(context.Background(), &configApi.ComponentConfig{ID: "e", FactoryID: "pluginFactory"})
+	assert.NoError(t, err)
+
+	assert.NoError(t, registrar.UnregisterOwner(context.Background(), "plugin"))
+
+	assert.Equal(t, []string{"stop:d", "dispose:d", "stop:c", "dispose:c", "stop:e", "dispose:e"}, events)
+	_, err = registrar.GetFactory("pluginFactory")
+	assert.True(t, errors.Is(err, component.ErrFactoryNotFound))
+	for _, id := range []string{"a", "b", "factoryA"} {
+		_, err = registrar.GetComponent(id)
+		assert.NoError(t, err, "components of other owners must not be removed")
+	}
+	_, err = registrar.GetFactory("factoryA")
+	assert.NoError(t, err)
+}
+
+// TestComponentRegistrar_Scope_Resolution tests that a scope resolves its own components first,
+// then falls back to its parent.
+func TestComponentRegistrar_Scope_Resolution(t *testing.T) {
+	var events []string
+	ctx := context.Background()
+	registrar := newLifecycleRegistrar(t, &events)
+
+	scope, err := registrar.CreateScope("process1")
+	assert.NoError(t, err)
+	other, err := registrar.CreateScope("process2")
+	assert.NoError(t, err)
+	_, err = registrar.CreateScope("process1")
+	assert.True(t, errors.Is(err, component.ErrScopeAlreadyExists))
+
+	// Both scopes may create a component with the same ID using a factory of the parent
+	local, err := scope.CreateComponent(ctx, &configApi.ComponentConfig{ID: "a", FactoryID: "factoryB"})
+	assert.NoError(t, err)
+	_, err = other.CreateComponent(ctx, &configApi.ComponentConfig{ID: "a", FactoryID: "factoryA"})
+	assert.NoError(t, err)
+
+	comp, err := scope.GetComponent("a")
+	assert.NoError(t, err)
+	assert.Same(t, local, comp)
+	info, err := other.GetComponentInfo("a")
+	assert.NoError(t, err)
+	assert.Equal(t, "factoryA", info.FactoryID)
+
+	parentComp, err := registrar.GetComponent("a")
+	assert.NoError(t, err)
+	assert.NotSame(t, local, parentComp)
+	comp, err = scope.GetComponent("b")
+	assert.NoError(t, err, "components of the parent must be visible")
+	assert.Len(t, scope.GetAllComponents(), 3)
+
+	found, err := registrar.GetScope("process1")
+	assert.NoError(t, err)
+	assert.Same(t, scope, found)
+	_, err = registrar.GetScope("unknown")
+	assert.True(t, errors.Is(err, component.ErrScopeNotFound))
+	assert.True(t, errors.Is(scope.RemoveComponent(ctx, "b"), component.ErrComponentNotFound))
+}
+
+// TestComponentRegistrar_Scope_TearDown tests that tearing down a scope removes its components
+// and nested scopes, leaves the parent untouched and detaches the scope.
+func TestComponentRegistrar_Scope_TearDown(t *testing.T) {
+	var events []string
+	ctx := context.Background()
+	registrar := newLifecycleRegistrar(t, &events)
+
+	scope, err := registrar.CreateScope("project")
+	assert.NoError(t, err)
+	nested, err := scope.CreateScope("process")
+	assert.NoError(t, err)
+	_, err = scope.CreateComponent(ctx, &configApi.ComponentConfig{ID: "a", FactoryID: "factoryA"})
+	assert.NoError(t, err)
+	_, err = scope.CreateComponent(ctx, &configApi.ComponentConfig{ID: "c", FactoryID: "factoryA"})
+	assert.NoError(t, err)
+	_, err = nested.CreateComponent(ctx, &configApi.ComponentConfig{ID: "a", FactoryID: "factoryA"})
+	assert.NoError(t, err)
+
+	assert.NoError(t, scope.TearDown(ctx))
+
+	assert.Equal(t, []string{"stop:a", "dispose:a", "stop:c", "dispose:c", "stop:a", "dispose:a"}, events)
+	_, err = registrar.GetScope("project")
+	assert.True(t, errors.Is(err, component.ErrScopeNotFound))
+	_, err = registrar.GetComponent("a")
+	assert.NoError(t, err, "components of the parent must not be removed")
+	assert.Len(t, registrar.GetAllComponents(), 3)
+}
@@ -0,0 +1,114 @@
+package component_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	"github.com/edward1christian/block-forge/pkg/application/component"
+	configApi "github.com/edward1christian/block-forge/pkg/application/config"
+)
+
+// labeledComponent is a component declaring its own labels.
+type labeledComponent struct {
+	component.BaseComponent
+	labels map[string]string
+}
+
+// Labels returns the labels declared by the component.
+func (c *labeledComponent) Labels() map[string]string {
+	return c.labels
+}
+
+// labeledFactory creates labeledComponents declaring the stage=extract label.
+type labeledFactory struct{}
+
+// CreateComponent creates a labeledComponent with the configured ID.
+func (f *labeledFactory) CreateComponent(config *configApi.ComponentConfig) (component.ComponentInterface, error) {
+	return &labeledComponent{
+		BaseComponent: component.BaseComponent{Id: config.ID},
+		labels:        map[string]string{"stage": "extract", "chain": "any"},
+	}, nil
+}
+
+// TestParseSelector tests the matching of the supported selector requirements.
+func TestParseSelector(t *testing.T) {
+	labels := map[string]string{"plugin": "nova", "stage": "extract", "chain": "bitcoin"}
+	tests := []struct {
+		expression string
+		matches    bool
+	}{
+		{"", true},
+		{"plugin=nova", true},
+		{"plugin==nova, stage=extract", true},
+		{"plugin=etl", false},
+		{"plugin!=etl", true},
+		{"missing!=etl", true},
+		{"chain in (bitcoin, ethereum)", true},
+		{"chain in (ethereum)", false},
+		{"chain notin (ethereum),stage", true},
+		{"stage", true},
+		{"!stage", false},
+		{"!role", true},
+	}
+
+	for _, test := range tests {
+		selector, err := component.ParseSelector(test.expression)
+		assert.NoError(t, err, test.expression)
+		assert.Equal(t, test.matches, selector.Matches(labels), test.expression)
+	}
+
+	selector, err := component.ParseSelector("chain in (bitcoin,ethereum), !role,plugin!=etl")
+	assert.NoError(t, err)
+	assert.Equal(t, "chain in (bitcoin,ethereum),!role,plugin!=etl", selector.String())
+	assert.True(t, component.SelectorFromLabels(map[string]string{"plugin": "nova"}).Matches(labels))
+}
+
+// TestParseSelector_Invalid tests that malformed selectors are rejected.
+func TestParseSelector_Invalid(t *testing.T) {
+	for _, expression := range []string{"=nova", "plugin=nova,", "chain in bitcoin", "chain in (bitcoin", "bad key=1", "!"} {
+		_, err := component.ParseSelector(expression)
+		assert.True(t, errors.Is(err, component.ErrInvalidSelector), expression)
+	}
+}
+
+// TestComponentRegistrar_GetComponentsBySelector tests that components are selected by the labels
+// they declare, overridden by the labels of their configuration.
+func TestComponentRegistrar_GetComponentsBySelector(t *testing.T) {
+	ctx := context.Background()
+	registrar := component.NewComponentRegistrar()
+	assert.NoError(t, registrar.RegisterFactory(ctx, "labeled", &labeledFactory{}))
+	for _, config := range []*configApi.ComponentConfig{
+		{ID: "btc", FactoryID: "labeled", Labels: map[string]string{"chain": "bitcoin"}},
+		{ID: "eth", FactoryID: "labeled", Labels: map[string]string{"chain": "ethereum"}},
+		{ID: "any", FactoryID: "labeled"},
+	} {
+		_, err := registrar.CreateComponent(ctx, config)
+		assert.NoError(t, err)
+	}
+	scope, err := registrar.CreateScope("process")
+	assert.NoError(t, err)
+	_, err = scope.CreateComponent(ctx, &configApi.ComponentConfig{ID: "btc", FactoryID: "labeled",
+		Labels: map[string]string{"stage": "load"}})
+	assert.NoError(t, err)
+
+	selector, err := component.ParseSelector("stage=extract,chain in (bitcoin,ethereum)")
+	assert.NoError(t, err)
+	ids := func(components []component.ComponentInterface) []string {
+		var ids []string
+		for _, comp := range components {
+			ids = append(ids, comp.ID())
+		}
+		return ids
+	}
+
+	assert.Equal(t, []string{"btc", "eth"}, ids(registrar.GetComponentsBySelector(selector)))
+	assert.Equal(t, []string{"eth"}, ids(scope.GetComponentsBySelector(selector)), "local components must shadow the parent")
+	assert.Len(t, registrar.GetComponentsBySelector(component.Selector{}), 3)
+
+	info, err := registrar.GetComponentInfo("btc")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"stage": "extract", "chain": "bitcoin"}, info.Labels)
+}
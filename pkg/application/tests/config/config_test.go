@@ -0,0 +1,83 @@
+package config_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	configApi "github.com/edward1christian/block-forge/pkg/application/config"
+)
+
+// loadConfiguration writes the given content to a configuration file and loads it.
+func loadConfiguration(t *testing.T, fileName, content string) (*configApi.Configuration, error) {
+	path := filepath.Join(t.TempDir(), fileName)
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+	configuration := &configApi.Configuration{}
+	return configuration, configApi.LoadConfigurationFromFile(path, configuration)
+}
+
+// TestLoadConfigurationFromFile_Durations tests that JSON and YAML files accept the same duration
+// values, written as duration strings or numbers of nanoseconds.
+func TestLoadConfigurationFromFile_Durations(t *testing.T) {
+	tests := []struct {
+		fileName string
+		content  string
+	}{
+		{"system.yaml", `
+jobRetention: 1m
+services:
+  - id: store
+    retryInterval: 5s
+    maxRetryInterval: 1m30s
+    restartStablePeriod: 3600000000000
+`},
+		{"system.json", `{
+  "jobRetention": "1m",
+  "services": [{
+    "id": "store",
+    "retryInterval": "5s",
+    "maxRetryInterval": "1m30s",
+    "restartStablePeriod": 3600000000000
+  }]
+}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.fileName, func(t *testing.T) {
+			configuration, err := loadConfiguration(t, test.fileName, test.content)
+
+			assert.NoError(t, err)
+			assert.Equal(t, configApi.Duration(time.Minute), configuration.JobRetention)
+			assert.Equal(t, configApi.Duration(5*time.Second), configuration.Services[0].RetryInterval)
+			assert.Equal(t, configApi.Duration(90*time.Second), configuration.Services[0].MaxRetryInterval)
+			assert.Equal(t, configApi.Duration(time.Hour), configuration.Services[0].RestartStablePeriod)
+		})
+	}
+}
+
+// TestLoadConfigurationFromFile_InvalidDuration tests that malformed durations are rejected.
+func TestLoadConfigurationFromFile_InvalidDuration(t *testing.T) {
+	for fileName, content := range map[string]string{
+		"system.yaml": "jobRetention: soon",
+		"system.json": `{"jobRetention": "soon"}`,
+	} {
+		_, err := loadConfiguration(t, fileName, content)
+		assert.Error(t, err, fileName)
+	}
+}
+
+// TestDuration_MarshalJSON tests that durations are written as duration strings and read back.
+func TestDuration_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(&configApi.Configuration{JobRetention: configApi.Duration(90 * time.Second)})
+	assert.NoError(t, err)
+
+	var configuration configApi.Configuration
+	assert.NoError(t, json.Unmarshal(data, &configuration))
+	assert.Contains(t, string(data), `"jobRetention":"1m30s"`)
+	assert.Equal(t, configApi.Duration(90*time.Second), configuration.JobRetention)
+}
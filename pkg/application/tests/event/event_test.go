@@ -0,0 +1,152 @@
+package event_test
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/event"
+)
+
+// recordTo returns subscription parameters appending the data of the events of the topic to the given slice.
+func recordTo(topic string, received *[]interface{}) event.BusSubscriptionParams {
+	return event.BusSubscriptionParams{
+		Topic:        topic,
+		EventHandler: func(e event.Event) { *received = append(*received, e.Data) },
+	}
+}
+
+// TestSystemEventBus_MultipleHandlers tests that every handler subscribed to a topic receives its
+// events, and that unsubscribing removes the given handler only.
+func TestSystemEventBus_MultipleHandlers(t *testing.T) {
+	// Arrange
+	bus := event.NewSystemEventBus()
+	var first, second []interface{}
+	subscription1, err := bus.Subscribe(recordTo(event.EventTypeDataExtracted, &first))
+	assert.NoError(t, err)
+	subscription2, err := bus.Subscribe(recordTo(event.EventTypeDataExtracted, &second))
+	assert.NoError(t, err)
+
+	// Act
+	bus.Publish(event.Event{Type: event.EventTypeDataExtracted, Data: 1})
+	assert.NoError(t, bus.Unsubscribe(subscription1))
+	bus.Publish(event.Event{Type: event.EventTypeDataExtracted, Data: 2})
+
+	// Assert
+	assert.NotEqual(t, subscription1.ID(), subscription2.ID())
+	assert.Equal(t, event.EventTypeDataExtracted, subscription1.Topic())
+	assert.Equal(t, []interface{}{1}, first)
+	assert.Equal(t, []interface{}{1, 2}, second)
+	assert.True(t, bus.HasCallback(event.EventTypeDataExtracted))
+
+	assert.NoError(t, bus.Unsubscribe(subscription2))
+	assert.False(t, bus.HasCallback(event.EventTypeDataExtracted))
+	assert.True(t, errors.Is(bus.Unsubscribe(subscription2), event.ErrSubscriptionNotFound))
+}
+
+// TestSystemEventBus_SubscribeOnce tests that handlers subscribed once receive a single event.
+func TestSystemEventBus_SubscribeOnce(t *testing.T) {
+	// Arrange
+	bus := event.NewSystemEventBus()
+	var once, always []interface{}
+	subscription, err := bus.SubscribeOnce(recordTo(event.EventTypeDataLoaded, &once))
+	assert.NoError(t, err)
+	_, err = bus.Subscribe(recordTo(event.EventTypeDataLoaded, &always))
+	assert.NoError(t, err)
+
+	// Act
+	bus.Publish(event.Event{Type: event.EventTypeDataLoaded, Data: 1})
+	bus.Publish(event.Event{Type: event.EventTypeDataLoaded, Data: 2})
+
+	// Assert
+	assert.Equal(t, []interface{}{1}, once)
+	assert.Equal(t, []interface{}{1, 2}, always)
+	assert.True(t, errors.Is(bus.Unsubscribe(subscription), event.ErrSubscriptionNotFound))
+}
+
+// TestSystemEventBus_Async tests that asynchronous handlers are awaited by WaitAsync, and that
+// transactional handlers are called one event at a time.
+func TestSystemEventBus_Async(t *testing.T) {
+	// Arrange
+	bus := event.NewSystemEventBus()
+	var calls, once, active, overlaps int32
+	_, err := bus.SubscribeAsync(event.BusSubscriptionParams{
+		Topic: event.EventTypeDataTransformed,
+		EventHandler: func(e event.Event) {
+			if atomic.AddInt32(&active, 1) > 1 {
+				atomic.AddInt32(&overlaps, 1)
+			}
+			atomic.AddInt32(&calls, 1)
+			atomic.AddInt32(&active, -1)
+		},
+	}, true)
+	assert.NoError(t, err)
+	_, err = bus.SubscribeOnceAsync(event.BusSubscriptionParams{
+		Topic:        event.EventTypeDataTransformed,
+		EventHandler: func(e event.Event) { atomic.AddInt32(&once, 1) },
+	})
+	assert.NoError(t, err)
+
+	// Act
+	for i := 0; i < 50; i++ {
+		bus.Publish(event.Event{Type: event.EventTypeDataTransformed, Data: i})
+	}
+	bus.WaitAsync()
+
+	// Assert
+	assert.Equal(t, int32(50), atomic.LoadInt32(&calls))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&once))
+	assert.Zero(t, atomic.LoadInt32(&overlaps))
+}
+
+// TestSystemEventBus_Concurrent tests that handlers can be subscribed, unsubscribed and called concurrently,
+// including from within a handler.
+func TestSystemEventBus_Concurrent(t *testing.T) {
+	// Arrange
+	bus := event.NewSystemEventBus()
+	var received int32
+	var self *event.Subscription
+	self, err := bus.Subscribe(event.BusSubscriptionParams{
+		Topic: event.EventTypeDataExtracted,
+		EventHandler: func(e event.Event) {
+			// Unsubscribing from within a handler must not deadlock
+			_ = bus.Unsubscribe(self)
+		},
+	})
+	assert.NoError(t, err)
+
+	// Act
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			subscription, err := bus.Subscribe(event.BusSubscriptionParams{
+				Topic:        event.EventTypeDataExtracted,
+				EventHandler: func(e event.Event) { atomic.AddInt32(&received, 1) },
+			})
+			assert.NoError(t, err)
+			bus.Publish(event.Event{Type: event.EventTypeDataExtracted})
+			assert.NoError(t, bus.Unsubscribe(subscription))
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	assert.GreaterOrEqual(t, atomic.LoadInt32(&received), int32(20))
+	assert.False(t, bus.HasCallback(event.EventTypeDataExtracted))
+}
+
+// TestSystemEventBus_Error_InvalidSubscription tests that subscriptions require a topic and a handler.
+func TestSystemEventBus_Error_InvalidSubscription(t *testing.T) {
+	bus := event.NewSystemEventBus()
+
+	_, err := bus.Subscribe(event.BusSubscriptionParams{Topic: event.EventTypeDataLoaded})
+	assert.True(t, errors.Is(err, event.ErrInvalidSubscription))
+	_, err = bus.SubscribeAsync(event.BusSubscriptionParams{EventHandler: func(event.Event) {}}, false)
+	assert.True(t, errors.Is(err, event.ErrInvalidSubscription))
+	assert.True(t, errors.Is(bus.Unsubscribe(nil), event.ErrSubscriptionNotFound))
+}
@@ -0,0 +1,144 @@
+package event_test
+
+import (
+	"errors"
+	"testing"
+
+	"cosmossdk.io/log"
+	"github.com/cosmos/iavl"
+	dbm "github.com/cosmos/iavl/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/event"
+	"github.com/edward1christian/block-forge/pkg/application/db"
+	"github.com/edward1christian/block-forge/pkg/application/mocks"
+	"github.com/edward1christian/block-forge/pkg/application/store"
+)
+
+// extracted is the data of the events published by the tests.
+type extracted struct {
+	Block int `json:"block"`
+}
+
+// newLogMultiStore returns a MultiStore whose stores are IAVL trees over the given database, as if
+// the system was started with the database left by a previous run.
+func newLogMultiStore(t *testing.T, database dbm.DB) store.MultiStore {
+	logStore, err := store.NewStoreImpl("eventlog", "eventlog", db.NewIAVLDatabase(
+		iavl.NewMutableTree(database, 100, false, log.NewNopLogger())))
+	assert.NoError(t, err)
+	storeFactory := &mocks.MockStoreFactory{}
+	storeFactory.On("CreateStore", mock.Anything).Return(logStore, nil)
+	multiStore, err := store.NewMultiStore(&mocks.MockStore{}, storeFactory)
+	assert.NoError(t, err)
+	return multiStore
+}
+
+// newDurableEventBus returns a durable event bus logging in the given database.
+func newDurableEventBus(t *testing.T, database dbm.DB) *event.DurableEventBus {
+	bus, err := event.NewDurableEventBus(event.NewSystemEventBus(), newLogMultiStore(t, database), "")
+	assert.NoError(t, err)
+	return bus
+}
+
+// blocks returns the blocks of the given events.
+func blocks(t *testing.T, events []event.Event) []int {
+	var result []int
+	for _, e := range events {
+		var data extracted
+		assert.NoError(t, e.Decode(&data))
+		result = append(result, data.Block)
+	}
+	return result
+}
+
+// TestDurableEventBus_Publish tests that published events are logged with increasing sequence
+// numbers and delivered with them.
+func TestDurableEventBus_Publish(t *testing.T) {
+	// Arrange
+	bus := newDurableEventBus(t, dbm.NewMemDB())
+	var received []event.Event
+	_, err := bus.Subscribe(event.BusSubscriptionParams{
+		Topic:        event.EventTypeDataExtracted,
+		EventHandler: func(e event.Event) { received = append(received, e) },
+	})
+	assert.NoError(t, err)
+
+	// Act
+	bus.Publish(event.Event{Type: event.EventTypeDataExtracted, Data: extracted{Block: 1}})
+	bus.Publish(event.Event{Type: event.EventTypeDataLoaded, Data: extracted{Block: 1}})
+	bus.Publish(event.Event{Type: event.EventTypeDataExtracted, Data: extracted{Block: 2}})
+
+	// Assert
+	assert.Equal(t, uint64(3), bus.Sequence())
+	assert.Len(t, received, 2)
+	assert.Equal(t, uint64(1), received[0].Sequence)
+	assert.Equal(t, uint64(3), received[1].Sequence)
+	assert.False(t, received[1].Timestamp.Before(received[0].Timestamp))
+	assert.Equal(t, extracted{Block: 2}, received[1].Data, "live events keep their data")
+}
+
+// TestDurableEventBus_Replay tests that logged events are replayed after a restart, and that the
+// sequence numbers continue after the last logged event.
+func TestDurableEventBus_Replay(t *testing.T) {
+	// Arrange
+	database := dbm.NewMemDB()
+	bus := newDurableEventBus(t, database)
+	for block := 1; block <= 300; block++ {
+		bus.Publish(event.Event{Type: event.EventTypeDataExtracted, Data: extracted{Block: block}})
+	}
+	bus.Publish(event.Event{Type: event.EventTypeDataLoaded, Data: extracted{Block: 1}})
+
+	// Act
+	restarted := newDurableEventBus(t, database)
+	var all, extractedFrom []event.Event
+	assert.NoError(t, restarted.Replay("", 0, func(e event.Event) { all = append(all, e) }))
+	assert.NoError(t, restarted.Replay(event.EventTypeDataExtracted, 299, func(e event.Event) { extractedFrom = append(extractedFrom, e) }))
+	restarted.Publish(event.Event{Type: event.EventTypeDataLoaded, Data: extracted{Block: 2}})
+
+	// Assert
+	assert.Len(t, all, 301)
+	assert.Equal(t, uint64(301), all[300].Sequence)
+	assert.Equal(t, event.EventTypeDataLoaded, all[300].Type)
+	assert.Equal(t, []int{299, 300}, blocks(t, extractedFrom))
+	assert.Equal(t, uint64(302), restarted.Sequence())
+}
+
+// TestDurableEventBus_SubscribeFrom tests that subscribers resuming from an offset receive the logged
+// events followed by the live ones, once and in order, including events published during the replay.
+func TestDurableEventBus_SubscribeFrom(t *testing.T) {
+	// Arrange
+	bus := newDurableEventBus(t, dbm.NewMemDB())
+	for block := 1; block <= 3; block++ {
+		bus.Publish(event.Event{Type: event.EventTypeDataExtracted, Data: extracted{Block: block}})
+	}
+	var received []event.Event
+
+	// Act
+	subscription, err := bus.SubscribeFrom(event.BusSubscriptionParams{
+		Topic: event.EventTypeDataExtracted,
+		EventHandler: func(e event.Event) {
+			received = append(received, e)
+			if e.Sequence == 2 {
+				// Published while replaying
+				bus.Publish(event.Event{Type: event.EventTypeDataExtracted, Data: extracted{Block: 4}})
+			}
+		},
+	}, 2)
+	assert.NoError(t, err)
+	bus.Publish(event.Event{Type: event.EventTypeDataExtracted, Data: extracted{Block: 5}})
+
+	// Assert
+	assert.Equal(t, []int{2, 3, 4, 5}, blocks(t, received))
+	assert.NoError(t, bus.Unsubscribe(subscription))
+	assert.False(t, bus.HasCallback(event.EventTypeDataExtracted))
+}
+
+// TestNewDurableEventBus_Error tests that the event log requires a MultiStore.
+func TestNewDurableEventBus_Error(t *testing.T) {
+	_, err := event.NewDurableEventBus(event.NewSystemEventBus(), nil, "")
+	assert.True(t, errors.Is(err, event.ErrEventLogUnavailable))
+
+	_, err = event.NewDurableEventBus(event.NewSystemEventBus(), newLogMultiStore(t, dbm.NewMemDB()), "event-log")
+	assert.True(t, errors.Is(err, event.ErrEventLogUnavailable), "store names are alphanumeric")
+}
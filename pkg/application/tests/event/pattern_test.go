@@ -0,0 +1,111 @@
+package event_test
+
+import (
+	"errors"
+	"testing"
+
+	dbm "github.com/cosmos/iavl/db"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/event"
+)
+
+func TestMatchTopic(t *testing.T) {
+	tests := []struct {
+		pattern string
+		topic   string
+		match   bool
+	}{
+		{"etl.process.*", "etl.process.extracted", true},
+		{"etl.process.*", "etl.process", false},
+		{"etl.process.*", "etl.process.extracted.block", false},
+		{"etl.*.loaded", "etl.process.loaded", true},
+		{"nova.project.#", "nova.project", true},
+		{"nova.project.#", "nova.project.module.added", true},
+		{"nova.project.#", "nova.projects.created", false},
+		{"#", "system.service.failed", true},
+		{"#.failed", "system.service.failed", true},
+		{"system.#.failed", "system.failed", true},
+		{"system.#.failed", "system.service.restarted", false},
+		{"etl.process.extracted", "etl.process.extracted", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.topic, func(t *testing.T) {
+			assert.Equal(t, tt.match, event.MatchTopic(tt.pattern, tt.topic))
+		})
+	}
+}
+
+// TestSystemEventBus_SubscribePattern tests that pattern subscriptions receive the events of every
+// matching topic, in subscription order with the topic subscriptions.
+func TestSystemEventBus_SubscribePattern(t *testing.T) {
+	// Arrange
+	bus := event.NewSystemEventBus()
+	var received []string
+	record := func(name string) event.EventHandler {
+		return func(e event.Event) { received = append(received, name+" "+e.Type) }
+	}
+	process, err := bus.SubscribePattern(event.BusSubscriptionParams{Topic: "etl.process.*", EventHandler: record("process")})
+	assert.NoError(t, err)
+	_, err = bus.Subscribe(event.BusSubscriptionParams{Topic: event.EventTypeDataLoaded, EventHandler: record("loaded")})
+	assert.NoError(t, err)
+	_, err = bus.SubscribePattern(event.BusSubscriptionParams{Topic: event.NamespaceETL + ".#", EventHandler: record("etl")})
+	assert.NoError(t, err)
+
+	// Act
+	assert.NoError(t, bus.Publish(event.Event{Type: event.EventTypeDataExtracted}))
+	assert.NoError(t, bus.Publish(event.Event{Type: event.EventTypeDataLoaded}))
+	assert.NoError(t, bus.Unsubscribe(process))
+	assert.NoError(t, bus.Publish(event.Event{Type: event.EventTypeDataTransformed}))
+	assert.NoError(t, bus.Publish(event.Event{Type: "system.service.failed"}))
+
+	// Assert
+	assert.Equal(t, []string{
+		"process etl.process.extracted",
+		"etl etl.process.extracted",
+		"process etl.process.loaded",
+		"loaded etl.process.loaded",
+		"etl etl.process.loaded",
+		"etl etl.process.transformed",
+	}, received)
+	assert.True(t, bus.HasCallback(event.EventTypeDataTransformed))
+	assert.False(t, bus.HasCallback("system.service.failed"))
+	assert.True(t, errors.Is(bus.Unsubscribe(process), event.ErrSubscriptionNotFound))
+}
+
+// TestSystemEventBus_SubscribePattern_Error tests that patterns must be made of whole segments,
+// and that events cannot be published on a pattern.
+func TestSystemEventBus_SubscribePattern_Error(t *testing.T) {
+	bus := event.NewSystemEventBus()
+	handler := func(event.Event) {}
+
+	for _, pattern := range []string{"etl.proc*", "etl..loaded", "etl.#."} {
+		_, err := bus.SubscribePattern(event.BusSubscriptionParams{Topic: pattern, EventHandler: handler})
+		assert.True(t, errors.Is(err, event.ErrInvalidSubscription), pattern)
+	}
+	assert.True(t, errors.Is(bus.Publish(event.Event{Type: "etl.process.*"}), event.ErrInvalidTopic))
+}
+
+// TestDurableEventBus_SubscribeFrom_Pattern tests that subscribers resuming on a pattern replay
+// the logged events of every matching topic.
+func TestDurableEventBus_SubscribeFrom_Pattern(t *testing.T) {
+	// Arrange
+	bus := newDurableEventBus(t, dbm.NewMemDB())
+	assert.NoError(t, bus.Publish(event.Event{Type: event.EventTypeDataExtracted, Data: extracted{Block: 1}}))
+	assert.NoError(t, bus.Publish(event.Event{Type: "system.service.failed"}))
+	assert.NoError(t, bus.Publish(event.Event{Type: event.EventTypeDataLoaded, Data: extracted{Block: 1}}))
+	var received []string
+
+	// Act
+	_, err := bus.SubscribeFrom(event.BusSubscriptionParams{
+		Topic:        "etl.process.*",
+		EventHandler: func(e event.Event) { received = append(received, e.Type) },
+	}, 0)
+	assert.NoError(t, err)
+	assert.NoError(t, bus.Publish(event.Event{Type: event.EventTypeDataTransformed, Data: extracted{Block: 2}}))
+
+	// Assert
+	assert.Equal(t, []string{event.EventTypeDataExtracted, event.EventTypeDataLoaded, event.EventTypeDataTransformed}, received)
+	assert.True(t, errors.Is(bus.Publish(event.Event{Type: "etl.#"}), event.ErrInvalidTopic))
+}
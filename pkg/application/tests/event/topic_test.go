@@ -0,0 +1,134 @@
+package event_test
+
+import (
+	"errors"
+	"testing"
+
+	dbm "github.com/cosmos/iavl/db"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/event"
+	"github.com/edward1christian/block-forge/pkg/application/component"
+	configApi "github.com/edward1christian/block-forge/pkg/application/config"
+	"github.com/edward1christian/block-forge/pkg/application/mocks"
+	"github.com/edward1christian/block-forge/pkg/application/system"
+)
+
+// extractedTopic is the typed topic of the events published by the tests.
+var extractedTopic = event.NewTopic[extracted](event.EventTypeDataExtracted)
+
+// TestTypedEvents_PublishSubscribe tests that typed handlers receive the payloads of a registered topic.
+func TestTypedEvents_PublishSubscribe(t *testing.T) {
+	// Arrange
+	bus := event.NewSystemEventBus()
+	assert.NoError(t, event.RegisterTopic(bus.Topics(), extractedTopic, nil))
+	var received []extracted
+	_, err := event.Subscribe(bus, extractedTopic, func(payload extracted, e event.Event) {
+		received = append(received, payload)
+	})
+	assert.NoError(t, err)
+
+	// Act
+	err = event.Publish(bus, extractedTopic, extracted{Block: 1})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []extracted{{Block: 1}}, received)
+}
+
+// TestTypedEvents_Error_Mismatch tests that events whose payload does not have the type of their
+// topic are rejected, and that topics cannot be registered with another payload type.
+func TestTypedEvents_Error_Mismatch(t *testing.T) {
+	// Arrange
+	bus := event.NewSystemEventBus()
+	assert.NoError(t, event.RegisterTopic(bus.Topics(), extractedTopic, nil))
+	assert.NoError(t, event.RegisterTopic(bus.Topics(), extractedTopic, nil), "registering the same type again is a no-op")
+	received := 0
+	_, err := bus.Subscribe(event.BusSubscriptionParams{
+		Topic:        event.EventTypeDataExtracted,
+		EventHandler: func(e event.Event) { received++ },
+	})
+	assert.NoError(t, err)
+
+	// Act
+	errPublish := bus.Publish(event.Event{Type: event.EventTypeDataExtracted, Data: "block 1"})
+	errTyped := event.Publish(bus, event.NewTopic[string](event.EventTypeDataExtracted), "block 1")
+	errRegister := event.RegisterTopic(bus.Topics(), event.NewTopic[int](event.EventTypeDataExtracted), nil)
+	errUnregistered := event.Publish(bus, event.NewTopic[int](event.EventTypeDataLoaded), 1)
+
+	// Assert
+	assert.True(t, errors.Is(errPublish, event.ErrPayloadTypeMismatch))
+	assert.True(t, errors.Is(errTyped, event.ErrPayloadTypeMismatch))
+	assert.True(t, errors.Is(errRegister, event.ErrTopicAlreadyRegistered))
+	assert.True(t, errors.Is(errUnregistered, event.ErrTopicNotRegistered))
+	assert.Zero(t, received)
+	assert.NoError(t, bus.Publish(event.Event{Type: event.EventTypeDataLoaded, Data: "untyped"}), "unregistered topics accept any payload")
+}
+
+// TestTopicRegistry_Codecs tests that payloads are serialized with the codec of their topic.
+func TestTopicRegistry_Codecs(t *testing.T) {
+	// Arrange
+	registry := event.NewTopicRegistry()
+	loadedTopic := event.NewTopic[*wrapperspb.StringValue](event.EventTypeDataLoaded)
+	assert.NoError(t, event.RegisterTopic(registry, extractedTopic, event.JSONCodec{}))
+	assert.NoError(t, event.RegisterTopic(registry, loadedTopic, event.ProtoCodec{}))
+
+	// Act
+	jsonData, errJSON := registry.Marshal(event.Event{Type: extractedTopic.Name(), Data: extracted{Block: 7}})
+	protoData, errProto := registry.Marshal(event.Event{Type: loadedTopic.Name(), Data: wrapperspb.String("tx")})
+
+	// Assert
+	assert.NoError(t, errJSON)
+	assert.JSONEq(t, `{"block": 7}`, string(jsonData))
+	decoded, err := registry.Unmarshal(extractedTopic.Name(), jsonData)
+	assert.NoError(t, err)
+	assert.Equal(t, extracted{Block: 7}, decoded.Data)
+
+	assert.NoError(t, errProto)
+	decoded, err = registry.Unmarshal(loadedTopic.Name(), protoData)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(wrapperspb.String("tx"), decoded.Data.(*wrapperspb.StringValue)))
+
+	assert.Equal(t, []string{event.EventTypeDataExtracted, event.EventTypeDataLoaded}, registry.Topics())
+	err = event.RegisterTopic(registry, event.NewTopic[extracted](event.EventTypeDataTransformed), event.ProtoCodec{})
+	assert.True(t, errors.Is(err, event.ErrInvalidPayload))
+	_, err = registry.Marshal(event.Event{Type: event.EventTypeDataTransformed})
+	assert.True(t, errors.Is(err, event.ErrTopicNotRegistered))
+}
+
+// TestTypedEvents_Replay tests that typed handlers receive the decoded payloads of replayed events.
+func TestTypedEvents_Replay(t *testing.T) {
+	// Arrange
+	bus := newDurableEventBus(t, dbm.NewMemDB())
+	assert.NoError(t, event.RegisterTopic(bus.Topics(), extractedTopic, nil))
+	assert.NoError(t, event.Publish[extracted](bus, extractedTopic, extracted{Block: 1}))
+	var received []extracted
+
+	// Act
+	_, err := bus.SubscribeFrom(event.BusSubscriptionParams{
+		Topic: extractedTopic.Name(),
+		EventHandler: event.TypedHandler(func(payload extracted, e event.Event) {
+			received = append(received, payload)
+		}),
+	}, 0)
+	assert.NoError(t, err)
+	assert.NoError(t, event.Publish[extracted](bus, extractedTopic, extracted{Block: 2}))
+
+	// Assert
+	assert.Equal(t, []extracted{{Block: 1}, {Block: 2}}, received)
+}
+
+// TestNewSystem_RegistersTopics tests that the system registers the payload types of its events.
+func TestNewSystem_RegistersTopics(t *testing.T) {
+	bus := event.NewSystemEventBus()
+	system.NewSystem(&mocks.MockLogger{}, bus, &configApi.Configuration{}, nil, component.NewComponentRegistrar(), nil)
+
+	payload, ok := bus.Topics().PayloadType(component.EventTypeComponentCreated)
+	assert.True(t, ok)
+	assert.Equal(t, "RegistryEvent", payload.Name())
+	assert.Contains(t, bus.Topics().Topics(), system.EventTypeScheduledOperationFailed)
+	err := bus.Publish(event.Event{Type: system.EventTypeServiceFailed, Data: "service"})
+	assert.True(t, errors.Is(err, event.ErrPayloadTypeMismatch))
+}
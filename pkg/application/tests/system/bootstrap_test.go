@@ -0,0 +1,199 @@
+package system_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	"github.com/edward1christian/block-forge/pkg/application/common/event"
+	"github.com/edward1christian/block-forge/pkg/application/component"
+	configApi "github.com/edward1christian/block-forge/pkg/application/config"
+	"github.com/edward1christian/block-forge/pkg/application/mocks"
+	systemApi "github.com/edward1christian/block-forge/pkg/application/system"
+)
+
+// bootstrapConfiguration is a system configuration declaring an operation and two services.
+const bootstrapConfiguration = `
+operations:
+  - id: echo
+    factoryId: operationFactory
+services:
+  - id: store
+    factoryId: serviceFactory
+    customConfig:
+      path: /data
+  - id: indexer
+    factoryId: serviceFactory
+    dependsOn: [store]
+    autoStart: false
+`
+
+// bootstrapFactory creates the components of the bootstrap tests from their configuration.
+type bootstrapFactory struct {
+	events  *[]string
+	configs []*configApi.ComponentConfig
+}
+
+// CreateComponent creates an operation or a service depending on the factory.
+func (f *bootstrapFactory) CreateComponent(config *configApi.ComponentConfig) (component.ComponentInterface, error) {
+	f.configs = append(f.configs, config)
+	if f.events == nil {
+		return echoOperation(config.ID, &[]string{}), nil
+	}
+	return newOrderedService(config.ID, f.events), nil
+}
+
+// newBootstrapSystem creates a system loading the given configuration file content.
+func newBootstrapSystem(t *testing.T, fileName, content string, events *[]string) (*systemApi.SystemImpl, *bootstrapFactory) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), fileName)
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+	configuration := &configApi.Configuration{}
+	assert.NoError(t, configApi.LoadConfigurationFromFile(path, configuration))
+
+	registrar := component.NewComponentRegistrar()
+	serviceFactory := &bootstrapFactory{events: events}
+	assert.NoError(t, registrar.RegisterFactory(ctx, "operationFactory", &bootstrapFactory{}))
+	assert.NoError(t, registrar.RegisterFactory(ctx, "serviceFactory", serviceFactory))
+
+	pluginManager := &mocks.MockPluginManager{}
+	pluginManager.On("Initialize", ctx, mock.Anything).Return(nil)
+	pluginManager.On("StartPlugins", ctx).Return(nil)
+	pluginManager.On("StopPlugins", mock.Anything).Return(nil)
+
+	return systemApi.NewSystem(&mocks.MockLogger{}, nil, configuration, pluginManager, registrar, nil), serviceFactory
+}
+
+// TestSystemImpl_Initialize_BootstrapsComponents tests that the components declared in the
+// configuration are created, initialized and started with the system unless disabled.
+func TestSystemImpl_Initialize_BootstrapsComponents(t *testing.T) {
+	ctx := context.Background()
+	var events []string
+	sys, factory := newBootstrapSystem(t, "system.yaml", bootstrapConfiguration, &events)
+
+	assert.NoError(t, sys.Initialize(ctx))
+	assert.Equal(t, map[string]interface{}{"path": "/data"}, factory.configs[0].CustomConfig)
+
+	output, err := sys.ExecuteOperation(ctx, "echo", &systemApi.SystemOperationInput{Data: "nova"})
+	assert.NoError(t, err)
+	assert.Equal(t, "nova", output.Data)
+
+	comp, err := sys.ComponentRegistry().GetComponent("indexer")
+	assert.NoError(t, err)
+	assert.Equal(t, sys, comp.(*orderedService).System)
+
+	assert.NoError(t, sys.Start(ctx))
+	assert.Equal(t, []string{"start:store"}, events)
+
+	assert.NoError(t, sys.StartService(ctx, "indexer"))
+	assert.Equal(t, []string{"start:store", "start:indexer"}, events)
+}
+
+// TestSystemImpl_Initialize_BootstrapsFromJSON tests that JSON configuration files are supported
+// and that the service custom configuration is passed to the factory.
+func TestSystemImpl_Initialize_BootstrapsFromJSON(t *testing.T) {
+	var events []string
+	sys, factory := newBootstrapSystem(t, "system.json",
+		`{"services": [{"id": "store", "factoryId": "serviceFactory", "customConfig": {"path": "/data"}}]}`, &events)
+
+	assert.NoError(t, sys.Initialize(context.Background()))
+
+	assert.Len(t, factory.configs, 1)
+	assert.Equal(t, map[string]interface{}{"path": "/data"}, factory.configs[0].CustomConfig)
+}
+
+// TestSystemImpl_Initialize_Twice tests that initializing the system again keeps the bootstrapped components.
+func TestSystemImpl_Initialize_Twice(t *testing.T) {
+	ctx := context.Background()
+	var events []string
+	sys, factory := newBootstrapSystem(t, "system.yml", bootstrapConfiguration, &events)
+
+	assert.NoError(t, sys.Initialize(ctx))
+	assert.NoError(t, sys.Initialize(ctx))
+	assert.Len(t, factory.configs, 2)
+}
+
+// TestSystemImpl_Initialize_FactoryNotFound tests that a component referring to an unknown factory
+// fails the initialization.
+func TestSystemImpl_Initialize_FactoryNotFound(t *testing.T) {
+	sys, _ := newBootstrapSystem(t, "system.json",
+		`{"operations": [{"id": "build", "factoryId": "buildFactory"}]}`, nil)
+
+	err := sys.Initialize(context.Background())
+
+	assert.True(t, errors.Is(err, systemApi.ErrFactoryNotFound))
+	assert.Contains(t, err.Error(), "build")
+	assert.ErrorIs(t, sys.Start(context.Background()), systemApi.ErrSystemNotInitialized)
+}
+
+// TestSystemImpl_Initialize_ComponentConflict tests that a declared component conflicting with
+// a component created from another factory fails the initialization.
+func TestSystemImpl_Initialize_ComponentConflict(t *testing.T) {
+	ctx := context.Background()
+	var events []string
+	sys, _ := newBootstrapSystem(t, "system.yaml", bootstrapConfiguration, &events)
+	_, err := sys.ComponentRegistry().CreateComponent(ctx, &configApi.ComponentConfig{ID: "echo", FactoryID: "serviceFactory"})
+	assert.NoError(t, err)
+
+	err = sys.Initialize(ctx)
+
+	assert.True(t, errors.Is(err, systemApi.ErrComponentAlreadyExist))
+}
+
+// TestSystemImpl_Initialize_ComponentInitError tests that a declared component failing to
+// initialize is removed, so that the next initialization creates it again.
+func TestSystemImpl_Initialize_ComponentInitError(t *testing.T) {
+	ctx := context.Background()
+	var events []string
+	failing := newOrderedService("store", &events)
+	failing.initErr = errors.New("disk unavailable")
+	factory := &orderedServiceFactory{services: map[string]component.ComponentInterface{"store": failing}}
+	sys, _ := newBootstrapSystem(t, "system.json", `{"services": [{"id": "store", "factoryId": "storeFactory"}]}`, &events)
+	assert.NoError(t, sys.ComponentRegistry().RegisterFactory(ctx, "storeFactory", factory))
+
+	err := sys.Initialize(ctx)
+
+	assert.ErrorIs(t, err, failing.initErr)
+	_, err = sys.ComponentRegistry().GetComponent("store")
+	assert.True(t, errors.Is(err, component.ErrComponentNotFound))
+	assert.ErrorIs(t, sys.Initialize(ctx), failing.initErr, "the component must be initialized again")
+
+	failing.initErr = nil
+	assert.NoError(t, sys.Initialize(ctx))
+	assert.NoError(t, sys.Start(ctx))
+	assert.Equal(t, []string{"start:store"}, events, "a component that was never started must not be stopped")
+}
+
+// TestNewSystem_PublishesRegistryEvents tests that the components bootstrapped by the system
+// are published on the system event bus.
+func TestNewSystem_PublishesRegistryEvents(t *testing.T) {
+	ctx := context.Background()
+	var created []string
+	bus := event.NewSystemEventBus()
+	_, err := bus.Subscribe(event.BusSubscriptionParams{
+		Topic: component.EventTypeComponentCreated,
+		EventHandler: func(e event.Event) {
+			created = append(created, e.Data.(component.RegistryEvent).ComponentID)
+		},
+	})
+	assert.NoError(t, err)
+
+	registrar := component.NewComponentRegistrar()
+	assert.NoError(t, registrar.RegisterFactory(ctx, "operationFactory", &bootstrapFactory{}))
+	pluginManager := &mocks.MockPluginManager{}
+	pluginManager.On("Initialize", ctx, mock.Anything).Return(nil)
+	configuration := &configApi.Configuration{Operations: []*configApi.OperationConfiguration{
+		{ComponentConfig: configApi.ComponentConfig{ID: "echo", FactoryID: "operationFactory"}},
+	}}
+	sys := systemApi.NewSystem(&mocks.MockLogger{}, bus, configuration, pluginManager, registrar, nil)
+
+	assert.NoError(t, sys.Initialize(ctx))
+
+	assert.Equal(t, []string{"echo"}, created)
+}
@@ -0,0 +1,83 @@
+package system_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	"github.com/edward1christian/block-forge/pkg/application/component"
+	configApi "github.com/edward1christian/block-forge/pkg/application/config"
+	"github.com/edward1christian/block-forge/pkg/application/mocks"
+	systemApi "github.com/edward1christian/block-forge/pkg/application/system"
+)
+
+// TestSystemImpl_Catalog tests that the catalog lists operations and services with their owner.
+func TestSystemImpl_Catalog(t *testing.T) {
+	ctx := context.Background()
+	var events []string
+	registrar := component.NewComponentRegistrar()
+	factory := &orderedServiceFactory{services: map[string]component.ComponentInterface{
+		"build": newSchemaOperation(nil),
+		"store": newOrderedService("store", &events),
+		"plain": &component.BaseComponent{Id: "plain"},
+	}}
+	assert.NoError(t, registrar.RegisterFactory(ctx, "orderedFactory", factory))
+
+	for _, id := range []string{"store", "build", "plain"} {
+		createCtx := ctx
+		var labels map[string]string
+		if id == "build" {
+			createCtx = component.WithOwner(ctx, "builder")
+			labels = map[string]string{"role": "command"}
+		}
+		_, err := registrar.CreateComponent(createCtx, &configApi.ComponentConfig{ID: id, FactoryID: "orderedFactory", Labels: labels})
+		assert.NoError(t, err)
+	}
+
+	pluginManager := &mocks.MockPluginManager{}
+	pluginManager.On("Initialize", ctx, mock.Anything).Return(nil)
+	sys := systemApi.NewSystem(&mocks.MockLogger{}, nil, &configApi.Configuration{}, pluginManager, registrar, nil)
+	assert.NoError(t, sys.Initialize(ctx))
+
+	catalog := sys.Catalog()
+
+	assert.Len(t, catalog, 2)
+	assert.Equal(t, "build", catalog[0].ID)
+	assert.Equal(t, systemApi.CatalogOperationKind, catalog[0].Kind)
+	assert.Equal(t, "builder", catalog[0].Owner)
+	assert.Equal(t, "orderedFactory", catalog[0].FactoryID)
+	assert.Equal(t, "*system_test.buildRequest", catalog[0].InputSchema.TypeName())
+	assert.Equal(t, "store", catalog[1].ID)
+	assert.Equal(t, systemApi.CatalogServiceKind, catalog[1].Kind)
+	assert.Empty(t, catalog[1].Owner)
+	assert.Nil(t, catalog[1].InputSchema)
+
+	selector, err := component.ParseSelector("role=command")
+	assert.NoError(t, err)
+	commands := systemApi.FilterCatalog(catalog, selector)
+	assert.Len(t, commands, 1)
+	assert.Equal(t, map[string]string{"role": "command"}, commands[0].Labels)
+}
+
+// TestWriteCatalog tests that catalog entries are written as a table.
+func TestWriteCatalog(t *testing.T) {
+	var buf bytes.Buffer
+	err := systemApi.WriteCatalog(&buf, []*systemApi.CatalogEntry{
+		{ID: "build", Kind: systemApi.CatalogOperationKind, Owner: "builder", FactoryID: "buildFactory",
+			InputSchema: systemApi.NewOperationSchema[string](""), Description: "Builds a project",
+			Labels: map[string]string{"role": "command", "plugin": "builder"}},
+		{ID: "store", Kind: systemApi.CatalogServiceKind},
+	})
+
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 3)
+	assert.Equal(t, []string{"ID", "KIND", "OWNER", "FACTORY", "INPUT", "LABELS", "DESCRIPTION"}, strings.Fields(lines[0]))
+	assert.Equal(t, []string{"build", "operation", "builder", "buildFactory", "string", "plugin=builder,role=command",
+		"Builds", "a", "project"}, strings.Fields(lines[1]))
+	assert.Equal(t, []string{"store", "service", "-", "-", "-", "-"}, strings.Fields(lines[2]))
+}
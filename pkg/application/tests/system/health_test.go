@@ -0,0 +1,119 @@
+package system_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	"github.com/edward1christian/block-forge/pkg/application/component"
+	configApi "github.com/edward1christian/block-forge/pkg/application/config"
+	systemApi "github.com/edward1christian/block-forge/pkg/application/system"
+)
+
+// reportingService is a service that reports a fixed health status.
+type reportingService struct {
+	orderedService
+	status component.HealthStatus
+}
+
+// Health returns the configured health status.
+func (s *reportingService) Health(ctx *context.Context) component.HealthStatus {
+	return s.status
+}
+
+// TestSystemImpl_Health_NotStarted tests that a system that is not started is unhealthy.
+func TestSystemImpl_Health_NotStarted(t *testing.T) {
+	var events []string
+	sys := newOrderedSystem(t, &configApi.Configuration{}, newOrderedService("a", &events))
+
+	health := sys.Health(context.Background())
+
+	assert.Equal(t, systemApi.SystemUnhealthyType, health.Status)
+	assert.Equal(t, systemApi.SystemInitializedType, health.SystemStatus)
+	service, ok := health.Service("a")
+	assert.True(t, ok)
+	assert.False(t, service.Live)
+}
+
+// TestSystemImpl_Health_Healthy tests that a started system with started services is healthy.
+func TestSystemImpl_Health_Healthy(t *testing.T) {
+	ctx := context.Background()
+	var events []string
+	sys := newOrderedSystem(t, &configApi.Configuration{},
+		newOrderedService("a", &events),
+		newOrderedService("b", &events))
+	assert.NoError(t, sys.Start(ctx))
+
+	health := sys.Health(ctx)
+
+	assert.Equal(t, systemApi.SystemHealthyType, health.Status)
+	assert.Len(t, health.Services, 2)
+	assert.Equal(t, "a", health.Services[0].ID)
+	assert.True(t, health.Services[0].Ready)
+}
+
+// TestSystemImpl_Health_Degraded tests that a service that is live but not ready degrades the system.
+func TestSystemImpl_Health_Degraded(t *testing.T) {
+	ctx := context.Background()
+	var events []string
+	degraded := &reportingService{
+		orderedService: *newOrderedService("manager", &events),
+		status:         component.HealthStatus{Live: true, Reason: "process failed"},
+	}
+	sys := newOrderedSystem(t, &configApi.Configuration{}, newOrderedService("a", &events), degraded)
+	assert.NoError(t, sys.Start(ctx))
+
+	health := sys.Health(ctx)
+
+	assert.Equal(t, systemApi.SystemDegradedType, health.Status)
+	service, ok := health.Service("manager")
+	assert.True(t, ok)
+	assert.Equal(t, "process failed", service.Reason)
+}
+
+// TestSystemImpl_Health_Unhealthy tests that a service that is not live makes the system unhealthy.
+func TestSystemImpl_Health_Unhealthy(t *testing.T) {
+	ctx := context.Background()
+	var events []string
+	down := &reportingService{
+		orderedService: *newOrderedService("manager", &events),
+		status:         component.HealthStatus{Reason: "crashed"},
+	}
+	sys := newOrderedSystem(t, &configApi.Configuration{}, down)
+	assert.NoError(t, sys.Start(ctx))
+
+	assert.Equal(t, systemApi.SystemUnhealthyType, sys.Health(ctx).Status)
+	assert.Equal(t, "unhealthy", sys.Health(ctx).Status.String())
+}
+
+// TestSystemImpl_Health_ManualService tests that a service configured not to start with the system
+// is not required until it is started with StartService, and is then live.
+func TestSystemImpl_Health_ManualService(t *testing.T) {
+	ctx := context.Background()
+	var events []string
+	autoStart := false
+	sys := newOrderedSystem(t, &configApi.Configuration{
+		Services: []*configApi.ServiceConfiguration{{ComponentConfig: configApi.ComponentConfig{ID: "manual"}, AutoStart: &autoStart}},
+	}, newOrderedService("a", &events), newOrderedService("manual", &events))
+	assert.NoError(t, sys.Start(ctx))
+
+	health := sys.Health(ctx)
+
+	assert.Equal(t, systemApi.SystemHealthyType, health.Status, "a service that is not auto-started must not be required")
+	service, ok := health.Service("manual")
+	assert.True(t, ok)
+	assert.False(t, service.Live)
+	assert.False(t, service.Required)
+
+	assert.NoError(t, sys.StartService(ctx, "manual"))
+	health = sys.Health(ctx)
+	assert.Equal(t, systemApi.SystemHealthyType, health.Status)
+	service, _ = health.Service("manual")
+	assert.True(t, service.Live, "a service started with StartService must be live")
+	assert.True(t, service.Required)
+
+	assert.NoError(t, sys.StopService(ctx, "manual"))
+	service, _ = sys.Health(ctx).Service("manual")
+	assert.False(t, service.Live)
+}
@@ -0,0 +1,146 @@
+package system_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	configApi "github.com/edward1christian/block-forge/pkg/application/config"
+	"github.com/edward1christian/block-forge/pkg/application/mocks"
+	systemApi "github.com/edward1christian/block-forge/pkg/application/system"
+)
+
+// funcOperation is an operation executing the given function.
+type funcOperation struct {
+	systemApi.BaseSystemOperation
+	fn func(input *systemApi.SystemOperationInput) (*systemApi.SystemOperationOutput, error)
+}
+
+// newFuncOperation creates a new funcOperation.
+func newFuncOperation(id string, fn func(input *systemApi.SystemOperationInput) (*systemApi.SystemOperationOutput, error)) *funcOperation {
+	return &funcOperation{
+		BaseSystemOperation: *systemApi.NewBaseSystemOperation(id, id, ""),
+		fn:                  fn,
+	}
+}
+
+// Execute executes the operation function.
+func (o *funcOperation) Execute(ctx *context.Context, input *systemApi.SystemOperationInput) (*systemApi.SystemOperationOutput, error) {
+	return o.fn(input)
+}
+
+// echoOperation returns an operation returning its input data.
+func echoOperation(id string, calls *[]string) *funcOperation {
+	return newFuncOperation(id, func(input *systemApi.SystemOperationInput) (*systemApi.SystemOperationOutput, error) {
+		*calls = append(*calls, "execute")
+		return &systemApi.SystemOperationOutput{Data: input.Data}, nil
+	})
+}
+
+// recordingInterceptor returns an interceptor recording the calls around the operation.
+func recordingInterceptor(name string, calls *[]string) systemApi.OperationInterceptor {
+	return func(
+		ctx *context.Context,
+		operation systemApi.SystemOperationInterface,
+		input *systemApi.SystemOperationInput,
+		next systemApi.OperationInvoker) (*systemApi.SystemOperationOutput, error) {
+		*calls = append(*calls, "before:"+name)
+		output, err := next(ctx, operation, input)
+		*calls = append(*calls, "after:"+name)
+		return output, err
+	}
+}
+
+// TestSystemImpl_ExecuteOperation_Interceptors tests that interceptors wrap the operation in registration order.
+func TestSystemImpl_ExecuteOperation_Interceptors(t *testing.T) {
+	var calls []string
+	sys := newOrderedSystem(t, &configApi.Configuration{}, echoOperation("echo", &calls))
+	sys.AddOperationInterceptor(recordingInterceptor("outer", &calls))
+	sys.AddOperationInterceptor(recordingInterceptor("inner", &calls))
+
+	output, err := sys.ExecuteOperation(context.Background(), "echo", &systemApi.SystemOperationInput{Data: "data"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "data", output.Data)
+	assert.Equal(t, []string{"before:outer", "before:inner", "execute", "after:inner", "after:outer"}, calls)
+}
+
+// TestBeforeOperation tests that a failing before hook prevents the operation from executing.
+func TestBeforeOperation(t *testing.T) {
+	var calls []string
+	denied := errors.New("not authorized")
+	sys := newOrderedSystem(t, &configApi.Configuration{}, echoOperation("echo", &calls))
+	sys.AddOperationInterceptor(systemApi.BeforeOperation(
+		func(ctx *context.Context, operation systemApi.SystemOperationInterface, input *systemApi.SystemOperationInput) error {
+			return denied
+		}))
+
+	output, err := sys.ExecuteOperation(context.Background(), "echo", &systemApi.SystemOperationInput{})
+
+	assert.Equal(t, denied, err)
+	assert.Nil(t, output)
+	assert.Empty(t, calls)
+}
+
+// TestAfterOperation tests that an after hook can replace the output of the operation.
+func TestAfterOperation(t *testing.T) {
+	var calls []string
+	sys := newOrderedSystem(t, &configApi.Configuration{}, echoOperation("echo", &calls))
+	sys.AddOperationInterceptor(systemApi.AfterOperation(
+		func(ctx *context.Context,
+			operation systemApi.SystemOperationInterface,
+			input *systemApi.SystemOperationInput,
+			output *systemApi.SystemOperationOutput,
+			err error) (*systemApi.SystemOperationOutput, error) {
+			return &systemApi.SystemOperationOutput{Data: operation.ID() + ":" + output.Data.(string)}, err
+		}))
+
+	output, err := sys.ExecuteOperation(context.Background(), "echo", &systemApi.SystemOperationInput{Data: "data"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "echo:data", output.Data)
+}
+
+// TestRecoveryInterceptor tests that panics raised by operations are converted into errors.
+func TestRecoveryInterceptor(t *testing.T) {
+	panicking := newFuncOperation("panicking", func(input *systemApi.SystemOperationInput) (*systemApi.SystemOperationOutput, error) {
+		panic("boom")
+	})
+	sys := newOrderedSystem(t, &configApi.Configuration{}, panicking)
+	sys.AddOperationInterceptor(systemApi.RecoveryInterceptor())
+
+	output, err := sys.ExecuteOperation(context.Background(), "panicking", &systemApi.SystemOperationInput{})
+
+	assert.True(t, errors.Is(err, systemApi.ErrOperationPanicked))
+	assert.Contains(t, err.Error(), "boom")
+	assert.Nil(t, output)
+}
+
+// TestTimingInterceptor tests that the duration of operations is logged.
+func TestTimingInterceptor(t *testing.T) {
+	var calls []string
+	log := &mocks.MockLogger{}
+	sys := newOrderedSystem(t, &configApi.Configuration{}, echoOperation("echo", &calls))
+	sys.AddOperationInterceptor(systemApi.TimingInterceptor(log))
+
+	_, err := sys.ExecuteOperation(context.Background(), "echo", &systemApi.SystemOperationInput{})
+
+	assert.NoError(t, err)
+	assert.Contains(t, log.LastMessage, "Operation echo completed in")
+}
+
+// TestChainOperationInterceptors tests that chained interceptors behave as a single interceptor.
+func TestChainOperationInterceptors(t *testing.T) {
+	var calls []string
+	sys := newOrderedSystem(t, &configApi.Configuration{}, echoOperation("echo", &calls))
+	sys.AddOperationInterceptor(systemApi.ChainOperationInterceptors(
+		recordingInterceptor("first", &calls),
+		recordingInterceptor("second", &calls)))
+
+	_, err := sys.ExecuteOperation(context.Background(), "echo", &systemApi.SystemOperationInput{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"before:first", "before:second", "execute", "after:second", "after:first"}, calls)
+}
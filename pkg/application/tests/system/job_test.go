@@ -0,0 +1,191 @@
+package system_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	configApi "github.com/edward1christian/block-forge/pkg/application/config"
+	systemApi "github.com/edward1christian/block-forge/pkg/application/system"
+)
+
+// contextOperation is an operation executing the given function with its context.
+type contextOperation struct {
+	systemApi.BaseSystemOperation
+	fn func(ctx *context.Context) (*systemApi.SystemOperationOutput, error)
+}
+
+// newContextOperation creates a new contextOperation.
+func newContextOperation(id string, fn func(ctx *context.Context) (*systemApi.SystemOperationOutput, error)) *contextOperation {
+	return &contextOperation{
+		BaseSystemOperation: *systemApi.NewBaseSystemOperation(id, id, ""),
+		fn:                  fn,
+	}
+}
+
+// Execute executes the operation function.
+func (o *contextOperation) Execute(ctx *context.Context, input *systemApi.SystemOperationInput) (*systemApi.SystemOperationOutput, error) {
+	return o.fn(ctx)
+}
+
+// blockingOperation returns an operation that reports progress and blocks until released or canceled.
+func blockingOperation(id string, release <-chan struct{}) *contextOperation {
+	return newContextOperation(id, func(ctx *context.Context) (*systemApi.SystemOperationOutput, error) {
+		systemApi.ReportProgress(ctx, 0.5)
+		select {
+		case <-release:
+			return &systemApi.SystemOperationOutput{Data: "released"}, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	})
+}
+
+// TestSystemImpl_ExecuteOperationAsync tests that an asynchronous operation reports its progress and result.
+func TestSystemImpl_ExecuteOperationAsync(t *testing.T) {
+	release := make(chan struct{})
+	sys := newOrderedSystem(t, &configApi.Configuration{}, blockingOperation("build", release))
+
+	job, err := sys.ExecuteOperationAsync(context.Background(), "build", &systemApi.SystemOperationInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, "build", job.OperationID())
+
+	assert.Eventually(t, func() bool { return job.Progress() == 0.5 }, time.Second, time.Millisecond)
+	assert.Equal(t, systemApi.JobRunningType, job.Status())
+
+	found, err := sys.GetJob(job.ID())
+	assert.NoError(t, err)
+	assert.Same(t, job, found)
+
+	close(release)
+	output, err := job.Wait()
+	assert.NoError(t, err)
+	assert.Equal(t, "released", output.Data)
+	assert.Equal(t, systemApi.JobSucceededType, job.Status())
+	assert.Equal(t, float64(1), job.Progress())
+}
+
+// TestSystemImpl_ExecuteOperationAsync_NilContext tests that jobs submitted without a context
+// run with a background context.
+func TestSystemImpl_ExecuteOperationAsync_NilContext(t *testing.T) {
+	release := make(chan struct{})
+	close(release)
+	sys := newOrderedSystem(t, &configApi.Configuration{}, blockingOperation("build", release))
+
+	for _, ctx := range []*context.Context{nil, {}} {
+		job, err := sys.ExecuteOperationAsync(ctx, "build", &systemApi.SystemOperationInput{})
+		assert.NoError(t, err)
+		output, err := job.Wait()
+		assert.NoError(t, err)
+		assert.Equal(t, "released", output.Data)
+	}
+}
+
+// TestSystemImpl_ExecuteOperationAsync_Cancel tests that canceling a job cancels the operation context.
+func TestSystemImpl_ExecuteOperationAsync_Cancel(t *testing.T) {
+	sys := newOrderedSystem(t, &configApi.Configuration{}, blockingOperation("build", make(chan struct{})))
+
+	job, err := sys.ExecuteOperationAsync(context.Background(), "build", &systemApi.SystemOperationInput{})
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool { return job.Status() == systemApi.JobRunningType }, time.Second, time.Millisecond)
+
+	job.Cancel()
+	_, err = job.Wait()
+
+	assert.Error(t, err)
+	assert.Equal(t, systemApi.JobCanceledType, job.Status())
+	assert.Equal(t, "canceled", job.Status().String())
+}
+
+// TestSystemImpl_ExecuteOperationAsync_Failed tests that a failing operation fails its job.
+func TestSystemImpl_ExecuteOperationAsync_Failed(t *testing.T) {
+	failure := errors.New("build failed")
+	sys := newOrderedSystem(t, &configApi.Configuration{}, newContextOperation("build",
+		func(ctx *context.Context) (*systemApi.SystemOperationOutput, error) { return nil, failure }))
+
+	job, err := sys.ExecuteOperationAsync(context.Background(), "build", &systemApi.SystemOperationInput{})
+	assert.NoError(t, err)
+
+	_, err = job.Wait()
+	assert.Equal(t, failure, err)
+	assert.Equal(t, systemApi.JobFailedType, job.Status())
+}
+
+// TestSystemImpl_GetJob_Expired tests that completed jobs are forgotten once the retention period has elapsed.
+func TestSystemImpl_GetJob_Expired(t *testing.T) {
+	release := make(chan struct{})
+	sys := newOrderedSystem(t, &configApi.Configuration{JobRetention: configApi.Duration(10 * time.Millisecond)}, blockingOperation("build", release))
+
+	job, err := sys.ExecuteOperationAsync(context.Background(), "build", &systemApi.SystemOperationInput{})
+	assert.NoError(t, err)
+	time.Sleep(20 * time.Millisecond)
+	_, err = sys.GetJob(job.ID())
+	assert.NoError(t, err, "running jobs are not forgotten")
+
+	close(release)
+	_, err = job.Wait()
+	assert.NoError(t, err)
+
+	assert.Eventually(t, func() bool {
+		_, err := sys.GetJob(job.ID())
+		return errors.Is(err, systemApi.ErrJobNotFound)
+	}, time.Second, time.Millisecond)
+}
+
+// TestSystemImpl_ExecuteOperationAsync_QueueFull tests that jobs are rejected when the queue is full.
+func TestSystemImpl_ExecuteOperationAsync_QueueFull(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	configuration := &configApi.Configuration{MaxConcurrentOperations: 1, OperationQueueSize: 1}
+	sys := newOrderedSystem(t, configuration, blockingOperation("build", release))
+	ctx := context.Background()
+
+	running, err := sys.ExecuteOperationAsync(ctx, "build", &systemApi.SystemOperationInput{})
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool { return running.Status() == systemApi.JobRunningType }, time.Second, time.Millisecond)
+
+	queued, err := sys.ExecuteOperationAsync(ctx, "build", &systemApi.SystemOperationInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, systemApi.JobPendingType, queued.Status())
+
+	_, err = sys.ExecuteOperationAsync(ctx, "build", &systemApi.SystemOperationInput{})
+	assert.True(t, errors.Is(err, systemApi.ErrJobQueueFull))
+}
+
+// TestSystemImpl_ExecuteOperationAsync_NotFound tests that unknown operations and jobs are reported.
+func TestSystemImpl_ExecuteOperationAsync_NotFound(t *testing.T) {
+	sys := newOrderedSystem(t, &configApi.Configuration{})
+
+	_, err := sys.ExecuteOperationAsync(context.Background(), "missing", &systemApi.SystemOperationInput{})
+	assert.Error(t, err)
+
+	_, err = sys.GetJob("job-42")
+	assert.True(t, errors.Is(err, systemApi.ErrJobNotFound))
+}
+
+// TestSystemImpl_ExecuteOperation_ReleasesLock tests that operations do not hold the system lock while executing.
+func TestSystemImpl_ExecuteOperation_ReleasesLock(t *testing.T) {
+	var sys *systemApi.SystemImpl
+	operation := newContextOperation("register", func(ctx *context.Context) (*systemApi.SystemOperationOutput, error) {
+		// Acquiring the system write lock would deadlock if the read lock was held
+		sys.AddOperationInterceptor(systemApi.RecoveryInterceptor())
+		return &systemApi.SystemOperationOutput{}, nil
+	})
+	sys = newOrderedSystem(t, &configApi.Configuration{}, operation)
+
+	done := make(chan error)
+	go func() {
+		_, err := sys.ExecuteOperation(context.Background(), "register", &systemApi.SystemOperationInput{})
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("operation blocked on the system lock")
+	}
+}
@@ -0,0 +1,107 @@
+package system_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	"github.com/edward1christian/block-forge/pkg/application/mocks"
+	"github.com/edward1christian/block-forge/pkg/application/system"
+	"github.com/stretchr/testify/assert"
+)
+
+// writeManifest writes a plugin manifest with the given content in the given directory.
+func writeManifest(t *testing.T, dir, content string) string {
+	assert.NoError(t, os.MkdirAll(dir, 0755))
+	path := filepath.Join(dir, system.PluginManifestFile)
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	return path
+}
+
+// fakeLoader returns a loader creating mock plugins with the ID of the manifest,
+// recording the loaded manifests.
+func fakeLoader(loaded *[]*system.PluginManifest) system.PluginLoader {
+	return func(ctx *context.Context, manifest *system.PluginManifest) (system.PluginInterface, error) {
+		*loaded = append(*loaded, manifest)
+		plugin := new(mocks.MockPlugin)
+		plugin.On("ID").Return(manifest.ID)
+		return plugin, nil
+	}
+}
+
+func TestReadPluginManifest(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	path := writeManifest(t, dir, `{"id": "extractor", "kind": "go", "path": "extractor.so", "version": "1.0.0"}`)
+
+	// Act
+	manifest, err := system.ReadPluginManifest(path)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "extractor", manifest.ID)
+	assert.Equal(t, "1.0.0", manifest.Version)
+	assert.Equal(t, filepath.Join(dir, "extractor.so"), manifest.EntryPath())
+
+	for _, content := range []string{`{"kind": "go", "path": "a.so"}`, `{"id": "a", "path": "a.so"}`, `{"id": "a", "kind": "go"}`, `{`} {
+		_, err = system.ReadPluginManifest(writeManifest(t, dir, content))
+		assert.True(t, errors.Is(err, system.ErrInvalidPluginManifest), content)
+	}
+}
+
+func TestDiscoverPlugins_Success(t *testing.T) {
+	// Arrange
+	root := t.TempDir()
+	writeManifest(t, filepath.Join(root, "plugins", "b"), `{"id": "b", "kind": "fake", "path": "b"}`)
+	writeManifest(t, filepath.Join(root, "plugins", "a"), `{"id": "a", "kind": "fake", "path": "a"}`)
+	assert.NoError(t, os.MkdirAll(filepath.Join(root, "plugins", "empty"), 0755))
+	single := writeManifest(t, filepath.Join(root, "single"), `{"id": "c", "kind": "fake", "path": "/opt/c"}`)
+
+	ctx := context.Background().WithPluginPaths(
+		filepath.Join(root, "plugins"), filepath.Dir(single), filepath.Join(root, "missing"))
+	pluginManager := system.NewPluginManager()
+	var loaded []*system.PluginManifest
+	assert.NoError(t, pluginManager.RegisterPluginLoader("fake", fakeLoader(&loaded)))
+
+	// Act
+	plugins, err := pluginManager.DiscoverPlugins(ctx)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, plugins, 3)
+	ids := []string{}
+	for _, plugin := range plugins {
+		ids = append(ids, plugin.ID())
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, ids)
+	assert.Equal(t, "/opt/c", loaded[2].EntryPath())
+}
+
+func TestDiscoverPlugins_Errors(t *testing.T) {
+	// Arrange
+	root := t.TempDir()
+	writeManifest(t, filepath.Join(root, "a"), `{"id": "a", "kind": "fake", "path": "a"}`)
+	writeManifest(t, filepath.Join(root, "b"), `{"id": "b", "kind": "unknown", "path": "b"}`)
+	writeManifest(t, filepath.Join(root, "c"), `{"id": "c", "kind": "failing", "path": "c"}`)
+
+	ctx := context.Background().WithPluginPaths(root)
+	pluginManager := system.NewPluginManager()
+	var loaded []*system.PluginManifest
+	assert.NoError(t, pluginManager.RegisterPluginLoader("fake", fakeLoader(&loaded)))
+	assert.NoError(t, pluginManager.RegisterPluginLoader("failing",
+		func(ctx *context.Context, manifest *system.PluginManifest) (system.PluginInterface, error) {
+			return nil, errors.New("missing library")
+		}))
+
+	// Act
+	plugins, err := pluginManager.DiscoverPlugins(ctx)
+
+	// Assert
+	assert.Len(t, plugins, 1, "plugins that loaded must be returned")
+	assert.True(t, errors.Is(err, system.ErrPluginLoaderNotFound))
+	assert.Contains(t, err.Error(), "failed to load plugin c: missing library")
+	assert.True(t, errors.Is(pluginManager.RegisterPluginLoader(system.GoPluginKind, fakeLoader(&loaded)),
+		system.ErrPluginLoaderAlreadyExists))
+}
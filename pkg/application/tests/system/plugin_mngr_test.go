@@ -2,9 +2,13 @@ package system_test
 
 import (
 	"errors"
+	"path/filepath"
 	"testing"
 
 	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	loggerApi "github.com/edward1christian/block-forge/pkg/application/common/logger"
+	"github.com/edward1christian/block-forge/pkg/application/component"
+	"github.com/edward1christian/block-forge/pkg/application/config"
 	"github.com/edward1christian/block-forge/pkg/application/mocks"
 	"github.com/edward1christian/block-forge/pkg/application/system"
 	"github.com/stretchr/testify/assert"
@@ -19,7 +23,7 @@ func TestAddPlugin_Success(t *testing.T) {
 
 	// Mock behavior
 	mockPlugin.On("Initialize", mock.Anything, mock.Anything).Return(nil)
-	mockPlugin.On("RegisterResources", ctx).Return(nil)
+	mockPlugin.On("RegisterResources", mock.Anything).Return(nil)
 	mockPlugin.On("ID").Return("mock_plugin")
 
 	// Act
@@ -37,7 +41,7 @@ func TestAddPlugin_Error_DuplicateID(t *testing.T) {
 
 	// Mock behavior
 	mockPlugin.On("Initialize", mock.Anything, mock.Anything).Return(nil)
-	mockPlugin.On("RegisterResources", ctx).Return(nil)
+	mockPlugin.On("RegisterResources", mock.Anything).Return(nil)
 	mockPlugin.On("ID").Return("duplicate_plugin")
 
 	// Add a plugin with the same ID first
@@ -61,7 +65,7 @@ func TestRemovePlugin_Success(t *testing.T) {
 
 	// Mock behavior
 	mockPlugin.On("Initialize", mock.Anything, mock.Anything).Return(nil)
-	mockPlugin.On("RegisterResources", ctx).Return(nil)
+	mockPlugin.On("RegisterResources", mock.Anything).Return(nil)
 	mockPlugin.On("ID").Return("duplicate_plugin")
 
 	// Add plugin to the manager
@@ -99,7 +103,7 @@ func TestGetPlugin_Success(t *testing.T) {
 
 	// Mock behavior
 	mockPlugin.On("Initialize", mock.Anything, mock.Anything).Return(nil)
-	mockPlugin.On("RegisterResources", ctx).Return(nil)
+	mockPlugin.On("RegisterResources", mock.Anything).Return(nil)
 	mockPlugin.On("ID").Return("super_plugin")
 
 	// Add plugin to the manager
@@ -137,8 +141,8 @@ func TestStartPlugins_Success(t *testing.T) {
 	mockPlugin1.On("Initialize", mock.Anything, mock.Anything).Return(nil)
 	mockPlugin2.On("Initialize", mock.Anything, mock.Anything).Return(nil)
 
-	mockPlugin1.On("RegisterResources", ctx).Return(nil)
-	mockPlugin2.On("RegisterResources", ctx).Return(nil)
+	mockPlugin1.On("RegisterResources", mock.Anything).Return(nil)
+	mockPlugin2.On("RegisterResources", mock.Anything).Return(nil)
 
 	mockPlugin1.On("ID").Return("super_plugin1")
 	mockPlugin2.On("ID").Return("super_plugin2")
@@ -168,11 +172,11 @@ func TestStartPlugins_Error(t *testing.T) {
 	mockPlugin2 := new(mocks.MockPlugin)
 
 	// Mock behavior
-	mockPlugin1.On("Initialize", ctx, mock.Anything).Return(nil)
-	mockPlugin2.On("Initialize", ctx, mock.Anything).Return(nil)
+	mockPlugin1.On("Initialize", mock.Anything, mock.Anything).Return(nil)
+	mockPlugin2.On("Initialize", mock.Anything, mock.Anything).Return(nil)
 
-	mockPlugin1.On("RegisterResources", ctx).Return(nil)
-	mockPlugin2.On("RegisterResources", ctx).Return(nil)
+	mockPlugin1.On("RegisterResources", mock.Anything).Return(nil)
+	mockPlugin2.On("RegisterResources", mock.Anything).Return(nil)
 
 	mockPlugin1.On("ID").Return("super_plugin1")
 	mockPlugin2.On("ID").Return("super_plugin2")
@@ -193,6 +197,33 @@ func TestStartPlugins_Error(t *testing.T) {
 	mockPlugin1.AssertCalled(t, "Start", mock.Anything)
 }
 
+func TestStopPlugins_SkipsPluginsFailingToStart(t *testing.T) {
+	// Arrange
+	ctx := &context.Context{}
+	pluginManager := system.NewPluginManager()
+	failing := new(mocks.MockPlugin)
+	started := new(mocks.MockPlugin)
+	for id, plugin := range map[string]*mocks.MockPlugin{"failing_plugin": failing, "started_plugin": started} {
+		plugin.On("Initialize", mock.Anything, mock.Anything).Return(nil)
+		plugin.On("RegisterResources", mock.Anything).Return(nil)
+		plugin.On("ID").Return(id)
+		plugin.On("Stop", mock.Anything).Return(nil)
+	}
+	failing.On("Start", mock.Anything).Return(errors.New("start error"))
+	started.On("Start", mock.Anything).Return(nil)
+	assert.NoError(t, pluginManager.AddPlugin(ctx, failing))
+	assert.NoError(t, pluginManager.AddPlugin(ctx, started))
+	assert.Error(t, pluginManager.StartPlugins(ctx))
+
+	// Act
+	err := pluginManager.StopPlugins(ctx)
+
+	// Assert
+	assert.NoError(t, err)
+	failing.AssertNotCalled(t, "Stop", mock.Anything)
+	started.AssertCalled(t, "Stop", mock.Anything)
+}
+
 func TestStopPlugins_Success(t *testing.T) {
 	// Arrange
 	ctx := &context.Context{}
@@ -201,11 +232,11 @@ func TestStopPlugins_Success(t *testing.T) {
 	mockPlugin2 := new(mocks.MockPlugin)
 
 	// Mock behavior
-	mockPlugin1.On("Initialize", ctx, mock.Anything).Return(nil)
-	mockPlugin2.On("Initialize", ctx, mock.Anything).Return(nil)
+	mockPlugin1.On("Initialize", mock.Anything, mock.Anything).Return(nil)
+	mockPlugin2.On("Initialize", mock.Anything, mock.Anything).Return(nil)
 
-	mockPlugin1.On("RegisterResources", ctx).Return(nil)
-	mockPlugin2.On("RegisterResources", ctx).Return(nil)
+	mockPlugin1.On("RegisterResources", mock.Anything).Return(nil)
+	mockPlugin2.On("RegisterResources", mock.Anything).Return(nil)
 
 	mockPlugin1.On("ID").Return("super_plugin1")
 	mockPlugin2.On("ID").Return("super_plugin2")
@@ -232,11 +263,11 @@ func TestStopPlugins_Error(t *testing.T) {
 	mockPlugin2 := new(mocks.MockPlugin)
 
 	// Mock behavior
-	mockPlugin1.On("Initialize", ctx, mock.Anything).Return(nil)
-	mockPlugin2.On("Initialize", ctx, mock.Anything).Return(nil)
+	mockPlugin1.On("Initialize", mock.Anything, mock.Anything).Return(nil)
+	mockPlugin2.On("Initialize", mock.Anything, mock.Anything).Return(nil)
 
-	mockPlugin1.On("RegisterResources", ctx).Return(nil)
-	mockPlugin2.On("RegisterResources", ctx).Return(nil)
+	mockPlugin1.On("RegisterResources", mock.Anything).Return(nil)
+	mockPlugin2.On("RegisterResources", mock.Anything).Return(nil)
 
 	mockPlugin1.On("ID").Return("super_plugin1")
 	mockPlugin2.On("ID").Return("super_plugin2")
@@ -262,3 +293,336 @@ func TestStopPlugins_Error(t *testing.T) {
 	assert.Contains(t, err.Error(), "stop error", "Error message should indicate stop error")
 	mockPlugin1.AssertCalled(t, "Stop", ctx)
 }
+
+func TestStopPlugins_ReverseOrder(t *testing.T) {
+	// Arrange
+	ctx := &context.Context{}
+	pluginManager := system.NewPluginManager()
+	var calls []string
+
+	for _, id := range []string{"plugin1", "plugin2", "plugin3"} {
+		id := id
+		mockPlugin := new(mocks.MockPlugin)
+		mockPlugin.On("Initialize", mock.Anything, mock.Anything).Return(nil)
+		mockPlugin.On("RegisterResources", mock.Anything).Return(nil)
+		mockPlugin.On("ID").Return(id)
+		mockPlugin.On("Start", mock.Anything).Run(func(mock.Arguments) { calls = append(calls, "start:"+id) }).Return(nil)
+		mockPlugin.On("Stop", ctx).Run(func(mock.Arguments) { calls = append(calls, "stop:"+id) }).Return(nil)
+		assert.NoError(t, pluginManager.AddPlugin(ctx, mockPlugin))
+	}
+
+	// Act
+	assert.NoError(t, pluginManager.StartPlugins(ctx))
+	assert.NoError(t, pluginManager.StopPlugins(ctx))
+
+	// Assert
+	assert.Equal(t, []string{
+		"start:plugin1", "start:plugin2", "start:plugin3",
+		"stop:plugin3", "stop:plugin2", "stop:plugin1",
+	}, calls)
+}
+
+func TestAddPlugin_RecordsOwner(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	registrar := component.NewComponentRegistrar()
+	pluginManager := system.NewPluginManager()
+	mockPlugin := new(mocks.MockPlugin)
+	factory := &mocks.MockComponentFactory{}
+	created := system.NewBaseSystemOperation("owned_op", "Owned", "")
+
+	factory.On("CreateComponent", mock.Anything).Return(created, nil)
+	mockPlugin.On("ID").Return("owner_plugin")
+	mockPlugin.On("Initialize", mock.Anything, mock.Anything).Return(nil)
+	mockPlugin.On("RegisterResources", mock.Anything).Run(func(args mock.Arguments) {
+		pluginCtx := args.Get(0).(*context.Context)
+		assert.NoError(t, registrar.RegisterFactory(pluginCtx, "owned_opFactory", factory))
+		_, err := registrar.CreateComponent(pluginCtx, &config.ComponentConfig{ID: "owned_op", FactoryID: "owned_opFactory"})
+		assert.NoError(t, err)
+	}).Return(nil)
+
+	// Act
+	err := pluginManager.AddPlugin(ctx, mockPlugin)
+
+	// Assert
+	assert.NoError(t, err)
+	info, err := registrar.GetComponentInfo("owned_op")
+	assert.NoError(t, err)
+	assert.Equal(t, component.ComponentInfo{FactoryID: "owned_opFactory", Owner: "owner_plugin"}, info)
+}
+
+// manifestedPlugin is a mock plugin providing a manifest.
+type manifestedPlugin struct {
+	*mocks.MockPlugin
+	manifest *system.PluginManifest
+}
+
+// Manifest returns the manifest of the plugin.
+func (p *manifestedPlugin) Manifest() *system.PluginManifest {
+	return p.manifest
+}
+
+// addManifestedPlugins adds plugins with the given manifests, recording their starts and stops.
+func addManifestedPlugins(t *testing.T, pluginManager system.PluginManagerInterface, calls *[]string, manifests ...*system.PluginManifest) {
+	for _, manifest := range manifests {
+		id := manifest.ID
+		mockPlugin := new(mocks.MockPlugin)
+		mockPlugin.On("Initialize", mock.Anything, mock.Anything).Return(nil)
+		mockPlugin.On("RegisterResources", mock.Anything).Return(nil)
+		mockPlugin.On("ID").Return(id)
+		mockPlugin.On("Start", mock.Anything).Run(func(mock.Arguments) { *calls = append(*calls, "start:"+id) }).Return(nil)
+		mockPlugin.On("Stop", mock.Anything).Run(func(mock.Arguments) { *calls = append(*calls, "stop:"+id) }).Return(nil)
+		assert.NoError(t, pluginManager.AddPlugin(context.Background(), &manifestedPlugin{MockPlugin: mockPlugin, manifest: manifest}))
+	}
+}
+
+func TestStartPlugins_DependencyOrder(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	pluginManager := system.NewPluginManager()
+	var calls []string
+	addManifestedPlugins(t, pluginManager, &calls,
+		&system.PluginManifest{ID: "a", DependsOn: []system.PluginDependency{{ID: "b", Version: "^1.0"}}},
+		&system.PluginManifest{ID: "b", Version: "1.2.0", CoreAPIVersion: "^1.0"},
+		&system.PluginManifest{ID: "c"},
+	)
+
+	// Act
+	assert.NoError(t, pluginManager.StartPlugins(ctx))
+	assert.NoError(t, pluginManager.StopPlugins(ctx))
+
+	// Assert
+	assert.Equal(t, []string{"start:b", "start:a", "start:c", "stop:c", "stop:a", "stop:b"}, calls)
+	manifest, err := pluginManager.GetPluginManifest("b")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.0", manifest.Version)
+	_, err = pluginManager.GetPluginManifest("unknown")
+	assert.True(t, errors.Is(err, system.ErrPluginManifestNotFound))
+}
+
+func TestStartPlugins_RefusesUnresolvedDependencies(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	pluginManager := system.NewPluginManager()
+	var calls []string
+	addManifestedPlugins(t, pluginManager, &calls,
+		&system.PluginManifest{ID: "a", DependsOn: []system.PluginDependency{{ID: "missing"}}},
+		&system.PluginManifest{ID: "b", DependsOn: []system.PluginDependency{{ID: "a"}}},
+		&system.PluginManifest{ID: "c", DependsOn: []system.PluginDependency{{ID: "d", Version: "^2.0"}}},
+		&system.PluginManifest{ID: "d", Version: "1.0.0"},
+		&system.PluginManifest{ID: "e", DependsOn: []system.PluginDependency{{ID: "f"}}},
+		&system.PluginManifest{ID: "f", DependsOn: []system.PluginDependency{{ID: "e"}}},
+	)
+
+	// Act
+	err := pluginManager.StartPlugins(ctx)
+
+	// Assert
+	assert.Equal(t, []string{"start:d"}, calls)
+	assert.True(t, errors.Is(err, system.ErrPluginDependencyNotFound))
+	assert.True(t, errors.Is(err, system.ErrIncompatiblePlugin))
+	assert.True(t, errors.Is(err, system.ErrPluginDependencyCycle))
+	assert.Contains(t, err.Error(), "plugin a requires missing")
+	assert.Contains(t, err.Error(), "plugin b requires a, which cannot be started")
+	assert.Contains(t, err.Error(), "plugin c requires d ^2.0, found 1.0.0")
+	assert.Contains(t, err.Error(), "e -> f -> e")
+}
+
+func TestAddPlugin_Error_IncompatibleCoreAPI(t *testing.T) {
+	// Arrange
+	pluginManager := system.NewPluginManager()
+	mockPlugin := new(mocks.MockPlugin)
+	mockPlugin.On("ID").Return("future_plugin")
+
+	// Act
+	err := pluginManager.AddPlugin(context.Background(), &manifestedPlugin{
+		MockPlugin: mockPlugin,
+		manifest:   &system.PluginManifest{ID: "future_plugin", CoreAPIVersion: "^2.0"},
+	})
+
+	// Assert
+	assert.True(t, errors.Is(err, system.ErrIncompatiblePlugin))
+	mockPlugin.AssertNotCalled(t, "Initialize", mock.Anything, mock.Anything)
+	_, err = pluginManager.GetPlugin("future_plugin")
+	assert.Error(t, err)
+}
+
+// recordingLoader returns a loader creating mock plugins with the ID of the manifest,
+// recording their initializations, starts and stops along with the version of the manifest.
+func recordingLoader(calls *[]string) system.PluginLoader {
+	return func(ctx *context.Context, manifest *system.PluginManifest) (system.PluginInterface, error) {
+		name := manifest.ID
+		if manifest.Version != "" {
+			name += "@" + manifest.Version
+		}
+		plugin := new(mocks.MockPlugin)
+		plugin.On("ID").Return(manifest.ID)
+		plugin.On("Initialize", mock.Anything, mock.Anything).Run(func(mock.Arguments) { *calls = append(*calls, "init:"+name) }).Return(nil)
+		plugin.On("RegisterResources", mock.Anything).Return(nil)
+		plugin.On("Start", mock.Anything).Run(func(mock.Arguments) { *calls = append(*calls, "start:"+name) }).Return(nil)
+		plugin.On("Stop", mock.Anything).Run(func(mock.Arguments) { *calls = append(*calls, "stop:"+name) }).Return(nil)
+		return plugin, nil
+	}
+}
+
+// addRecordedPlugins discovers the plugins of kind "recorded" in the given path and adds them.
+func addRecordedPlugins(t *testing.T, pluginManager system.PluginManagerInterface, path string, calls *[]string) {
+	assert.NoError(t, pluginManager.RegisterPluginLoader("recorded", recordingLoader(calls)))
+	plugins, err := pluginManager.DiscoverPlugins(context.Background().WithPluginPaths(path))
+	assert.NoError(t, err)
+	for _, plugin := range plugins {
+		assert.NoError(t, pluginManager.AddPlugin(context.Background(), plugin))
+	}
+}
+
+func TestUnloadPlugin_UnregistersResources(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	sys := system.NewSystem(loggerApi.NewLogrusLogger(loggerApi.LevelFatal), nil, &config.Configuration{},
+		system.NewPluginManager(), component.NewComponentRegistrar(), nil)
+	assert.NoError(t, sys.Initialize(ctx))
+	mockPlugin := new(mocks.MockPlugin)
+	factory := &mocks.MockComponentFactory{}
+	factory.On("CreateComponent", mock.Anything).Return(system.NewBaseSystemOperation("owned_op", "Owned", ""), nil)
+	mockPlugin.On("ID").Return("owner_plugin")
+	mockPlugin.On("Initialize", mock.Anything, mock.Anything).Return(nil)
+	mockPlugin.On("RegisterResources", mock.Anything).Run(func(args mock.Arguments) {
+		pluginCtx := args.Get(0).(*context.Context)
+		assert.NoError(t, system.RegisterComponent(pluginCtx, sys,
+			&config.ComponentConfig{ID: "owned_op", FactoryID: "owned_opFactory"}, factory))
+	}).Return(nil)
+	mockPlugin.On("Start", mock.Anything).Return(nil)
+	mockPlugin.On("Stop", mock.Anything).Return(nil)
+	assert.NoError(t, sys.PluginManager().AddPlugin(ctx, mockPlugin))
+	assert.NoError(t, sys.PluginManager().StartPlugins(ctx))
+
+	// Act
+	err := sys.PluginManager().UnloadPlugin(ctx, "owner_plugin")
+
+	// Assert
+	assert.NoError(t, err)
+	mockPlugin.AssertCalled(t, "Stop", mock.Anything)
+	_, err = sys.ComponentRegistry().GetComponent("owned_op")
+	assert.True(t, errors.Is(err, component.ErrComponentNotFound))
+	_, err = sys.ComponentRegistry().GetFactory("owned_opFactory")
+	assert.True(t, errors.Is(err, component.ErrFactoryNotFound))
+	_, err = sys.PluginManager().GetPlugin("owner_plugin")
+	assert.Error(t, err)
+	assert.NoError(t, sys.PluginManager().StopPlugins(ctx))
+	mockPlugin.AssertNumberOfCalls(t, "Stop", 1)
+}
+
+func TestUnloadPlugin_Error_InUse(t *testing.T) {
+	// Arrange
+	pluginManager := system.NewPluginManager()
+	var calls []string
+	addManifestedPlugins(t, pluginManager, &calls,
+		&system.PluginManifest{ID: "a"},
+		&system.PluginManifest{ID: "b", DependsOn: []system.PluginDependency{{ID: "a"}}},
+	)
+
+	// Act
+	err := pluginManager.UnloadPlugin(context.Background(), "a")
+
+	// Assert
+	assert.True(t, errors.Is(err, system.ErrPluginInUse))
+	assert.Contains(t, err.Error(), "a is required by b")
+	assert.NoError(t, pluginManager.UnloadPlugin(context.Background(), "b"))
+	assert.NoError(t, pluginManager.UnloadPlugin(context.Background(), "a"))
+	assert.Empty(t, calls, "plugins that were not started must not be stopped")
+}
+
+func TestReloadPlugin_RestartsDependents(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	dir := t.TempDir()
+	pathA := writeManifest(t, filepath.Join(dir, "a"), `{"id": "a", "kind": "recorded", "path": "a", "version": "1.0.0"}`)
+	writeManifest(t, filepath.Join(dir, "b"), `{"id": "b", "kind": "recorded", "path": "b", "dependsOn": [{"id": "a", "version": "^1.0"}]}`)
+	writeManifest(t, filepath.Join(dir, "c"), `{"id": "c", "kind": "recorded", "path": "c", "dependsOn": [{"id": "b"}]}`)
+	pluginManager := system.NewPluginManager()
+	var calls []string
+	addRecordedPlugins(t, pluginManager, dir, &calls)
+	assert.NoError(t, pluginManager.StartPlugins(ctx))
+	calls = nil
+
+	// Act
+	writeManifest(t, filepath.Join(dir, "a"), `{"id": "a", "kind": "recorded", "path": "a", "version": "1.1.0"}`)
+	err := pluginManager.ReloadPlugin(ctx, "a")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"stop:c", "stop:b", "stop:a@1.0.0", "init:a@1.1.0", "start:a@1.1.0", "start:b", "start:c"}, calls)
+	manifest, err := pluginManager.GetPluginManifest("a")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.1.0", manifest.Version)
+	assert.Equal(t, pathA, manifest.File)
+
+	// A version outside of the range required by the dependents leaves them stopped
+	calls = nil
+	writeManifest(t, filepath.Join(dir, "a"), `{"id": "a", "kind": "recorded", "path": "a", "version": "2.0.0"}`)
+	err = pluginManager.ReloadPlugin(ctx, "a")
+	assert.True(t, errors.Is(err, system.ErrIncompatiblePlugin))
+	assert.Equal(t, []string{"stop:c", "stop:b", "stop:a@1.1.0", "init:a@2.0.0", "start:a@2.0.0"}, calls)
+	assert.NoError(t, pluginManager.StopPlugins(ctx))
+	assert.Equal(t, "stop:a@2.0.0", calls[len(calls)-1])
+}
+
+func TestReloadPlugin_Error_Initialize(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	dir := t.TempDir()
+	writeManifest(t, filepath.Join(dir, "a"), `{"id": "a", "kind": "recorded", "path": "a", "version": "1.0.0"}`)
+	writeManifest(t, filepath.Join(dir, "b"), `{"id": "b", "kind": "recorded", "path": "b", "dependsOn": [{"id": "a"}]}`)
+	pluginManager := system.NewPluginManager()
+	var calls []string
+	addRecordedPlugins(t, pluginManager, dir, &calls)
+	assert.NoError(t, pluginManager.RegisterPluginLoader("failing", func(ctx *context.Context, manifest *system.PluginManifest) (system.PluginInterface, error) {
+		plugin := new(mocks.MockPlugin)
+		plugin.On("ID").Return(manifest.ID)
+		plugin.On("Initialize", mock.Anything, mock.Anything).Return(errors.New("initialize failed"))
+		return plugin, nil
+	}))
+	assert.NoError(t, pluginManager.StartPlugins(ctx))
+	calls = nil
+
+	// Act
+	writeManifest(t, filepath.Join(dir, "a"), `{"id": "a", "kind": "failing", "path": "a", "version": "1.1.0"}`)
+	err := pluginManager.ReloadPlugin(ctx, "a")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "initialize failed")
+	assert.Equal(t, []string{"stop:b", "stop:a@1.0.0", "init:a@1.0.0", "start:a@1.0.0", "start:b"}, calls,
+		"the current version must be restored along with its dependents")
+	manifest, err := pluginManager.GetPluginManifest("a")
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.0", manifest.Version)
+	_, err = pluginManager.GetPlugin("a")
+	assert.NoError(t, err)
+}
+
+func TestReloadPlugin_Error_InvalidVersion(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	dir := t.TempDir()
+	pluginManager := system.NewPluginManager()
+	var calls []string
+	writeManifest(t, dir, `{"id": "a", "kind": "recorded", "path": "a", "version": "1.0.0"}`)
+	addRecordedPlugins(t, pluginManager, dir, &calls)
+	mockPlugin := new(mocks.MockPlugin)
+	mockPlugin.On("ID").Return("native")
+	mockPlugin.On("Initialize", mock.Anything, mock.Anything).Return(nil)
+	mockPlugin.On("RegisterResources", mock.Anything).Return(nil)
+	assert.NoError(t, pluginManager.AddPlugin(ctx, mockPlugin))
+
+	// Act
+	writeManifest(t, dir, `{"id": "a", "kind": "recorded", "path": "a", "coreApiVersion": "^2.0"}`)
+	err := pluginManager.ReloadPlugin(ctx, "a")
+
+	// Assert
+	assert.True(t, errors.Is(err, system.ErrIncompatiblePlugin))
+	assert.Equal(t, []string{"init:a@1.0.0"}, calls, "the current version must stay loaded")
+	_, err = pluginManager.GetPlugin("a")
+	assert.NoError(t, err)
+	assert.True(t, errors.Is(pluginManager.ReloadPlugin(ctx, "native"), system.ErrPluginNotReloadable))
+}
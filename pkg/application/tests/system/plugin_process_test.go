@@ -0,0 +1,314 @@
+package system_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	loggerApi "github.com/edward1christian/block-forge/pkg/application/common/logger"
+	"github.com/edward1christian/block-forge/pkg/application/component"
+	configApi "github.com/edward1christian/block-forge/pkg/application/config"
+	"github.com/edward1christian/block-forge/pkg/application/mocks"
+	systemApi "github.com/edward1christian/block-forge/pkg/application/system"
+)
+
+// pluginHelperEnv is set in the environment of the test binary when it runs as a plugin process.
+const pluginHelperEnv = "BLOCK_FORGE_PLUGIN_HELPER"
+
+// pluginHangEnv names a marker file: the helper plugin serves when the file does not exist yet,
+// and otherwise never answers after writing its process ID to the file with the ".hung" suffix.
+const pluginHangEnv = "BLOCK_FORGE_PLUGIN_HANG"
+
+// TestPluginHelperProcess is not a test: it serves the helper plugin when the test binary
+// is started as a plugin process by the tests below.
+func TestPluginHelperProcess(t *testing.T) {
+	if os.Getenv(pluginHelperEnv) != "1" {
+		return
+	}
+	if marker := os.Getenv(pluginHangEnv); marker != "" {
+		if _, err := os.Stat(marker); err == nil {
+			os.WriteFile(marker+".hung", []byte(strconv.Itoa(os.Getpid())), 0o644)
+			time.Sleep(time.Minute)
+			os.Exit(0)
+		}
+		os.WriteFile(marker, nil, 0o644)
+	}
+
+	err := systemApi.ServePlugin(&systemApi.PluginServer{
+		ID: "helper",
+		Operations: []systemApi.SystemOperationInterface{
+			newFuncOperation("helper.upper", func(input *systemApi.SystemOperationInput) (*systemApi.SystemOperationOutput, error) {
+				data, err := systemApi.InputAs[string](input)
+				if err != nil {
+					return nil, err
+				}
+				return &systemApi.SystemOperationOutput{Data: strings.ToUpper(data)}, nil
+			}),
+			newFuncOperation("helper.crash", func(input *systemApi.SystemOperationInput) (*systemApi.SystemOperationOutput, error) {
+				os.Exit(3)
+				return nil, nil
+			}),
+		},
+	})
+	if err != nil {
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+// writeHelperManifest writes the manifest of a process plugin running the helper plugin.
+func writeHelperManifest(t *testing.T, manifest systemApi.PluginManifest) string {
+	manifest.Kind = systemApi.ProcessPluginKind
+	manifest.Path = os.Args[0]
+	manifest.Args = []string{"-test.run=^TestPluginHelperProcess$"}
+	env := map[string]string{pluginHelperEnv: "1"}
+	for key, value := range manifest.Env {
+		env[key] = value
+	}
+	manifest.Env = env
+
+	data, err := json.Marshal(manifest)
+	assert.NoError(t, err)
+	return writeManifest(t, filepath.Join(t.TempDir(), manifest.ID), string(data))
+}
+
+// newPluginHostSystem creates an initialized system discovering the plugins described by the given manifest.
+func newPluginHostSystem(t *testing.T, manifestPath string) (*context.Context, systemApi.SystemInterface, []systemApi.PluginInterface) {
+	ctx := context.Background().WithPluginPaths(manifestPath)
+	sys := systemApi.NewSystem(loggerApi.NewLogrusLogger(loggerApi.LevelFatal), nil, &configApi.Configuration{},
+		systemApi.NewPluginManager(), component.NewComponentRegistrar(), nil)
+	assert.NoError(t, sys.Initialize(ctx))
+
+	plugins, err := sys.PluginManager().DiscoverPlugins(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, plugins, 1)
+	return ctx, sys, plugins
+}
+
+func TestProcessPlugin_ProxiesOperations(t *testing.T) {
+	// Arrange
+	ctx, sys, plugins := newPluginHostSystem(t, writeHelperManifest(t, systemApi.PluginManifest{ID: "helper"}))
+
+	// Act
+	err := sys.PluginManager().AddPlugin(ctx, plugins[0])
+	assert.NoError(t, err)
+	assert.NoError(t, sys.PluginManager().StartPlugins(ctx))
+	output, err := sys.ExecuteOperation(ctx, "helper.upper", &systemApi.SystemOperationInput{Data: "nova"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "NOVA", output.Data)
+	info, err := sys.ComponentRegistry().GetComponentInfo("helper.crash")
+	assert.NoError(t, err)
+	assert.Equal(t, "helper", info.Owner, "operations must be owned by the plugin")
+
+	_, err = sys.ExecuteOperation(ctx, "helper.upper", &systemApi.SystemOperationInput{Data: 42})
+	assert.Error(t, err, "errors of the plugin must be returned")
+
+	plugin := plugins[0].(*systemApi.ProcessPlugin)
+	assert.NoError(t, sys.PluginManager().StopPlugins(ctx))
+	assert.Equal(t, 0, plugin.Pid())
+	_, err = sys.ExecuteOperation(ctx, "helper.upper", &systemApi.SystemOperationInput{Data: "nova"})
+	assert.True(t, errors.Is(err, systemApi.ErrPluginNotRunning))
+}
+
+func TestProcessPlugin_StopAndStart(t *testing.T) {
+	// Arrange
+	ctx, sys, plugins := newPluginHostSystem(t, writeHelperManifest(t, systemApi.PluginManifest{ID: "helper"}))
+	assert.NoError(t, sys.PluginManager().AddPlugin(ctx, plugins[0]))
+	assert.NoError(t, sys.PluginManager().StartPlugins(ctx))
+	plugin := plugins[0].(*systemApi.ProcessPlugin)
+	defer plugin.Stop(ctx)
+	assert.NoError(t, sys.PluginManager().StopPlugins(ctx))
+
+	// Act
+	err := sys.PluginManager().StartPlugins(ctx)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotEqual(t, 0, plugin.Pid(), "the plugin must run in a new process")
+	output, err := sys.ExecuteOperation(ctx, "helper.upper", &systemApi.SystemOperationInput{Data: "restarted"})
+	assert.NoError(t, err)
+	assert.Equal(t, "RESTARTED", output.Data)
+}
+
+func TestProcessPlugin_ReloadDependency(t *testing.T) {
+	// Arrange
+	ctx, sys, plugins := newPluginHostSystem(t, writeHelperManifest(t, systemApi.PluginManifest{
+		ID:        "helper",
+		DependsOn: []systemApi.PluginDependency{{ID: "a"}},
+	}))
+	dir := t.TempDir()
+	writeManifest(t, filepath.Join(dir, "a"), `{"id": "a", "kind": "recorded", "path": "a", "version": "1.0.0"}`)
+	var calls []string
+	addRecordedPlugins(t, sys.PluginManager(), dir, &calls)
+	assert.NoError(t, sys.PluginManager().AddPlugin(ctx, plugins[0]))
+	assert.NoError(t, sys.PluginManager().StartPlugins(ctx))
+	plugin := plugins[0].(*systemApi.ProcessPlugin)
+	defer plugin.Stop(ctx)
+	pid := plugin.Pid()
+
+	// Act
+	writeManifest(t, filepath.Join(dir, "a"), `{"id": "a", "kind": "recorded", "path": "a", "version": "1.1.0"}`)
+	err := sys.PluginManager().ReloadPlugin(ctx, "a")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotEqual(t, 0, plugin.Pid(), "the dependent process plugin must be started again")
+	assert.NotEqual(t, pid, plugin.Pid())
+	output, err := sys.ExecuteOperation(ctx, "helper.upper", &systemApi.SystemOperationInput{Data: "reloaded"})
+	assert.NoError(t, err)
+	assert.Equal(t, "RELOADED", output.Data)
+}
+
+func TestProcessPlugin_Initialize_Twice(t *testing.T) {
+	// Arrange
+	ctx, sys, plugins := newPluginHostSystem(t, writeHelperManifest(t, systemApi.PluginManifest{ID: "helper"}))
+	plugin := plugins[0].(*systemApi.ProcessPlugin)
+	assert.NoError(t, plugin.Initialize(ctx, sys))
+	defer plugin.Dispose(ctx)
+	pid := plugin.Pid()
+
+	// Act
+	err := plugin.Initialize(ctx, sys)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotEqual(t, 0, plugin.Pid())
+	assert.NotEqual(t, pid, plugin.Pid())
+	assert.Error(t, syscall.Kill(pid, 0), "the process of the previous initialization must exit")
+}
+
+func TestProcessPlugin_RestartsAfterCrash(t *testing.T) {
+	// Arrange
+	ctx, sys, plugins := newPluginHostSystem(t, writeHelperManifest(t, systemApi.PluginManifest{
+		ID:            "helper",
+		RestartPolicy: string(systemApi.RestartOnFailure),
+		MaxRestarts:   1,
+	}))
+	assert.NoError(t, sys.PluginManager().AddPlugin(ctx, plugins[0]))
+	plugin := plugins[0].(*systemApi.ProcessPlugin)
+	pid := plugin.Pid()
+	defer plugin.Stop(ctx)
+
+	// Act
+	_, err := sys.ExecuteOperation(ctx, "helper.crash", nil)
+
+	// Assert
+	assert.True(t, errors.Is(err, systemApi.ErrPluginCrashed))
+	assert.Eventually(t, func() bool { return plugin.Pid() != 0 && plugin.Pid() != pid }, 5*time.Second, 10*time.Millisecond)
+	output, err := sys.ExecuteOperation(ctx, "helper.upper", &systemApi.SystemOperationInput{Data: "again"})
+	assert.NoError(t, err)
+	assert.Equal(t, "AGAIN", output.Data)
+}
+
+func TestProcessPlugin_RestartTimeout(t *testing.T) {
+	// Arrange
+	marker := filepath.Join(t.TempDir(), "marker")
+	ctx, sys, plugins := newPluginHostSystem(t, writeHelperManifest(t, systemApi.PluginManifest{
+		ID:            "helper",
+		RestartPolicy: string(systemApi.RestartOnFailure),
+		MaxRestarts:   1,
+		Env:           map[string]string{pluginHangEnv: marker},
+	}))
+	plugin := plugins[0].(*systemApi.ProcessPlugin)
+	plugin.RestartTimeout = 500 * time.Millisecond
+	assert.NoError(t, sys.PluginManager().AddPlugin(ctx, plugin))
+	defer plugin.Stop(ctx)
+
+	// Act
+	_, err := sys.ExecuteOperation(ctx, "helper.crash", nil)
+
+	// Assert
+	assert.True(t, errors.Is(err, systemApi.ErrPluginCrashed))
+	var data []byte
+	assert.Eventually(t, func() bool {
+		data, err = os.ReadFile(marker + ".hung")
+		return err == nil && len(data) > 0
+	}, 5*time.Second, 10*time.Millisecond, "the plugin must be restarted")
+	pid, err := strconv.Atoi(string(data))
+	assert.NoError(t, err)
+
+	start := time.Now()
+	assert.Equal(t, 0, plugin.Pid())
+	assert.Less(t, time.Since(start), 100*time.Millisecond, "the plugin must not be locked while restarting")
+	assert.Eventually(t, func() bool { return syscall.Kill(pid, 0) != nil }, 5*time.Second, 10*time.Millisecond,
+		"a restarted process that does not answer must be killed")
+	assert.Equal(t, 0, plugin.Pid())
+}
+
+func TestProcessPlugin_RegisterResourcesError(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	sys := systemApi.NewSystem(loggerApi.NewLogrusLogger(loggerApi.LevelFatal), nil, &configApi.Configuration{},
+		systemApi.NewPluginManager(), component.NewComponentRegistrar(), nil)
+	assert.NoError(t, sys.Initialize(ctx))
+	manifest, err := systemApi.ReadPluginManifest(writeHelperManifest(t, systemApi.PluginManifest{ID: "helper"}))
+	assert.NoError(t, err)
+	plugin, err := systemApi.NewProcessPlugin(manifest)
+	assert.NoError(t, err)
+	assert.NoError(t, sys.ComponentRegistry().RegisterFactory(ctx, "helper/helper.crash", &mocks.MockComponentFactory{}))
+
+	// Act
+	err = sys.PluginManager().AddPlugin(ctx, plugin)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, 0, plugin.Pid(), "the process of the discarded plugin must exit")
+	_, err = sys.ComponentRegistry().GetComponent("helper.upper")
+	assert.True(t, errors.Is(err, component.ErrComponentNotFound), "the registered components must be removed")
+	_, err = sys.ComponentRegistry().GetFactory("helper/helper.upper")
+	assert.True(t, errors.Is(err, component.ErrFactoryNotFound), "the registered factories must be removed")
+	_, err = sys.PluginManager().GetPluginManifest("helper")
+	assert.Error(t, err, "the manifest of the discarded plugin must be forgotten")
+	_, err = sys.PluginManager().GetPlugin("helper")
+	assert.Error(t, err)
+}
+
+func TestProcessPlugin_Initialize_IDMismatch(t *testing.T) {
+	// Arrange
+	ctx, sys, plugins := newPluginHostSystem(t, writeHelperManifest(t, systemApi.PluginManifest{ID: "other"}))
+
+	// Act
+	err := sys.PluginManager().AddPlugin(ctx, plugins[0])
+
+	// Assert
+	assert.True(t, errors.Is(err, systemApi.ErrInvalidPluginManifest))
+	assert.Equal(t, 0, plugins[0].(*systemApi.ProcessPlugin).Pid())
+}
+
+func TestProcessPlugin_ReloadAndUnload(t *testing.T) {
+	// Arrange
+	ctx, sys, plugins := newPluginHostSystem(t, writeHelperManifest(t, systemApi.PluginManifest{ID: "helper"}))
+	assert.NoError(t, sys.PluginManager().AddPlugin(ctx, plugins[0]))
+	assert.NoError(t, sys.PluginManager().StartPlugins(ctx))
+	previous := plugins[0].(*systemApi.ProcessPlugin)
+
+	// Act
+	err := sys.PluginManager().ReloadPlugin(ctx, "helper")
+
+	// Assert
+	assert.NoError(t, err)
+	reloaded, err := sys.PluginManager().GetPlugin("helper")
+	assert.NoError(t, err)
+	assert.NotSame(t, previous, reloaded)
+	assert.Equal(t, 0, previous.Pid(), "the previous process must exit")
+	assert.NotEqual(t, 0, reloaded.(*systemApi.ProcessPlugin).Pid())
+	output, err := sys.ExecuteOperation(ctx, "helper.upper", &systemApi.SystemOperationInput{Data: "reloaded"})
+	assert.NoError(t, err)
+	assert.Equal(t, "RELOADED", output.Data)
+
+	assert.NoError(t, sys.PluginManager().UnloadPlugin(ctx, "helper"))
+	assert.Equal(t, 0, reloaded.(*systemApi.ProcessPlugin).Pid())
+	_, err = sys.ComponentRegistry().GetComponent("helper.upper")
+	assert.True(t, errors.Is(err, component.ErrComponentNotFound))
+}
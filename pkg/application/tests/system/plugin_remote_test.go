@@ -0,0 +1,260 @@
+package system_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	loggerApi "github.com/edward1christian/block-forge/pkg/application/common/logger"
+	"github.com/edward1christian/block-forge/pkg/application/component"
+	configApi "github.com/edward1christian/block-forge/pkg/application/config"
+	systemApi "github.com/edward1christian/block-forge/pkg/application/system"
+)
+
+// remoteManifest is the manifest of the plugin held by the test bundles.
+const remoteManifest = `{"id": "remote", "kind": "recorded", "path": "remote", "version": "1.0.0"}`
+
+// makeBundle returns a gzipped tar archive holding the given entries.
+func makeBundle(t *testing.T, entries ...*tar.Header) []byte {
+	var buffer bytes.Buffer
+	compressed := gzip.NewWriter(&buffer)
+	archive := tar.NewWriter(compressed)
+	for _, header := range entries {
+		content := header.Linkname
+		if header.Typeflag == tar.TypeReg {
+			header.Size, header.Linkname = int64(len(content)), ""
+		}
+		if header.Mode == 0 {
+			header.Mode = 0644
+		}
+		assert.NoError(t, archive.WriteHeader(header))
+		if header.Typeflag == tar.TypeReg {
+			_, err := archive.Write([]byte(content))
+			assert.NoError(t, err)
+		}
+	}
+	assert.NoError(t, archive.Close())
+	assert.NoError(t, compressed.Close())
+	return buffer.Bytes()
+}
+
+// file returns the tar header of a regular file with the given content.
+func file(name, content string) *tar.Header {
+	return &tar.Header{Name: name, Typeflag: tar.TypeReg, Linkname: content}
+}
+
+// publishBundle writes the bundle in the given directory along with its checksum and, if a key
+// is given, its signature. It returns the path of the bundle.
+func publishBundle(t *testing.T, dir string, bundle []byte, key ed25519.PrivateKey) string {
+	path := filepath.Join(dir, "remote.tar.gz")
+	sum := sha256.Sum256(bundle)
+	assert.NoError(t, os.WriteFile(path, bundle, 0644))
+	assert.NoError(t, os.WriteFile(path+systemApi.PluginChecksumSuffix, []byte(hex.EncodeToString(sum[:])+"  remote.tar.gz\n"), 0644))
+	if key != nil {
+		signature := base64.StdEncoding.EncodeToString(ed25519.Sign(key, bundle))
+		assert.NoError(t, os.WriteFile(path+systemApi.PluginSignatureSuffix, []byte(signature), 0644))
+	}
+	return path
+}
+
+// newRemotePluginManager creates a plugin manager of an initialized system loading remote plugins
+// with the given configuration, and loading the plugins of kind "recorded".
+func newRemotePluginManager(t *testing.T, cfg configApi.RemotePluginConfiguration) systemApi.PluginManagerInterface {
+	sys := systemApi.NewSystem(loggerApi.NewLogrusLogger(loggerApi.LevelFatal), nil, &configApi.Configuration{RemotePlugins: cfg},
+		systemApi.NewPluginManager(), component.NewComponentRegistrar(), nil)
+	assert.NoError(t, sys.Initialize(context.Background()))
+	var calls []string
+	assert.NoError(t, sys.PluginManager().RegisterPluginLoader("recorded", recordingLoader(&calls)))
+	return sys.PluginManager()
+}
+
+func TestLoadRemotePlugin_Signed(t *testing.T) {
+	// Arrange
+	public, private, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+	cacheDir := t.TempDir()
+	path := publishBundle(t, t.TempDir(), makeBundle(t, file("plugin.json", remoteManifest), file("remote", "#!/bin/sh")), private)
+	pluginManager := newRemotePluginManager(t, configApi.RemotePluginConfiguration{
+		CacheDir:    cacheDir,
+		TrustedKeys: []string{base64.StdEncoding.EncodeToString(public)},
+	})
+
+	// Act
+	plugin, err := pluginManager.LoadRemotePlugin(context.Background(), path)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "remote", plugin.ID())
+	manifest, err := pluginManager.GetPluginManifest("remote")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(manifest.File, cacheDir), "the bundle must be extracted in the cache")
+	assert.Equal(t, "1.0.0", manifest.Version)
+}
+
+func TestLoadRemotePlugin_Verification(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+	_, untrusted, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+	bundle := makeBundle(t, file("plugin.json", remoteManifest))
+	trusted := configApi.RemotePluginConfiguration{TrustedKeys: []string{base64.StdEncoding.EncodeToString(public)}}
+
+	tests := []struct {
+		name    string
+		cfg     configApi.RemotePluginConfiguration
+		publish func(dir string) string
+		err     error
+	}{
+		{
+			name:    "unsigned bundles are refused",
+			cfg:     trusted,
+			publish: func(dir string) string { return publishBundle(t, dir, bundle, nil) },
+			err:     systemApi.ErrUnsignedPlugin,
+		},
+		{
+			name:    "unsigned bundles are allowed explicitly",
+			cfg:     configApi.RemotePluginConfiguration{AllowUnsigned: true},
+			publish: func(dir string) string { return publishBundle(t, dir, bundle, nil) },
+		},
+		{
+			name:    "signatures of untrusted keys are refused",
+			cfg:     trusted,
+			publish: func(dir string) string { return publishBundle(t, dir, bundle, untrusted) },
+			err:     systemApi.ErrPluginSignatureInvalid,
+		},
+		{
+			name: "tampered bundles are refused",
+			cfg:  trusted,
+			publish: func(dir string) string {
+				path := publishBundle(t, dir, bundle, private)
+				assert.NoError(t, os.WriteFile(path, makeBundle(t, file("plugin.json", remoteManifest), file("remote", "evil")), 0644))
+				return path
+			},
+			err: systemApi.ErrPluginChecksumMismatch,
+		},
+		{
+			name: "checksum pinned in the location",
+			cfg:  trusted,
+			publish: func(dir string) string {
+				path := publishBundle(t, dir, bundle, private)
+				sum := sha256.Sum256([]byte("another bundle"))
+				return path + "#sha256=" + hex.EncodeToString(sum[:])
+			},
+			err: systemApi.ErrPluginChecksumMismatch,
+		},
+		{
+			name: "entries escaping the bundle are refused",
+			cfg:  trusted,
+			publish: func(dir string) string {
+				return publishBundle(t, dir, makeBundle(t, file("../plugin.json", remoteManifest)), private)
+			},
+			err: systemApi.ErrInvalidPluginBundle,
+		},
+		{
+			name: "links are refused",
+			cfg:  trusted,
+			publish: func(dir string) string {
+				return publishBundle(t, dir, makeBundle(t, &tar.Header{Name: "plugin.json", Typeflag: tar.TypeSymlink, Linkname: "/etc/passwd"}), private)
+			},
+			err: systemApi.ErrInvalidPluginBundle,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			tt.cfg.CacheDir = t.TempDir()
+			pluginManager := newRemotePluginManager(t, tt.cfg)
+
+			// Act
+			plugin, err := pluginManager.LoadRemotePlugin(context.Background(), tt.publish(t.TempDir()))
+
+			// Assert
+			if tt.err == nil {
+				assert.NoError(t, err)
+				assert.Equal(t, "remote", plugin.ID())
+				return
+			}
+			assert.True(t, errors.Is(err, tt.err), "expected %v, got %v", tt.err, err)
+			extracted, err := filepath.Glob(filepath.Join(tt.cfg.CacheDir, "*", "plugin", "*"))
+			assert.NoError(t, err)
+			assert.Empty(t, extracted, "refused bundles must not be extracted")
+		})
+	}
+}
+
+func TestRemotePluginSource_Fetch_HTTPCache(t *testing.T) {
+	// Arrange
+	public, private, err := ed25519.GenerateKey(nil)
+	assert.NoError(t, err)
+	bundle := makeBundle(t, file("plugin.json", remoteManifest))
+	sum := sha256.Sum256(bundle)
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch r.URL.Path {
+		case "/remote.tar.gz":
+			w.Write(bundle)
+		case "/remote.tar.gz" + systemApi.PluginSignatureSuffix:
+			w.Write([]byte(base64.StdEncoding.EncodeToString(ed25519.Sign(private, bundle))))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	location := server.URL + "/remote.tar.gz#sha256=" + hex.EncodeToString(sum[:])
+	source, err := systemApi.NewRemotePluginSource(configApi.RemotePluginConfiguration{
+		CacheDir:    t.TempDir(),
+		TrustedKeys: []string{base64.StdEncoding.EncodeToString(public)},
+	})
+	assert.NoError(t, err)
+
+	// Act
+	dir, signed, err := source.Fetch(context.Background(), location)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, signed)
+	assert.FileExists(t, filepath.Join(dir, "plugin.json"))
+	assert.Equal(t, 2, requests)
+
+	// Cached bundles are verified again without being fetched
+	server.Close()
+	cached, _, err := source.Fetch(context.Background(), location)
+	assert.NoError(t, err)
+	assert.Equal(t, dir, cached)
+
+	_, err = systemApi.NewRemotePluginSource(configApi.RemotePluginConfiguration{TrustedKeys: []string{"not a key"}})
+	assert.True(t, errors.Is(err, systemApi.ErrInvalidPluginKey))
+}
+
+func TestDiscoverPlugins_RemotePluginLocations(t *testing.T) {
+	// Arrange
+	dir := t.TempDir()
+	writeManifest(t, filepath.Join(dir, "local"), `{"id": "local", "kind": "recorded", "path": "local"}`)
+	path := publishBundle(t, t.TempDir(), makeBundle(t, file("remote/plugin.json", remoteManifest)), nil)
+	pluginManager := newRemotePluginManager(t, configApi.RemotePluginConfiguration{CacheDir: t.TempDir(), AllowUnsigned: true})
+	ctx := context.Background().WithRemotePluginLocations(path, path+".missing").WithPluginPaths(dir)
+
+	// Act
+	plugins, err := pluginManager.DiscoverPlugins(ctx)
+
+	// Assert
+	assert.Error(t, err, "missing bundles must be reported")
+	assert.Len(t, plugins, 2)
+	assert.Equal(t, "local", plugins[0].ID())
+	assert.Equal(t, "remote", plugins[1].ID())
+}
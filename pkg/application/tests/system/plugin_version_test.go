@@ -0,0 +1,69 @@
+package system_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/edward1christian/block-forge/pkg/application/system"
+)
+
+func TestParseVersion(t *testing.T) {
+	version, err := system.ParseVersion("v1.2.3-beta.1+build")
+	assert.NoError(t, err)
+	assert.Equal(t, system.Version{Major: 1, Minor: 2, Patch: 3, Prerelease: "beta.1"}, version)
+	assert.Equal(t, "1.2.3-beta.1", version.String())
+
+	for _, invalid := range []string{"", "1.2", "1.2.3.4", "a.b.c", "1.-2.3"} {
+		_, err := system.ParseVersion(invalid)
+		assert.True(t, errors.Is(err, system.ErrInvalidVersion), invalid)
+	}
+}
+
+func TestVersion_Compare(t *testing.T) {
+	ordered := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0", "1.0.1", "1.2.0", "2.0.0"}
+	for i := 1; i < len(ordered); i++ {
+		lower, _ := system.ParseVersion(ordered[i-1])
+		higher, _ := system.ParseVersion(ordered[i])
+		assert.Equal(t, -1, lower.Compare(higher), "%s < %s", lower, higher)
+		assert.Equal(t, 1, higher.Compare(lower), "%s > %s", higher, lower)
+		assert.Equal(t, 0, higher.Compare(higher))
+	}
+}
+
+func TestVersionRange_Contains(t *testing.T) {
+	tests := []struct {
+		expression string
+		contains   []string
+		excludes   []string
+	}{
+		{"", []string{"0.0.1", "9.9.9"}, nil},
+		{"*", []string{"1.0.0"}, nil},
+		{">=1.2.0 <2.0.0", []string{"1.2.0", "1.9.9"}, []string{"1.1.9", "2.0.0"}},
+		{"^1.2", []string{"1.2.0", "1.5.0"}, []string{"1.1.0", "2.0.0-rc.1", "2.0.0"}},
+		{"^0.2.3", []string{"0.2.3", "0.2.9"}, []string{"0.3.0"}},
+		{"~1.2.3", []string{"1.2.3", "1.2.9"}, []string{"1.3.0"}},
+		{"1.x", []string{"1.0.0", "1.9.0"}, []string{"2.0.0"}},
+		{"1.2.3", []string{"1.2.3"}, []string{"1.2.4"}},
+		{">1.2", []string{"1.3.0"}, []string{"1.2.9"}},
+		{"<=1.2", []string{"1.2.9"}, []string{"1.3.0"}},
+		{"^1.0 || ^3.0", []string{"1.1.0", "3.2.0"}, []string{"2.0.0"}},
+	}
+
+	for _, test := range tests {
+		versionRange, err := system.ParseVersionRange(test.expression)
+		assert.NoError(t, err, test.expression)
+		for _, s := range test.contains {
+			version, _ := system.ParseVersion(s)
+			assert.True(t, versionRange.Contains(version), "%s should contain %s", test.expression, s)
+		}
+		for _, s := range test.excludes {
+			version, _ := system.ParseVersion(s)
+			assert.False(t, versionRange.Contains(version), "%s should exclude %s", test.expression, s)
+		}
+	}
+
+	_, err := system.ParseVersionRange(">=1.a")
+	assert.True(t, errors.Is(err, system.ErrInvalidVersion))
+}
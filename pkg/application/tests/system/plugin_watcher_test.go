@@ -0,0 +1,81 @@
+package system_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	"github.com/edward1christian/block-forge/pkg/application/system"
+)
+
+// touch sets the modification time of the given file to the given offset from now.
+func touch(t *testing.T, path string, offset time.Duration) {
+	modTime := time.Now().Add(offset)
+	assert.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+func TestPluginWatcher_Poll(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	dir := t.TempDir()
+	writeManifest(t, filepath.Join(dir, "a"), `{"id": "a", "kind": "recorded", "path": "a", "version": "1.0.0"}`)
+	pluginManager := system.NewPluginManager()
+	var calls []string
+	addRecordedPlugins(t, pluginManager, dir, &calls)
+	assert.NoError(t, pluginManager.StartPlugins(ctx))
+	watcher := system.NewPluginWatcher(pluginManager, []string{dir}, time.Hour)
+	assert.NoError(t, watcher.Poll(ctx))
+	calls = nil
+
+	// Act
+	touch(t, writeManifest(t, filepath.Join(dir, "a"), `{"id": "a", "kind": "recorded", "path": "a", "version": "1.1.0"}`), time.Second)
+	writeManifest(t, filepath.Join(dir, "b"), `{"id": "b", "kind": "recorded", "path": "b", "dependsOn": [{"id": "a"}]}`)
+	err := watcher.Poll(ctx)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"stop:a@1.0.0", "init:a@1.1.0", "start:a@1.1.0", "init:b", "start:b"}, calls)
+	assert.NoError(t, watcher.Poll(ctx))
+	assert.Len(t, calls, 5, "unchanged plugins must not be reloaded")
+
+	// An invalid manifest is reported once and leaves the plugin in place
+	calls = nil
+	touch(t, writeManifest(t, filepath.Join(dir, "b"), `{"id": "b"`), 2*time.Second)
+	assert.Error(t, watcher.Poll(ctx))
+	assert.NoError(t, watcher.Poll(ctx))
+	_, err = pluginManager.GetPlugin("b")
+	assert.NoError(t, err)
+
+	// Removed plugins are unloaded
+	assert.NoError(t, os.RemoveAll(filepath.Join(dir, "b")))
+	assert.NoError(t, watcher.Poll(ctx))
+	assert.Equal(t, []string{"stop:b"}, calls)
+	_, err = pluginManager.GetPlugin("b")
+	assert.Error(t, err)
+}
+
+func TestPluginWatcher_StartStop(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	dir := t.TempDir()
+	pluginManager := system.NewPluginManager()
+	var calls []string
+	addRecordedPlugins(t, pluginManager, dir, &calls)
+	watcher := system.NewPluginWatcher(pluginManager, []string{dir}, 10*time.Millisecond)
+
+	// Act
+	assert.NoError(t, watcher.Start(ctx))
+	writeManifest(t, filepath.Join(dir, "a"), `{"id": "a", "kind": "recorded", "path": "a"}`)
+
+	// Assert
+	assert.Eventually(t, func() bool {
+		_, err := pluginManager.GetPlugin("a")
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.NoError(t, watcher.Stop(ctx))
+	assert.NoError(t, watcher.Stop(ctx))
+}
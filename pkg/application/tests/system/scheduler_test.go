@@ -0,0 +1,309 @@
+package system_test
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"cosmossdk.io/log"
+	"github.com/cosmos/iavl"
+	iavldb "github.com/cosmos/iavl/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	"github.com/edward1christian/block-forge/pkg/application/common/event"
+	"github.com/edward1christian/block-forge/pkg/application/component"
+	configApi "github.com/edward1christian/block-forge/pkg/application/config"
+	"github.com/edward1christian/block-forge/pkg/application/db"
+	"github.com/edward1christian/block-forge/pkg/application/mocks"
+	"github.com/edward1christian/block-forge/pkg/application/store"
+	systemApi "github.com/edward1christian/block-forge/pkg/application/system"
+)
+
+// TestParseCronSchedule_Next tests the next activation of cron expressions.
+func TestParseCronSchedule_Next(t *testing.T) {
+	at := func(value string) time.Time {
+		parsed, err := time.Parse("2006-01-02 15:04", value)
+		assert.NoError(t, err)
+		return parsed
+	}
+
+	tests := []struct {
+		expression string
+		after      string
+		next       string
+	}{
+		{"*/15 * * * *", "2024-06-03 10:07", "2024-06-03 10:15"},
+		{"*/15 * * * *", "2024-06-03 10:15", "2024-06-03 10:30"},
+		{"0 9 * * mon-fri", "2024-06-01 10:00", "2024-06-03 09:00"},
+		{"30 2 1 * *", "2024-01-15 00:00", "2024-02-01 02:30"},
+		{"0 0 * * 7", "2024-06-03 00:00", "2024-06-09 00:00"},
+		{"0 12 1,15 * *", "2024-06-02 00:00", "2024-06-15 12:00"},
+		{"0 0 13 * fri", "2024-06-01 00:00", "2024-06-07 00:00"},
+		{"5/20 8-10 * jan *", "2024-06-01 00:00", "2025-01-01 08:05"},
+		{"@daily", "2024-06-03 10:07", "2024-06-04 00:00"},
+		{"@hourly", "2024-06-03 10:07", "2024-06-03 11:00"},
+	}
+	for _, test := range tests {
+		schedule, err := systemApi.ParseCronSchedule(test.expression)
+		assert.NoError(t, err, test.expression)
+		assert.Equal(t, at(test.next), schedule.Next(at(test.after)), test.expression)
+	}
+
+	never, err := systemApi.ParseCronSchedule("0 0 30 2 *")
+	assert.NoError(t, err)
+	assert.True(t, never.Next(at("2024-01-01 00:00")).IsZero())
+}
+
+// TestParseSchedule_Invalid tests that invalid expressions are rejected.
+func TestParseSchedule_Invalid(t *testing.T) {
+	for _, expression := range []string{"", "* * *", "60 * * * *", "*/0 * * * *", "5-1 * * * *", "* * * foo *", "@every x", "@every -1s"} {
+		_, err := systemApi.ParseSchedule(expression)
+		assert.True(t, errors.Is(err, systemApi.ErrInvalidSchedule), expression)
+	}
+}
+
+// TestParseSchedule_Interval tests that fixed intervals are parsed.
+func TestParseSchedule_Interval(t *testing.T) {
+	schedule, err := systemApi.ParseSchedule("@every 90s")
+
+	assert.NoError(t, err)
+	assert.Equal(t, systemApi.IntervalSchedule{Interval: 90 * time.Second}, schedule)
+}
+
+// newSchedulerStore creates a store backed by an in-memory database.
+func newSchedulerStore(t *testing.T) store.Store {
+	tree := iavl.NewMutableTree(iavldb.NewMemDB(), 100, false, log.NewNopLogger())
+	schedulerStore, err := store.NewStoreImpl(systemApi.SchedulerStoreName, "", db.NewIAVLDatabase(tree))
+	assert.NoError(t, err)
+	return schedulerStore
+}
+
+// newSchedulerSystem creates a started system with a scheduler, the given operations and store.
+func newSchedulerSystem(t *testing.T, configuration *configApi.Configuration, schedulerStore store.Store,
+	operations ...component.ComponentInterface) (*systemApi.SystemImpl, *systemApi.SchedulerService, event.EventBusInterface) {
+	ctx := context.Background()
+	registrar := component.NewComponentRegistrar()
+	assert.NoError(t, registrar.RegisterFactory(ctx, systemApi.SchedulerServiceFactoryID, &systemApi.SchedulerServiceFactory{}))
+	factory := &orderedServiceFactory{services: map[string]component.ComponentInterface{}}
+	assert.NoError(t, registrar.RegisterFactory(ctx, "orderedFactory", factory))
+	for _, operation := range operations {
+		factory.services[operation.ID()] = operation
+		_, err := registrar.CreateComponent(ctx, &configApi.ComponentConfig{ID: operation.ID(), FactoryID: "orderedFactory"})
+		assert.NoError(t, err)
+	}
+
+	configuration.Services = append(configuration.Services, &configApi.ServiceConfiguration{
+		ComponentConfig: configApi.ComponentConfig{ID: systemApi.SchedulerServiceID, FactoryID: systemApi.SchedulerServiceFactoryID},
+	})
+
+	pluginManager := &mocks.MockPluginManager{}
+	pluginManager.On("Initialize", ctx, mock.Anything).Return(nil)
+	pluginManager.On("StartPlugins", ctx).Return(nil)
+	pluginManager.On("StopPlugins", mock.Anything).Return(nil)
+
+	multiStore := &mocks.MockMultiStore{}
+	multiStore.On("CreateStore", systemApi.SchedulerStoreName).Return(schedulerStore, false, nil)
+	multiStore.On("SaveVersion").Return([]byte{}, int64(0), nil)
+	multiStore.On("Close").Return(nil)
+
+	eventBus := event.NewSystemEventBus()
+	sys := systemApi.NewSystem(&mocks.MockLogger{}, eventBus, configuration, pluginManager, registrar, multiStore)
+	assert.NoError(t, sys.Initialize(ctx))
+	assert.NoError(t, sys.Start(ctx))
+
+	scheduler, err := registrar.GetComponent(systemApi.SchedulerServiceID)
+	assert.NoError(t, err)
+	return sys, scheduler.(*systemApi.SchedulerService), eventBus
+}
+
+// countingOperation returns an operation counting its executions.
+func countingOperation(id string, count *int32) *contextOperation {
+	return newContextOperation(id, func(ctx *context.Context) (*systemApi.SystemOperationOutput, error) {
+		atomic.AddInt32(count, 1)
+		return &systemApi.SystemOperationOutput{}, nil
+	})
+}
+
+// recordEvents subscribes to the given event type and returns the recorded events.
+func recordEvents(t *testing.T, eventBus event.EventBusInterface, eventType string) func() []systemApi.ScheduledRunEvent {
+	var mutex sync.Mutex
+	var events []systemApi.ScheduledRunEvent
+	_, err := eventBus.Subscribe(event.BusSubscriptionParams{
+		Topic: eventType,
+		EventHandler: func(e event.Event) {
+			mutex.Lock()
+			defer mutex.Unlock()
+			events = append(events, e.Data.(systemApi.ScheduledRunEvent))
+		},
+	})
+	assert.NoError(t, err)
+	return func() []systemApi.ScheduledRunEvent {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return append([]systemApi.ScheduledRunEvent(nil), events...)
+	}
+}
+
+// TestSchedulerService_RunsOnInterval tests that scheduled operations run repeatedly and that
+// every run is published and persisted.
+func TestSchedulerService_RunsOnInterval(t *testing.T) {
+	var count int32
+	schedulerStore := newSchedulerStore(t)
+	configuration := &configApi.Configuration{Schedules: []*configApi.ScheduleConfiguration{
+		{ID: "tick", OperationID: "count", Schedule: "@every 5ms"},
+	}}
+	sys, scheduler, eventBus := newSchedulerSystem(t, configuration, schedulerStore, countingOperation("count", &count))
+	succeeded := recordEvents(t, eventBus, systemApi.EventTypeScheduledOperationSucceeded)
+
+	assert.Eventually(t, func() bool { return len(succeeded()) >= 3 }, time.Second, time.Millisecond)
+	assert.NoError(t, sys.Stop(context.Background()))
+
+	runs := atomic.LoadInt32(&count)
+	assert.GreaterOrEqual(t, runs, int32(3))
+	assert.Equal(t, "tick", succeeded()[0].ScheduleID)
+	assert.False(t, succeeded()[0].CatchUp)
+
+	schedules := scheduler.Schedules()
+	assert.Len(t, schedules, 1)
+	assert.Equal(t, int(runs), schedules[0].Runs)
+
+	var persisted systemApi.ScheduledOperation
+	data, err := schedulerStore.Get([]byte("tick"))
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(data, &persisted))
+	assert.Equal(t, int(runs), persisted.Runs)
+	assert.Equal(t, "count", persisted.OperationID)
+}
+
+// TestSchedulerService_PublishesFailures tests that failed runs are recorded and published.
+func TestSchedulerService_PublishesFailures(t *testing.T) {
+	failing := newContextOperation("fail", func(ctx *context.Context) (*systemApi.SystemOperationOutput, error) {
+		return nil, errors.New("source unavailable")
+	})
+	configuration := &configApi.Configuration{Schedules: []*configApi.ScheduleConfiguration{
+		{ID: "extract", OperationID: "fail", Schedule: "@every 5ms"},
+	}}
+	sys, scheduler, eventBus := newSchedulerSystem(t, configuration, newSchedulerStore(t), failing)
+	failed := recordEvents(t, eventBus, systemApi.EventTypeScheduledOperationFailed)
+
+	assert.Eventually(t, func() bool { return len(failed()) >= 1 }, time.Second, time.Millisecond)
+	assert.NoError(t, sys.Stop(context.Background()))
+
+	assert.Equal(t, "source unavailable", failed()[0].Error)
+	assert.Equal(t, "source unavailable", scheduler.Schedules()[0].LastError)
+}
+
+// TestSchedulerService_SkipsOverlappingRuns tests that runs due while the previous run is still
+// executing are skipped instead of executed late.
+func TestSchedulerService_SkipsOverlappingRuns(t *testing.T) {
+	var count int32
+	slow := newContextOperation("slow", func(ctx *context.Context) (*systemApi.SystemOperationOutput, error) {
+		if atomic.AddInt32(&count, 1) == 1 {
+			time.Sleep(60 * time.Millisecond)
+		}
+		return &systemApi.SystemOperationOutput{}, nil
+	})
+	configuration := &configApi.Configuration{Schedules: []*configApi.ScheduleConfiguration{
+		{ID: "slow", OperationID: "slow", Schedule: "@every 20ms"},
+	}}
+	sys, _, eventBus := newSchedulerSystem(t, configuration, newSchedulerStore(t), slow)
+	succeeded := recordEvents(t, eventBus, systemApi.EventTypeScheduledOperationSucceeded)
+
+	assert.Eventually(t, func() bool { return len(succeeded()) >= 2 }, time.Second, time.Millisecond)
+	assert.NoError(t, sys.Stop(context.Background()))
+
+	// The second run is scheduled after the end of the first one
+	first, second := succeeded()[0], succeeded()[1]
+	assert.True(t, second.ScheduledAt.After(first.StartedAt.Add(first.Duration)))
+	assert.False(t, second.CatchUp)
+}
+
+// TestSchedulerService_CatchUp tests the policies applied to the runs missed while stopped.
+func TestSchedulerService_CatchUp(t *testing.T) {
+	tests := []struct {
+		policy systemApi.CatchUpPolicyType
+		runs   int32
+	}{
+		{systemApi.CatchUpSkip, 0},
+		{systemApi.CatchUpRunOnce, 1},
+		{systemApi.CatchUpRunAll, 3},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.policy), func(t *testing.T) {
+			var count int32
+			schedulerStore := newSchedulerStore(t)
+
+			// The scheduler was stopped before three hourly runs
+			data, err := json.Marshal(systemApi.ScheduledOperation{
+				ID: "hourly", OperationID: "count", Expression: "@every 1h", CatchUp: test.policy,
+				NextRun: time.Now().Add(-170 * time.Minute),
+			})
+			assert.NoError(t, err)
+			assert.NoError(t, schedulerStore.Set([]byte("hourly"), data))
+			_, _, err = schedulerStore.SaveVersion()
+			assert.NoError(t, err)
+
+			sys, scheduler, _ := newSchedulerSystem(t, &configApi.Configuration{}, schedulerStore, countingOperation("count", &count))
+
+			assert.Eventually(t, func() bool { return scheduler.Schedules()[0].Runs == int(test.runs) }, time.Second, time.Millisecond)
+			assert.NoError(t, sys.Stop(context.Background()))
+
+			assert.Equal(t, test.runs, atomic.LoadInt32(&count))
+			assert.True(t, scheduler.Schedules()[0].NextRun.After(time.Now()))
+		})
+	}
+}
+
+// TestSchedulerService_AddRemoveSchedule tests that schedules can be managed at runtime.
+func TestSchedulerService_AddRemoveSchedule(t *testing.T) {
+	var count int32
+	sys, scheduler, _ := newSchedulerSystem(t, &configApi.Configuration{}, newSchedulerStore(t), countingOperation("count", &count))
+	defer sys.Stop(context.Background())
+
+	err := scheduler.AddSchedule(&configApi.ScheduleConfiguration{ID: "tick", OperationID: "count", Schedule: "@every 5ms", CatchUp: "later"})
+	assert.True(t, errors.Is(err, systemApi.ErrInvalidCatchUpPolicy))
+
+	assert.NoError(t, scheduler.AddSchedule(&configApi.ScheduleConfiguration{ID: "tick", OperationID: "count", Schedule: "@every 5ms"}))
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&count) > 0 }, time.Second, time.Millisecond)
+
+	assert.NoError(t, scheduler.RemoveSchedule("tick"))
+	assert.Empty(t, scheduler.Schedules())
+	assert.True(t, errors.Is(scheduler.RemoveSchedule("tick"), systemApi.ErrScheduleNotFound))
+}
+
+// TestSchedulerService_RemovedFromConfiguration tests that persisted schedules removed from the
+// configuration are removed on restart, unlike the schedules added at runtime.
+func TestSchedulerService_RemovedFromConfiguration(t *testing.T) {
+	var count int32
+	schedulerStore := newSchedulerStore(t)
+	configuration := &configApi.Configuration{Schedules: []*configApi.ScheduleConfiguration{
+		{ID: "hourly", OperationID: "count", Schedule: "@every 1h"},
+		{ID: "daily", OperationID: "count", Schedule: "@every 24h"},
+	}}
+	sys, scheduler, _ := newSchedulerSystem(t, configuration, schedulerStore, countingOperation("count", &count))
+	assert.NoError(t, scheduler.AddSchedule(&configApi.ScheduleConfiguration{ID: "added", OperationID: "count", Schedule: "@every 1h"}))
+	assert.NoError(t, sys.Stop(context.Background()))
+
+	// Restart with the daily schedule removed from the configuration
+	configuration = &configApi.Configuration{Schedules: []*configApi.ScheduleConfiguration{
+		{ID: "hourly", OperationID: "count", Schedule: "@every 1h"},
+	}}
+	sys, scheduler, _ = newSchedulerSystem(t, configuration, schedulerStore, countingOperation("count", &count))
+	defer sys.Stop(context.Background())
+
+	schedules := scheduler.Schedules()
+	assert.Len(t, schedules, 2)
+	assert.Equal(t, "added", schedules[0].ID)
+	assert.False(t, schedules[0].Configured)
+	assert.Equal(t, "hourly", schedules[1].ID)
+	assert.True(t, schedules[1].Configured)
+	data, err := schedulerStore.Get([]byte("daily"))
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+}
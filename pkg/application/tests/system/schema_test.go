@@ -0,0 +1,127 @@
+package system_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	configApi "github.com/edward1christian/block-forge/pkg/application/config"
+	systemApi "github.com/edward1christian/block-forge/pkg/application/system"
+)
+
+// buildRequest is the input of the schemaOperation.
+type buildRequest struct {
+	Project string `json:"project" valid:"required" description:"Name of the project"`
+	Target  string `json:"target,omitempty" valid:"in(debug|release)"`
+	secret  string
+}
+
+// schemaOperation is an operation declaring its input and output schemas.
+type schemaOperation struct {
+	funcOperation
+}
+
+// InputSchema returns the schema of the input data.
+func (o *schemaOperation) InputSchema() *systemApi.OperationSchema {
+	return systemApi.NewOperationSchema[*buildRequest]("Build request")
+}
+
+// OutputSchema returns the schema of the output data.
+func (o *schemaOperation) OutputSchema() *systemApi.OperationSchema {
+	return systemApi.NewOperationSchema[string]("Path of the build artifact")
+}
+
+// newSchemaOperation creates a schemaOperation executing the given function.
+func newSchemaOperation(fn func(input *systemApi.SystemOperationInput) (*systemApi.SystemOperationOutput, error)) *schemaOperation {
+	return &schemaOperation{funcOperation: *newFuncOperation("build", fn)}
+}
+
+// TestSystemImpl_ExecuteOperation_ValidInput tests that valid input is passed to the operation.
+func TestSystemImpl_ExecuteOperation_ValidInput(t *testing.T) {
+	sys := newOrderedSystem(t, &configApi.Configuration{}, newSchemaOperation(
+		func(input *systemApi.SystemOperationInput) (*systemApi.SystemOperationOutput, error) {
+			request, err := systemApi.InputAs[*buildRequest](input)
+			if err != nil {
+				return nil, err
+			}
+			return &systemApi.SystemOperationOutput{Data: "/out/" + request.Project}, nil
+		}))
+
+	output, err := sys.ExecuteOperation(context.Background(), "build",
+		&systemApi.SystemOperationInput{Data: &buildRequest{Project: "nova", Target: "release"}})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "/out/nova", output.Data)
+}
+
+// TestSystemImpl_ExecuteOperation_InvalidInput tests that invalid input is rejected before execution.
+func TestSystemImpl_ExecuteOperation_InvalidInput(t *testing.T) {
+	executed := false
+	sys := newOrderedSystem(t, &configApi.Configuration{}, newSchemaOperation(
+		func(input *systemApi.SystemOperationInput) (*systemApi.SystemOperationOutput, error) {
+			executed = true
+			return &systemApi.SystemOperationOutput{Data: ""}, nil
+		}))
+	ctx := context.Background()
+
+	for _, data := range []interface{}{nil, "nova", &buildRequest{}, &buildRequest{Project: "nova", Target: "beta"}} {
+		_, err := sys.ExecuteOperation(ctx, "build", &systemApi.SystemOperationInput{Data: data})
+
+		var validationErr *systemApi.OperationValidationError
+		assert.True(t, errors.As(err, &validationErr), "data %v", data)
+		assert.True(t, errors.Is(err, systemApi.ErrInvalidOperationInput))
+		assert.Equal(t, "build", validationErr.OperationID)
+	}
+	assert.False(t, executed)
+}
+
+// TestSystemImpl_ExecuteOperation_InvalidOutput tests that output not matching the schema is reported.
+func TestSystemImpl_ExecuteOperation_InvalidOutput(t *testing.T) {
+	sys := newOrderedSystem(t, &configApi.Configuration{}, newSchemaOperation(
+		func(input *systemApi.SystemOperationInput) (*systemApi.SystemOperationOutput, error) {
+			return &systemApi.SystemOperationOutput{Data: 42}, nil
+		}))
+
+	output, err := sys.ExecuteOperation(context.Background(), "build",
+		&systemApi.SystemOperationInput{Data: &buildRequest{Project: "nova"}})
+
+	assert.Nil(t, output)
+	assert.True(t, errors.Is(err, systemApi.ErrInvalidOperationOutput))
+	assert.Contains(t, err.Error(), "invalid output for operation build")
+}
+
+// TestOperationSchema_Fields tests that the fields of struct schemas can be introspected.
+func TestOperationSchema_Fields(t *testing.T) {
+	schema := newSchemaOperation(nil).InputSchema()
+
+	assert.Equal(t, "*system_test.buildRequest", schema.TypeName())
+	assert.Equal(t, []systemApi.SchemaField{
+		{Name: "project", Type: "string", Required: true, Description: "Name of the project"},
+		{Name: "target", Type: "string"},
+	}, schema.Fields())
+	assert.Nil(t, systemApi.NewOperationSchema[string]("").Fields())
+}
+
+// TestOperationSchema_Optional tests that optional schemas accept nil data.
+func TestOperationSchema_Optional(t *testing.T) {
+	schema := systemApi.NewOperationSchema[string]("").Optional()
+
+	assert.NoError(t, schema.Validate(nil))
+	assert.Error(t, schema.Validate(1))
+}
+
+// TestInputAs tests that input data is converted to the requested type.
+func TestInputAs(t *testing.T) {
+	value, err := systemApi.InputAs[string](&systemApi.SystemOperationInput{Data: "nova"})
+	assert.NoError(t, err)
+	assert.Equal(t, "nova", value)
+
+	_, err = systemApi.InputAs[string](&systemApi.SystemOperationInput{Data: 1})
+	assert.True(t, errors.Is(err, systemApi.ErrInvalidOperationInput))
+	assert.EqualError(t, err, "invalid operation input: expected string, got int")
+
+	_, err = systemApi.InputAs[string](nil)
+	assert.Error(t, err)
+}
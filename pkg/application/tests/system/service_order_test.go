@@ -0,0 +1,202 @@
+package system_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	"github.com/edward1christian/block-forge/pkg/application/component"
+	configApi "github.com/edward1christian/block-forge/pkg/application/config"
+	"github.com/edward1christian/block-forge/pkg/application/mocks"
+	systemApi "github.com/edward1christian/block-forge/pkg/application/system"
+)
+
+// orderedService is a service that records when it is started and stopped.
+type orderedService struct {
+	systemApi.BaseSystemService
+	deps     []string
+	initErr  error
+	startErr error
+	events   *[]string
+}
+
+// newOrderedService creates a new orderedService recording into events.
+func newOrderedService(id string, events *[]string, deps ...string) *orderedService {
+	return &orderedService{
+		BaseSystemService: *systemApi.NewBaseSystemService(id, id, ""),
+		deps:              deps,
+		events:            events,
+	}
+}
+
+// Dependencies returns the IDs of the components the service depends on.
+func (s *orderedService) Dependencies() []string {
+	return s.deps
+}
+
+// Initialize initializes the service, unless it fails to initialize.
+func (s *orderedService) Initialize(ctx *context.Context, system systemApi.SystemInterface) error {
+	if s.initErr != nil {
+		return s.initErr
+	}
+	return s.BaseSystemService.Initialize(ctx, system)
+}
+
+// Start records the start of the service.
+func (s *orderedService) Start(ctx *context.Context) error {
+	if s.startErr != nil {
+		return s.startErr
+	}
+	*s.events = append(*s.events, "start:"+s.ID())
+	return nil
+}
+
+// Stop records the stop of the service.
+func (s *orderedService) Stop(ctx *context.Context) error {
+	*s.events = append(*s.events, "stop:"+s.ID())
+	return nil
+}
+
+// orderedServiceFactory returns the pre-built service matching the requested ID.
+type orderedServiceFactory struct {
+	services map[string]component.ComponentInterface
+}
+
+// CreateComponent returns the service with the configured ID.
+func (f *orderedServiceFactory) CreateComponent(config *configApi.ComponentConfig) (component.ComponentInterface, error) {
+	return f.services[config.ID], nil
+}
+
+// newOrderedSystem creates a system with the given services registered.
+func newOrderedSystem(t *testing.T, configuration *configApi.Configuration, services ...component.ComponentInterface) *systemApi.SystemImpl {
+	ctx := context.Background()
+	registrar := component.NewComponentRegistrar()
+	factory := &orderedServiceFactory{services: map[string]component.ComponentInterface{}}
+	assert.NoError(t, registrar.RegisterFactory(ctx, "orderedFactory", factory))
+
+	for _, service := range services {
+		factory.services[service.ID()] = service
+		_, err := registrar.CreateComponent(ctx, &configApi.ComponentConfig{ID: service.ID(), FactoryID: "orderedFactory"})
+		assert.NoError(t, err)
+	}
+
+	pluginManager := &mocks.MockPluginManager{}
+	pluginManager.On("Initialize", ctx, mock.Anything).Return(nil)
+	pluginManager.On("StartPlugins", ctx).Return(nil)
+	pluginManager.On("StopPlugins", mock.Anything).Return(nil)
+
+	sys := systemApi.NewSystem(&mocks.MockLogger{}, nil, configuration, pluginManager, registrar, nil)
+	assert.NoError(t, sys.Initialize(ctx))
+	return sys
+}
+
+// TestSortServicesByDependencies tests that services are ordered after their dependencies.
+func TestSortServicesByDependencies(t *testing.T) {
+	var events []string
+	store := newOrderedService("store", &events)
+	indexer := newOrderedService("indexer", &events, "store")
+	etl := newOrderedService("etl", &events, "indexer", "store")
+
+	ordered, err := systemApi.SortServicesByDependencies(
+		[]systemApi.SystemServiceInterface{etl, indexer, store},
+		map[string][]string{"etl": etl.deps, "indexer": indexer.deps})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []systemApi.SystemServiceInterface{store, indexer, etl}, ordered)
+}
+
+// TestSortServicesByDependencies_Cycle tests that dependency cycles are rejected.
+func TestSortServicesByDependencies_Cycle(t *testing.T) {
+	var events []string
+	a := newOrderedService("a", &events, "b")
+	b := newOrderedService("b", &events, "a")
+
+	_, err := systemApi.SortServicesByDependencies(
+		[]systemApi.SystemServiceInterface{a, b},
+		map[string][]string{"a": a.deps, "b": b.deps})
+
+	assert.True(t, errors.Is(err, systemApi.ErrServiceDependencyCycle))
+	assert.Contains(t, err.Error(), "a -> b -> a")
+}
+
+// TestSystemImpl_Start_DependencyOrder tests that the system starts services in dependency
+// order and stops them in reverse order.
+func TestSystemImpl_Start_DependencyOrder(t *testing.T) {
+	ctx := context.Background()
+	var events []string
+	configuration := &configApi.Configuration{
+		Services: []*configApi.ServiceConfiguration{
+			{ComponentConfig: configApi.ComponentConfig{ID: "manager"}, DependsOn: []string{"store"}},
+		},
+	}
+
+	sys := newOrderedSystem(t, configuration,
+		newOrderedService("manager", &events),
+		newOrderedService("store", &events),
+		newOrderedService("api", &events, "manager"))
+
+	assert.NoError(t, sys.Start(ctx))
+	assert.Equal(t, []string{"start:store", "start:manager", "start:api"}, events)
+
+	events = events[:0]
+	assert.NoError(t, sys.Stop(ctx))
+	assert.Equal(t, []string{"stop:api", "stop:manager", "stop:store"}, events)
+}
+
+// TestSystemImpl_Start_DependencyCycle tests that the system refuses to start on a dependency cycle.
+func TestSystemImpl_Start_DependencyCycle(t *testing.T) {
+	var events []string
+	sys := newOrderedSystem(t, &configApi.Configuration{},
+		newOrderedService("a", &events, "b"),
+		newOrderedService("b", &events, "a"))
+
+	err := sys.Start(context.Background())
+	assert.True(t, errors.Is(err, systemApi.ErrServiceDependencyCycle))
+	assert.Empty(t, events)
+}
+
+// TestSystemImpl_Start_MissingDependency tests that the system refuses to start when a dependency is missing.
+func TestSystemImpl_Start_MissingDependency(t *testing.T) {
+	var events []string
+	sys := newOrderedSystem(t, &configApi.Configuration{},
+		newOrderedService("a", &events, "missing"))
+
+	err := sys.Start(context.Background())
+	assert.True(t, errors.Is(err, systemApi.ErrServiceDependencyNotFound))
+}
+
+// TestSystemImpl_Start_RollsBackOnFailure tests that started services are stopped if a later service fails.
+func TestSystemImpl_Start_RollsBackOnFailure(t *testing.T) {
+	var events []string
+	failing := newOrderedService("b", &events, "a")
+	failing.startErr = errors.New("boom")
+
+	sys := newOrderedSystem(t, &configApi.Configuration{},
+		newOrderedService("a", &events), failing)
+
+	err := sys.Start(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, []string{"start:a", "stop:a"}, events)
+}
+
+// TestSystemImpl_Start_PluginFailure tests that started services are stopped if the plugins fail to start.
+func TestSystemImpl_Start_PluginFailure(t *testing.T) {
+	ctx := context.Background()
+	var events []string
+	sys := newOrderedSystem(t, &configApi.Configuration{},
+		newOrderedService("a", &events), newOrderedService("b", &events, "a"))
+	pluginManager := sys.PluginManager().(*mocks.MockPluginManager)
+	pluginManager.ExpectedCalls = nil
+	pluginManager.On("StartPlugins", ctx).Return(errors.New("plugin failed"))
+	pluginManager.On("StopPlugins", ctx).Return(nil)
+
+	err := sys.Start(ctx)
+
+	assert.Error(t, err)
+	assert.Equal(t, []string{"start:a", "start:b", "stop:b", "stop:a"}, events)
+	pluginManager.AssertCalled(t, "StopPlugins", ctx)
+	assert.True(t, errors.Is(sys.Stop(ctx), systemApi.ErrSystemNotStarted))
+}
@@ -0,0 +1,206 @@
+package system_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	"github.com/edward1christian/block-forge/pkg/application/component"
+	configApi "github.com/edward1christian/block-forge/pkg/application/config"
+	"github.com/edward1christian/block-forge/pkg/application/mocks"
+	systemApi "github.com/edward1christian/block-forge/pkg/application/system"
+)
+
+// startedOperation returns an operation signaling when it starts and blocking until released.
+func startedOperation(id string, started chan<- struct{}, release <-chan struct{}) *contextOperation {
+	return newContextOperation(id, func(ctx *context.Context) (*systemApi.SystemOperationOutput, error) {
+		started <- struct{}{}
+		<-release
+		return &systemApi.SystemOperationOutput{Data: "done"}, nil
+	})
+}
+
+// TestSystemImpl_Stop_DrainsOperations tests that the system rejects new operations and
+// waits for in-flight operations before stopping.
+func TestSystemImpl_Stop_DrainsOperations(t *testing.T) {
+	ctx := context.Background()
+	started, release := make(chan struct{}), make(chan struct{})
+	sys := newOrderedSystem(t, &configApi.Configuration{}, startedOperation("build", started, release))
+	assert.NoError(t, sys.Start(ctx))
+
+	result := make(chan error)
+	go func() {
+		_, err := sys.ExecuteOperation(ctx, "build", &systemApi.SystemOperationInput{})
+		result <- err
+	}()
+	<-started
+
+	stopped := make(chan error)
+	go func() { stopped <- sys.Stop(ctx) }()
+
+	assert.Eventually(t, func() bool {
+		_, err := sys.ExecuteOperation(ctx, "build", &systemApi.SystemOperationInput{})
+		return errors.Is(err, systemApi.ErrSystemShuttingDown)
+	}, time.Second, time.Millisecond)
+
+	select {
+	case <-stopped:
+		t.Fatal("system stopped before the in-flight operation completed")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	close(release)
+	assert.NoError(t, <-result)
+	assert.NoError(t, <-stopped)
+}
+
+// TestSystemImpl_Stop_DeadlineExceeded tests that the shutdown stops waiting at the deadline
+// of the context and cancels the running jobs.
+func TestSystemImpl_Stop_DeadlineExceeded(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	sys := newOrderedSystem(t, &configApi.Configuration{}, blockingOperation("build", release))
+	assert.NoError(t, sys.Start(context.Background()))
+
+	job, err := sys.ExecuteOperationAsync(context.Background(), "build", &systemApi.SystemOperationInput{})
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool { return job.Status() == systemApi.JobRunningType }, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err = sys.Stop(ctx)
+
+	var shutdownErr *systemApi.ShutdownError
+	assert.True(t, errors.As(err, &shutdownErr))
+	assert.True(t, errors.Is(err, systemApi.ErrShutdownDeadlineExceeded))
+	assert.Equal(t, "operations", shutdownErr.Failures[0].Component)
+
+	_, err = job.Wait()
+	assert.Error(t, err)
+	assert.Equal(t, systemApi.JobCanceledType, job.Status())
+}
+
+// TestSystemImpl_Stop_DeadlineExceeded_KeepsStoreOpen tests that the MultiStore is not closed while
+// operations are still running, and that the system can be started again meanwhile.
+func TestSystemImpl_Stop_DeadlineExceeded_KeepsStoreOpen(t *testing.T) {
+	ctx := context.Background()
+	started, release := make(chan struct{}, 2), make(chan struct{})
+	registrar := component.NewComponentRegistrar()
+	factory := &orderedServiceFactory{services: map[string]component.ComponentInterface{
+		"build": startedOperation("build", started, release),
+	}}
+	assert.NoError(t, registrar.RegisterFactory(ctx, "orderedFactory", factory))
+	_, err := registrar.CreateComponent(ctx, &configApi.ComponentConfig{ID: "build", FactoryID: "orderedFactory"})
+	assert.NoError(t, err)
+	pluginManager := &mocks.MockPluginManager{}
+	pluginManager.On("Initialize", mock.Anything, mock.Anything).Return(nil)
+	pluginManager.On("StartPlugins", mock.Anything).Return(nil)
+	pluginManager.On("StopPlugins", mock.Anything).Return(nil)
+	multiStore := &mocks.MockMultiStore{}
+	multiStore.On("SaveVersion").Return([]byte{}, int64(0), nil)
+	multiStore.On("Close").Return(nil)
+	sys := systemApi.NewSystem(&mocks.MockLogger{}, nil, &configApi.Configuration{}, pluginManager, registrar, multiStore)
+	assert.NoError(t, sys.Initialize(ctx))
+	assert.NoError(t, sys.Start(ctx))
+
+	result := make(chan error, 2)
+	go func() {
+		_, err := sys.ExecuteOperation(ctx, "build", &systemApi.SystemOperationInput{})
+		result <- err
+	}()
+	<-started
+
+	deadlineCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	err = sys.Stop(deadlineCtx)
+
+	assert.True(t, errors.Is(err, systemApi.ErrShutdownDeadlineExceeded))
+	multiStore.AssertNotCalled(t, "Close")
+
+	// Start the system again while the operation is still running
+	assert.NoError(t, sys.Initialize(ctx))
+	assert.NoError(t, sys.Start(ctx))
+	go func() {
+		_, err := sys.ExecuteOperation(ctx, "build", &systemApi.SystemOperationInput{})
+		result <- err
+	}()
+	<-started
+	close(release)
+	assert.NoError(t, <-result)
+	assert.NoError(t, <-result)
+	assert.NoError(t, sys.Stop(ctx))
+	multiStore.AssertCalled(t, "Close")
+}
+
+// TestSystemImpl_Stop_StopsStartedServices tests that the services started with StartService
+// are stopped with the system.
+func TestSystemImpl_Stop_StopsStartedServices(t *testing.T) {
+	ctx := context.Background()
+	var events []string
+	autoStart := false
+	sys := newOrderedSystem(t, &configApi.Configuration{
+		Services: []*configApi.ServiceConfiguration{{ComponentConfig: configApi.ComponentConfig{ID: "manual"}, AutoStart: &autoStart}},
+	}, newOrderedService("a", &events), newOrderedService("manual", &events))
+	assert.NoError(t, sys.Start(ctx))
+	assert.NoError(t, sys.StartService(ctx, "manual"))
+
+	err := sys.Stop(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"start:a", "start:manual", "stop:manual", "stop:a"}, events)
+}
+
+// TestSystemImpl_Stop_AggregatesErrors tests that every failed shutdown step is reported.
+func TestSystemImpl_Stop_AggregatesErrors(t *testing.T) {
+	ctx := context.Background()
+	var events []string
+	failing := newOrderedService("store", &events)
+	registrar := component.NewComponentRegistrar()
+	factory := &orderedServiceFactory{services: map[string]component.ComponentInterface{"store": &stopFailingService{failing}}}
+	assert.NoError(t, registrar.RegisterFactory(ctx, "orderedFactory", factory))
+	_, err := registrar.CreateComponent(ctx, &configApi.ComponentConfig{ID: "store", FactoryID: "orderedFactory"})
+	assert.NoError(t, err)
+
+	pluginErr, saveErr := errors.New("plugin stop failed"), errors.New("disk full")
+	pluginManager := &mocks.MockPluginManager{}
+	pluginManager.On("Initialize", ctx, mock.Anything).Return(nil)
+	pluginManager.On("StartPlugins", ctx).Return(nil)
+	pluginManager.On("StopPlugins", ctx).Return(pluginErr)
+	multiStore := &mocks.MockMultiStore{}
+	multiStore.On("SaveVersion").Return([]byte{}, int64(0), saveErr)
+	multiStore.On("Close").Return(nil)
+
+	sys := systemApi.NewSystem(&mocks.MockLogger{}, nil, &configApi.Configuration{}, pluginManager, registrar, multiStore)
+	assert.NoError(t, sys.Initialize(ctx))
+	assert.NoError(t, sys.Start(ctx))
+
+	err = sys.Stop(ctx)
+
+	var shutdownErr *systemApi.ShutdownError
+	assert.True(t, errors.As(err, &shutdownErr))
+	assert.Len(t, shutdownErr.Failures, 3)
+	assert.True(t, errors.Is(err, pluginErr))
+	assert.True(t, errors.Is(err, errServiceStop))
+	assert.True(t, errors.Is(err, saveErr))
+	multiStore.AssertCalled(t, "Close")
+
+	// The system is stopped even though the shutdown failed
+	assert.ErrorIs(t, sys.Stop(ctx), systemApi.ErrSystemNotStarted)
+}
+
+// errServiceStop is the error returned by stopFailingService.
+var errServiceStop = errors.New("service stop failed")
+
+// stopFailingService is a service that fails to stop.
+type stopFailingService struct {
+	*orderedService
+}
+
+// Stop returns errServiceStop.
+func (s *stopFailingService) Stop(ctx *context.Context) error {
+	return errServiceStop
+}
@@ -0,0 +1,351 @@
+package system_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	"github.com/edward1christian/block-forge/pkg/application/common/event"
+	"github.com/edward1christian/block-forge/pkg/application/component"
+	configApi "github.com/edward1christian/block-forge/pkg/application/config"
+	"github.com/edward1christian/block-forge/pkg/application/mocks"
+	systemApi "github.com/edward1christian/block-forge/pkg/application/system"
+)
+
+// exitingService is a service that reports its exit through a Done channel.
+type exitingService struct {
+	systemApi.BaseSystemService
+	mutex    sync.Mutex
+	starts   int
+	stops    int
+	startErr error
+	live     bool
+	done     chan error
+}
+
+// newExitingService creates a new exitingService that is already running.
+func newExitingService(id string) *exitingService {
+	return &exitingService{
+		BaseSystemService: *systemApi.NewBaseSystemService(id, id, ""),
+		starts:            1,
+		live:              true,
+		done:              make(chan error, 1),
+	}
+}
+
+// Start starts the service, renewing its Done channel.
+func (s *exitingService) Start(ctx *context.Context) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.startErr != nil {
+		return s.startErr
+	}
+	s.starts++
+	s.live = true
+	s.done = make(chan error, 1)
+	return nil
+}
+
+// Stop stops the service.
+func (s *exitingService) Stop(ctx *context.Context) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.stops++
+	return nil
+}
+
+// Done returns the channel receiving the exit error of the service.
+func (s *exitingService) Done() <-chan error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.done
+}
+
+// exit makes the service exit with the given error.
+func (s *exitingService) exit(err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err == nil {
+		close(s.done)
+		return
+	}
+	s.done <- err
+}
+
+// counts returns the number of times the service was started and stopped.
+func (s *exitingService) counts() (int, int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.starts, s.stops
+}
+
+// unhealthyService is an exitingService that is watched through its health only.
+type unhealthyService struct {
+	*exitingService
+}
+
+// Done hides the Done channel of the embedded service.
+func (s *unhealthyService) Done() {}
+
+// Health reports whether the service is live.
+func (s *unhealthyService) Health(ctx *context.Context) component.HealthStatus {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return component.HealthStatus{Live: s.live, Ready: s.live, Reason: "deadlocked"}
+}
+
+// recordedEvents collects the events published on an event bus.
+type recordedEvents struct {
+	mutex  sync.Mutex
+	events []event.Event
+}
+
+// types returns the types of the recorded events.
+func (r *recordedEvents) types() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	types := make([]string, 0, len(r.events))
+	for _, e := range r.events {
+		types = append(types, e.Type)
+	}
+	return types
+}
+
+// newSupervisor creates a supervisor whose system publishes events into the returned recorder.
+func newSupervisor(t *testing.T) (*systemApi.Supervisor, *recordedEvents) {
+	bus := event.NewSystemEventBus()
+	recorded := &recordedEvents{}
+	for _, topic := range []string{
+		systemApi.EventTypeServiceFailed,
+		systemApi.EventTypeServiceRestarted,
+		systemApi.EventTypeServiceRestartAbandoned,
+	} {
+		_, err := bus.Subscribe(event.BusSubscriptionParams{
+			Topic: topic,
+			EventHandler: func(e event.Event) {
+				recorded.mutex.Lock()
+				defer recorded.mutex.Unlock()
+				recorded.events = append(recorded.events, e)
+			},
+		})
+		assert.NoError(t, err)
+	}
+
+	sys := systemApi.NewSystem(&mocks.MockLogger{}, bus, &configApi.Configuration{}, nil, nil, nil)
+	supervisor := systemApi.NewSupervisor(sys)
+	t.Cleanup(supervisor.Stop)
+	return supervisor, recorded
+}
+
+// TestRestartPolicy_Delay tests that the restart delay grows exponentially up to the maximum.
+func TestRestartPolicy_Delay(t *testing.T) {
+	policy := systemApi.RestartPolicy{Backoff: 10 * time.Millisecond, MaxBackoff: 50 * time.Millisecond}
+
+	assert.Equal(t, 10*time.Millisecond, policy.Delay(1))
+	assert.Equal(t, 20*time.Millisecond, policy.Delay(2))
+	assert.Equal(t, 40*time.Millisecond, policy.Delay(3))
+	assert.Equal(t, 50*time.Millisecond, policy.Delay(4))
+	assert.Equal(t, systemApi.DefaultRestartBackoff, systemApi.RestartPolicy{}.Delay(1))
+}
+
+// TestRestartPolicyFromConfig tests that restart policies are read from the service configuration.
+func TestRestartPolicyFromConfig(t *testing.T) {
+	policy, err := systemApi.RestartPolicyFromConfig(&configApi.ServiceConfiguration{
+		RestartPolicy:       "on-failure",
+		MaxRestartAttempts:  3,
+		RetryInterval:       configApi.Duration(time.Second),
+		MaxRetryInterval:    configApi.Duration(time.Minute),
+		RestartStablePeriod: configApi.Duration(time.Hour),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, systemApi.RestartPolicy{
+		Policy:       systemApi.RestartOnFailure,
+		MaxAttempts:  3,
+		Backoff:      time.Second,
+		MaxBackoff:   time.Minute,
+		StablePeriod: time.Hour,
+	}, policy)
+
+	policy, err = systemApi.RestartPolicyFromConfig(&configApi.ServiceConfiguration{})
+	assert.NoError(t, err)
+	assert.Equal(t, systemApi.RestartNever, policy.Policy)
+
+	_, err = systemApi.RestartPolicyFromConfig(&configApi.ServiceConfiguration{RestartPolicy: "sometimes"})
+	assert.True(t, errors.Is(err, systemApi.ErrInvalidRestartPolicy))
+}
+
+// TestSupervisor_RestartsFailedService tests that a failed service is restarted and events are published.
+func TestSupervisor_RestartsFailedService(t *testing.T) {
+	supervisor, recorded := newSupervisor(t)
+	service := newExitingService("service")
+	policy := systemApi.RestartPolicy{Policy: systemApi.RestartOnFailure, Backoff: time.Millisecond}
+	assert.NoError(t, supervisor.Supervise(context.Background(), service, policy))
+
+	service.exit(errors.New("crashed"))
+	assert.Eventually(t, func() bool { starts, _ := service.counts(); return starts == 2 }, time.Second, time.Millisecond)
+
+	service.exit(errors.New("crashed again"))
+	assert.Eventually(t, func() bool { starts, _ := service.counts(); return starts == 3 }, time.Second, time.Millisecond)
+	assert.Eventually(t, func() bool { return len(recorded.types()) == 4 }, time.Second, time.Millisecond)
+
+	assert.Equal(t, 2, supervisor.Attempts("service"))
+	assert.Equal(t, []string{
+		systemApi.EventTypeServiceFailed,
+		systemApi.EventTypeServiceRestarted,
+		systemApi.EventTypeServiceFailed,
+		systemApi.EventTypeServiceRestarted,
+	}, recorded.types())
+}
+
+// TestSupervisor_OnFailureIgnoresCleanExit tests that a clean exit is not restarted by the on-failure policy.
+func TestSupervisor_OnFailureIgnoresCleanExit(t *testing.T) {
+	supervisor, recorded := newSupervisor(t)
+	service := newExitingService("service")
+	policy := systemApi.RestartPolicy{Policy: systemApi.RestartOnFailure, Backoff: time.Millisecond}
+	assert.NoError(t, supervisor.Supervise(context.Background(), service, policy))
+
+	service.exit(nil)
+
+	assert.Never(t, func() bool { starts, _ := service.counts(); return starts > 1 }, 50*time.Millisecond, time.Millisecond)
+	assert.Empty(t, recorded.types())
+}
+
+// TestSupervisor_AlwaysRestartsCleanExit tests that a clean exit is restarted by the always policy.
+func TestSupervisor_AlwaysRestartsCleanExit(t *testing.T) {
+	supervisor, _ := newSupervisor(t)
+	service := newExitingService("service")
+	policy := systemApi.RestartPolicy{Policy: systemApi.RestartAlways, Backoff: time.Millisecond}
+	assert.NoError(t, supervisor.Supervise(context.Background(), service, policy))
+
+	service.exit(nil)
+
+	assert.Eventually(t, func() bool { starts, _ := service.counts(); return starts == 2 }, time.Second, time.Millisecond)
+}
+
+// TestSupervisor_AbandonsAfterMaxAttempts tests that the supervisor gives up after the maximum attempts.
+func TestSupervisor_AbandonsAfterMaxAttempts(t *testing.T) {
+	supervisor, recorded := newSupervisor(t)
+	service := newExitingService("service")
+	service.startErr = errors.New("cannot start")
+	policy := systemApi.RestartPolicy{Policy: systemApi.RestartOnFailure, MaxAttempts: 2, Backoff: time.Millisecond}
+	assert.NoError(t, supervisor.Supervise(context.Background(), service, policy))
+
+	service.exit(errors.New("crashed"))
+
+	assert.Eventually(t, func() bool { return len(recorded.types()) == 4 }, time.Second, time.Millisecond)
+	assert.Equal(t, []string{
+		systemApi.EventTypeServiceFailed,
+		systemApi.EventTypeServiceFailed,
+		systemApi.EventTypeServiceFailed,
+		systemApi.EventTypeServiceRestartAbandoned,
+	}, recorded.types())
+}
+
+// TestSupervisor_ResetsAttemptsWhenStable tests that the restart attempts of a service are reset
+// once it ran for the stable period, so that only failures close together are abandoned.
+func TestSupervisor_ResetsAttemptsWhenStable(t *testing.T) {
+	supervisor, recorded := newSupervisor(t)
+	service := newExitingService("service")
+	policy := systemApi.RestartPolicy{
+		Policy:       systemApi.RestartOnFailure,
+		MaxAttempts:  1,
+		Backoff:      time.Millisecond,
+		StablePeriod: 20 * time.Millisecond,
+	}
+	assert.NoError(t, supervisor.Supervise(context.Background(), service, policy))
+
+	service.exit(errors.New("crashed"))
+	assert.Eventually(t, func() bool { starts, _ := service.counts(); return starts == 2 }, time.Second, time.Millisecond)
+
+	// The service ran for the stable period before crashing again
+	time.Sleep(40 * time.Millisecond)
+	service.exit(errors.New("crashed after a while"))
+	assert.Eventually(t, func() bool { starts, _ := service.counts(); return starts == 3 }, time.Second, time.Millisecond)
+	assert.Equal(t, 1, supervisor.Attempts("service"))
+
+	// A crash right after the restart exceeds the attempts
+	service.exit(errors.New("crashed right away"))
+	assert.Eventually(t, func() bool {
+		types := recorded.types()
+		return len(types) > 0 && types[len(types)-1] == systemApi.EventTypeServiceRestartAbandoned
+	}, time.Second, time.Millisecond)
+	starts, _ := service.counts()
+	assert.Equal(t, 3, starts)
+}
+
+// TestSupervisor_RestartsUnhealthyService tests that a service that is no longer live is stopped and started.
+func TestSupervisor_RestartsUnhealthyService(t *testing.T) {
+	supervisor, _ := newSupervisor(t)
+	supervisor.HealthCheckInterval = time.Millisecond
+	service := &unhealthyService{exitingService: newExitingService("service")}
+	policy := systemApi.RestartPolicy{Policy: systemApi.RestartOnFailure, Backoff: time.Millisecond}
+	assert.NoError(t, supervisor.Supervise(context.Background(), service, policy))
+
+	service.mutex.Lock()
+	service.live = false
+	service.mutex.Unlock()
+
+	assert.Eventually(t, func() bool {
+		starts, stops := service.counts()
+		return starts == 2 && stops == 1
+	}, time.Second, time.Millisecond)
+}
+
+// TestSupervisor_NotSupervisable tests that services that cannot be watched are rejected.
+func TestSupervisor_NotSupervisable(t *testing.T) {
+	supervisor, _ := newSupervisor(t)
+	var events []string
+
+	err := supervisor.Supervise(context.Background(), newOrderedService("service", &events), systemApi.RestartPolicy{})
+	assert.True(t, errors.Is(err, systemApi.ErrServiceNotSupervisable))
+}
+
+// TestSystemImpl_Start_SupervisesServices tests that the system supervises services with a restart policy.
+func TestSystemImpl_Start_SupervisesServices(t *testing.T) {
+	ctx := context.Background()
+	service := newExitingService("service")
+	configuration := &configApi.Configuration{
+		Services: []*configApi.ServiceConfiguration{{
+			ComponentConfig: configApi.ComponentConfig{ID: "service"},
+			RestartPolicy:   "on-failure",
+			RetryInterval:   configApi.Duration(time.Millisecond),
+		}},
+	}
+	sys := newOrderedSystem(t, configuration, service)
+	assert.NoError(t, sys.Start(ctx))
+
+	service.exit(errors.New("crashed"))
+	assert.Eventually(t, func() bool { starts, _ := service.counts(); return starts == 3 }, time.Second, time.Millisecond)
+
+	assert.NoError(t, sys.Stop(ctx))
+	assert.Equal(t, 0, sys.Supervisor().Attempts("service"))
+}
+
+// TestSystemImpl_Start_InvalidRestartPolicy tests that the system refuses to start with an invalid restart policy.
+func TestSystemImpl_Start_InvalidRestartPolicy(t *testing.T) {
+	configuration := &configApi.Configuration{
+		Services: []*configApi.ServiceConfiguration{{
+			ComponentConfig: configApi.ComponentConfig{ID: "service"},
+			RestartPolicy:   "sometimes",
+		}},
+	}
+	pluginManager := &mocks.MockPluginManager{}
+	pluginManager.On("Initialize", mock.Anything, mock.Anything).Return(nil)
+	sys := systemApi.NewSystem(&mocks.MockLogger{}, nil, configuration, pluginManager, component.NewComponentRegistrar(), nil)
+	assert.NoError(t, sys.Initialize(context.Background()))
+
+	err := sys.Start(context.Background())
+	assert.True(t, errors.Is(err, systemApi.ErrInvalidRestartPolicy))
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	"github.com/edward1christian/block-forge/pkg/application/common/event"
 	"github.com/edward1christian/block-forge/pkg/application/component"
 	configApi "github.com/edward1christian/block-forge/pkg/application/config"
 	"github.com/edward1christian/block-forge/pkg/application/mocks"
@@ -35,6 +36,7 @@ func TestMain(m *testing.M) {
 	ctx = context.Background()
 	logger = &mocks.MockLogger{}
 	eventBus := &mocks.MockEventBus{}
+	eventBus.On("Topics").Return(event.NewTopicRegistry())
 	registrar = &mocks.MockComponentRegistrar{}
 	serviceFactory = &mocks.MockComponentFactory{}
 	operationFactory = &mocks.MockComponentFactory{}
@@ -65,6 +67,7 @@ func TestMain(m *testing.M) {
 	sys = systemApi.NewSystem(logger, eventBus, configuration, mockPluginManager, registrar, mockMultiStore)
 
 	// Mock the behavior of the component and factory
+	mockServiceComponent.On("ID").Return("Service1_ID")
 	mockServiceComponent.On("Type").Return(component.ServiceType)
 	mockServiceComponent.On("Initialize", mock.Anything, mock.Anything).Return(nil)
 
@@ -76,6 +79,10 @@ func TestMain(m *testing.M) {
 
 	mockPluginManager.On("Initialize", ctx, mock.Anything).Return(nil)
 	mockPluginManager.On("StartPlugins", ctx).Return(nil)
+	mockPluginManager.On("StopPlugins", ctx).Return(nil)
+
+	mockMultiStore.On("SaveVersion").Return([]byte{}, int64(1), nil)
+	mockMultiStore.On("Close").Return(nil)
 
 	// Run tests
 	exitCode := m.Run()
@@ -245,7 +252,7 @@ func TestSystemImpl_ExecuteOperation_Error_ComponentNotOperation(t *testing.T) {
 
 	// Test executing an operation with component not an operation error
 	output, err := sys.ExecuteOperation(ctx, "operation_id", operationInput)
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, systemApi.ErrComponentTypeMismatch)
 	assert.Nil(t, output)
 }
 
@@ -299,7 +306,8 @@ func TestSystemImpl_StopService_Error_ComponentNotFound(t *testing.T) {
 
 	// Test stopping a service with component not found error
 	err := sys.StopService(ctx, "service_id")
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, systemApi.ErrComponentNotFound)
+	assert.Contains(t, err.Error(), "failed to stop service")
 }
 
 func TestSystemImpl_RestartService_Success(t *testing.T) {
@@ -139,16 +139,17 @@ func TestStopService_ComponentNotFoundError(t *testing.T) {
 	mockComponent := &mocks.MockSystemService{}
 	mockRegistrar := &mocks.MockComponentRegistrar{}
 	mockID := "nonExistentService"
-	expectedErr := fmt.Errorf("failed to stop build service. Service not found: %v", errors.New("component not found"))
+	expectedErr := fmt.Errorf("failed to stop service: %w", system.ErrComponentNotFound)
 
 	mockSystem.On("ComponentRegistry").Return(mockRegistrar)
-	mockRegistrar.On("GetComponent", mockID).Return(mockComponent, errors.New("component not found"))
+	mockRegistrar.On("GetComponent", mockID).Return(mockComponent, system.ErrComponentNotFound)
 
 	// Act
 	err := system.StopService(ctx, mockSystem, mockID)
 
 	// Assert
 	assert.EqualError(t, err, expectedErr.Error(), "Stopping service with component not found error should return an error")
+	assert.ErrorIs(t, err, system.ErrComponentNotFound)
 }
 
 // TestStopService_ServiceInterfaceError tests the system.StopService function for error when component does not implement SystemServiceInterface.
@@ -159,7 +160,7 @@ func TestStopService_ServiceInterfaceError(t *testing.T) {
 	mockSystem := &mocks.MockSystem{}
 	mockComponent := &mocks.MockComponent{}
 	mockRegistrar := &mocks.MockComponentRegistrar{}
-	expectedErr := errors.New("failed to stop service. Service component is not a system service")
+	expectedErr := errors.New("failed to stop service: component type mismatch: mockService is not a system.SystemServiceInterface")
 
 	mockComponent.On("ID").Return("mockService")
 	mockComponent.On("Initialize", ctx, mockSystem).Return(nil)
@@ -173,6 +174,7 @@ func TestStopService_ServiceInterfaceError(t *testing.T) {
 
 	// Assert
 	assert.EqualError(t, err, expectedErr.Error(), "Stopping service with non-system service component should return an error")
+	assert.ErrorIs(t, err, system.ErrComponentTypeMismatch)
 }
 
 // TestRegisterComponent_Success tests the RegisterComponent function for success.
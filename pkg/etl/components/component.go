@@ -1,14 +1,14 @@
 package components
 
 import (
-	"github.com/edward1christian/block-forge/pkg/application/components"
+	"github.com/edward1christian/block-forge/pkg/application/component"
 	"github.com/edward1christian/block-forge/pkg/application/system"
 	blockchain "github.com/edward1christian/block-forge/pkg/blockchain/interfaces"
 )
 
 // ETLProcessComponent is an component that belongs to an ETL process
 type ETLProcessComponent interface {
-	components.StartableInterface   // Interface for a startable component
+	component.StartableInterface    // Interface for a startable component
 	system.SystemComponentInterface // Interface for a system component
 	GetProcessID() string           // Gets the ID of the ETL process the component belongs to
 }
@@ -4,7 +4,7 @@ import (
 	"errors"
 
 	"github.com/edward1christian/block-forge/pkg/application/common/context"
-	"github.com/edward1christian/block-forge/pkg/application/components"
+	"github.com/edward1christian/block-forge/pkg/application/component"
 	"github.com/edward1christian/block-forge/pkg/application/system"
 )
 
@@ -17,7 +17,7 @@ func NewDemoOperation(id, name, description string) *DemoOperation {
 	return &DemoOperation{
 		BaseSystemOperation: system.BaseSystemOperation{
 			BaseSystemComponent: system.BaseSystemComponent{
-				BaseComponent: components.BaseComponent{
+				BaseComponent: component.BaseComponent{
 					Id:   id,
 					Nm:   name,
 					Desc: description,
@@ -29,7 +29,7 @@ func NewDemoOperation(id, name, description string) *DemoOperation {
 
 // Execute performs the operation with the given context and input parameters,
 // and returns any output or error encountered.
-func (bo *DemoOperation) Execute(ctx *context.Context, input *system.OperationInput) (*system.OperationOutput, error) {
+func (bo *DemoOperation) Execute(ctx *context.Context, input *system.SystemOperationInput) (*system.SystemOperationOutput, error) {
 	// Perform operation logic here
 	// For demonstration purposes, just return an error
 	return nil, errors.New("operation not implemented")
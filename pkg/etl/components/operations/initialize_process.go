@@ -1,11 +1,12 @@
 package operations
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/edward1christian/block-forge/pkg/application/common"
 	"github.com/edward1christian/block-forge/pkg/application/common/context"
-	"github.com/edward1christian/block-forge/pkg/application/components"
+	"github.com/edward1christian/block-forge/pkg/application/component"
 	systemApi "github.com/edward1christian/block-forge/pkg/application/system"
 	"github.com/edward1christian/block-forge/pkg/etl"
 	etlComponentsApi "github.com/edward1christian/block-forge/pkg/etl/components"
@@ -24,7 +25,7 @@ func NewInitializeETLProcessOperation(id, name, description string, idGenerator
 		idGenerator: idGenerator,
 		BaseSystemOperation: systemApi.BaseSystemOperation{
 			BaseSystemComponent: systemApi.BaseSystemComponent{
-				BaseComponent: components.BaseComponent{
+				BaseComponent: component.BaseComponent{
 					Id:   id,
 					Nm:   name,
 					Desc: description,
@@ -36,7 +37,7 @@ func NewInitializeETLProcessOperation(id, name, description string, idGenerator
 
 // Execute performs the operation with the given context and input parameters,
 // and returns any output or error encountered.
-func (ie *InitializeETLProcessOperation) Execute(ctx *context.Context, input *systemApi.OperationInput) (*systemApi.OperationOutput, error) {
+func (ie *InitializeETLProcessOperation) Execute(ctx *context.Context, input *systemApi.SystemOperationInput) (*systemApi.SystemOperationOutput, error) {
 	// Check if the processes configuration is of type []*process.ETLProcessConfig
 	processesConfig, ok := input.Data.([]*process.ETLProcessConfig)
 	if !ok {
@@ -76,27 +77,26 @@ func (ie *InitializeETLProcessOperation) InitializeProcess(ctx *context.Context,
 		Components: make(map[string]etlComponentsApi.ETLProcessComponent),
 	}
 
-	// Initialize each component defined in the process config
+	// Create the components in a scope of their own, so that processes may reuse component IDs
 	sys := ie.BaseSystemOperation.System
-	for _, compConfig := range config.Components {
-		registrar := sys.ComponentRegistry()
-
-		// Retrieve the factory for the component type from the system
-		factory, err := registrar.GetComponentFactory(compConfig.FactoryName)
-		if err != nil {
-			return nil, err
-		}
+	scope, err := sys.ComponentRegistry().CreateScope(processID)
+	if err != nil {
+		return nil, err
+	}
+	process.Scope = scope
 
-		// Create an instance of the component using the factory
-		component, err := factory.CreateComponent(compConfig)
+	// Initialize each component defined in the process config
+	for _, compConfig := range config.Components {
+		// Create an instance of the component using the factory, resolved from the scope
+		component, err := scope.CreateComponent(ctx, compConfig)
 		if err != nil {
-			return nil, err
+			return nil, ie.abortProcess(ctx, scope, err)
 		}
 
 		// Check if the component implements the ETLProcessComponent interface
 		etlComponent, ok := component.(etlComponentsApi.ETLProcessComponent)
 		if !ok {
-			return nil, etl.ErrNotProcessComponent
+			return nil, ie.abortProcess(ctx, scope, etl.ErrNotProcessComponent)
 		}
 
 		// Add the component to the ETL process
@@ -105,3 +105,11 @@ func (ie *InitializeETLProcessOperation) InitializeProcess(ctx *context.Context,
 
 	return process, nil
 }
+
+// abortProcess tears down the scope of a process that failed to initialize and returns the cause.
+func (ie *InitializeETLProcessOperation) abortProcess(ctx *context.Context, scope component.ComponentRegistrarInterface, cause error) error {
+	if err := scope.TearDown(ctx); err != nil {
+		return errors.Join(cause, err)
+	}
+	return cause
+}
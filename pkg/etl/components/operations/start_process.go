@@ -2,7 +2,7 @@ package operations
 
 import (
 	"github.com/edward1christian/block-forge/pkg/application/common/context"
-	"github.com/edward1christian/block-forge/pkg/application/components"
+	"github.com/edward1christian/block-forge/pkg/application/component"
 	"github.com/edward1christian/block-forge/pkg/application/system"
 	"github.com/edward1christian/block-forge/pkg/etl"
 	processApi "github.com/edward1christian/block-forge/pkg/etl/process"
@@ -17,7 +17,7 @@ func NewStartProcessOperation(id, name, description string) *StartProcessOperati
 	return &StartProcessOperation{
 		BaseSystemOperation: system.BaseSystemOperation{
 			BaseSystemComponent: system.BaseSystemComponent{
-				BaseComponent: components.BaseComponent{
+				BaseComponent: component.BaseComponent{
 					Id:   id,
 					Nm:   name,
 					Desc: description,
@@ -29,7 +29,7 @@ func NewStartProcessOperation(id, name, description string) *StartProcessOperati
 
 // Execute performs the operation with the given context and input parameters,
 // and returns any output or error encountered.
-func (bo *StartProcessOperation) Execute(ctx *context.Context, input *system.OperationInput) (*system.OperationOutput, error) {
+func (bo *StartProcessOperation) Execute(ctx *context.Context, input *system.SystemOperationInput) (*system.SystemOperationOutput, error) {
 	process, ok := input.Data.(*processApi.ETLProcess)
 	if !ok {
 		return nil, etl.ErrNotProcess
@@ -37,9 +37,9 @@ func (bo *StartProcessOperation) Execute(ctx *context.Context, input *system.Ope
 	// Perform operation logic here
 	// For demonstration purposes, just return an error
 	// Start each component of the ETL process
-	for _, component := range process.Components {
+	for _, processComponent := range process.Components {
 		// Check if the component is startable
-		startable, ok := component.(components.StartableInterface)
+		startable, ok := processComponent.(component.StartableInterface)
 		if !ok {
 			continue
 		}
@@ -52,5 +52,5 @@ func (bo *StartProcessOperation) Execute(ctx *context.Context, input *system.Ope
 	// Update the process status
 	process.Status = processApi.ETLProcessStatusRunning
 
-	return &system.OperationOutput{Data: process}, nil
+	return &system.SystemOperationOutput{Data: process}, nil
 }
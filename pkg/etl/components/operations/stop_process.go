@@ -2,7 +2,7 @@ package operations
 
 import (
 	"github.com/edward1christian/block-forge/pkg/application/common/context"
-	"github.com/edward1christian/block-forge/pkg/application/components"
+	"github.com/edward1christian/block-forge/pkg/application/component"
 	"github.com/edward1christian/block-forge/pkg/application/system"
 	"github.com/edward1christian/block-forge/pkg/etl"
 	processApi "github.com/edward1christian/block-forge/pkg/etl/process"
@@ -18,7 +18,7 @@ func NewStopProcessOperation(id, name, description string) *StopProcessOperation
 	return &StopProcessOperation{
 		BaseSystemOperation: system.BaseSystemOperation{
 			BaseSystemComponent: system.BaseSystemComponent{
-				BaseComponent: components.BaseComponent{
+				BaseComponent: component.BaseComponent{
 					Id:   id,
 					Nm:   name,
 					Desc: description,
@@ -29,16 +29,16 @@ func NewStopProcessOperation(id, name, description string) *StopProcessOperation
 }
 
 // Execute performs the operation to stop the ETL process.
-func (so *StopProcessOperation) Execute(ctx *context.Context, input *system.OperationInput) (*system.OperationOutput, error) {
+func (so *StopProcessOperation) Execute(ctx *context.Context, input *system.SystemOperationInput) (*system.SystemOperationOutput, error) {
 	process, ok := input.Data.(*processApi.ETLProcess)
 	if !ok {
 		return nil, etl.ErrNotProcess
 	}
 
 	// Stop each component of the ETL process
-	for _, component := range process.Components {
+	for _, processComponent := range process.Components {
 		// Check if the component is stoppable
-		stoppable, ok := component.(components.StartableInterface)
+		stoppable, ok := processComponent.(component.StartableInterface)
 		if !ok {
 			continue
 		}
@@ -51,5 +51,10 @@ func (so *StopProcessOperation) Execute(ctx *context.Context, input *system.Oper
 	// Update the process status
 	process.Status = processApi.ETLProcessStatusStopped
 
-	return &system.OperationOutput{Data: process}, nil
+	// Release the scope holding the components of the process
+	if err := process.TearDown(ctx); err != nil {
+		return nil, err
+	}
+
+	return &system.SystemOperationOutput{Data: process}, nil
 }
@@ -4,7 +4,7 @@ import (
 	"errors"
 
 	"github.com/edward1christian/block-forge/pkg/application/common/context"
-	"github.com/edward1christian/block-forge/pkg/application/components"
+	"github.com/edward1christian/block-forge/pkg/application/component"
 	"github.com/edward1christian/block-forge/pkg/application/system"
 )
 
@@ -14,15 +14,15 @@ type DemoService struct {
 }
 
 // Type returns the type of the component.
-func (bo *DemoService) Type() components.ComponentType {
-	return components.ServiceType
+func (bo *DemoService) Type() component.ComponentType {
+	return component.ServiceType
 }
 
 func NewDemoService(id, name, description string) *DemoService {
 	return &DemoService{
 		BaseSystemService: system.BaseSystemService{
 			BaseSystemComponent: system.BaseSystemComponent{
-				BaseComponent: components.BaseComponent{
+				BaseComponent: component.BaseComponent{
 					Id:   id,
 					Nm:   name,
 					Desc: description,
@@ -2,19 +2,57 @@ package services
 
 import (
 	"fmt"
+	"strings"
+	"sync"
 
 	"github.com/edward1christian/block-forge/pkg/application/common/context"
-	"github.com/edward1christian/block-forge/pkg/application/components"
+	"github.com/edward1christian/block-forge/pkg/application/component"
+	configApi "github.com/edward1christian/block-forge/pkg/application/config"
 	systemApi "github.com/edward1christian/block-forge/pkg/application/system"
 	"github.com/edward1christian/block-forge/pkg/etl"
 	"github.com/edward1christian/block-forge/pkg/etl/process"
 )
 
+// ProcessManagerDependency is the name of the process manager dependency of the ProcessManagerService.
+const ProcessManagerDependency = "processManager"
+
+// ProcessManagerServiceFactory creates ProcessManagerServices. The process manager is resolved
+// by the component registrar and injected when the service is created.
+type ProcessManagerServiceFactory struct {
+	ManagerID string // ID of the process manager component, resolved by interface if empty
+}
+
+// CreateComponent fails, as the service cannot be created without its process manager.
+func (f *ProcessManagerServiceFactory) CreateComponent(config *configApi.ComponentConfig) (component.ComponentInterface, error) {
+	return nil, fmt.Errorf("%w: %s requires %s", component.ErrMissingDependency, config.ID, ProcessManagerDependency)
+}
+
+// Dependencies returns the process manager dependency of the service.
+func (f *ProcessManagerServiceFactory) Dependencies(config *configApi.ComponentConfig) []component.Dependency {
+	return []component.Dependency{{
+		Name:        ProcessManagerDependency,
+		ComponentID: f.ManagerID,
+		Interface:   component.InterfaceOf[process.ProcessManagerInterface](),
+	}}
+}
+
+// CreateInjectedComponent creates a new instance of the ProcessManagerService with the injected process manager.
+func (f *ProcessManagerServiceFactory) CreateInjectedComponent(
+	config *configApi.ComponentConfig, dependencies component.Dependencies) (component.ComponentInterface, error) {
+	manager, err := component.DependencyAs[process.ProcessManagerInterface](dependencies, ProcessManagerDependency)
+	if err != nil {
+		return nil, err
+	}
+	return NewProcessManagerService(config.ID, config.Name, config.Description, manager), nil
+}
+
 // ProcessManagerService represents a service for managing ETL processes.
 type ProcessManagerService struct {
 	systemApi.BaseSystemService                                 // Embedding BaseComponent for component properties
 	manager                     process.ProcessManagerInterface // Process manager interface
 	system                      systemApi.SystemInterface       // System interface
+	mutex                       sync.RWMutex                    // Mutex guarding the running flag
+	running                     bool                            // Whether the service has been started
 }
 
 // NewProcessManagerService creates a new instance of ProcessManagerService.
@@ -22,7 +60,7 @@ func NewProcessManagerService(id, name, description string, manager process.Proc
 	return &ProcessManagerService{
 		BaseSystemService: systemApi.BaseSystemService{
 			BaseSystemComponent: systemApi.BaseSystemComponent{
-				BaseComponent: components.BaseComponent{
+				BaseComponent: component.BaseComponent{
 					Id:   id,
 					Nm:   name,
 					Desc: description,
@@ -59,6 +97,7 @@ func (pms *ProcessManagerService) Initialize(ctx *context.Context, system system
 // Start starts the ProcessManagerService.
 // It starts all initialized ETL processes.
 func (pms *ProcessManagerService) Start(ctx *context.Context) error {
+	pms.setRunning(true)
 	for _, etlProcess := range pms.manager.GetAllProcesses() {
 		err := pms.manager.StartProcess(ctx, etlProcess.ID)
 		if err != nil {
@@ -71,6 +110,7 @@ func (pms *ProcessManagerService) Start(ctx *context.Context) error {
 // Stop stops the ProcessManagerService.
 // It stops all running ETL processes.
 func (pms *ProcessManagerService) Stop(ctx *context.Context) error {
+	pms.setRunning(false)
 	for _, etlProcess := range pms.manager.GetAllProcesses() {
 		err := pms.manager.StopProcess(ctx, etlProcess.ID)
 		if err != nil {
@@ -80,7 +120,42 @@ func (pms *ProcessManagerService) Stop(ctx *context.Context) error {
 	return nil
 }
 
+// Health returns the current health of the ProcessManagerService.
+// The service is live once started and degraded while any ETL process has failed.
+func (pms *ProcessManagerService) Health(ctx *context.Context) component.HealthStatus {
+	pms.mutex.RLock()
+	running := pms.running
+	pms.mutex.RUnlock()
+
+	if !running {
+		return component.HealthStatus{Reason: "process manager not started"}
+	}
+
+	// Collect the processes that have failed
+	var failed []string
+	for _, etlProcess := range pms.manager.GetAllProcesses() {
+		if etlProcess.Status == process.ETLProcessStatusFailed {
+			failed = append(failed, etlProcess.ID)
+		}
+	}
+	if len(failed) > 0 {
+		return component.HealthStatus{
+			Live:   true,
+			Reason: fmt.Sprintf("failed ETL processes: %s", strings.Join(failed, ", ")),
+		}
+	}
+
+	return component.HealthStatus{Live: true, Ready: true, Reason: "process manager running"}
+}
+
+// setRunning records whether the service has been started.
+func (pms *ProcessManagerService) setRunning(running bool) {
+	pms.mutex.Lock()
+	defer pms.mutex.Unlock()
+	pms.running = running
+}
+
 // Type returns the type of the component.
-func (pms *ProcessManagerService) Type() components.ComponentType {
-	return components.ServiceType
+func (pms *ProcessManagerService) Type() component.ComponentType {
+	return component.ServiceType
 }
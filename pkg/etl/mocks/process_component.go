@@ -2,7 +2,7 @@ package mocks
 
 import (
 	"github.com/edward1christian/block-forge/pkg/application/common/context"
-	"github.com/edward1christian/block-forge/pkg/application/components"
+	"github.com/edward1christian/block-forge/pkg/application/component"
 	"github.com/edward1christian/block-forge/pkg/application/system"
 	"github.com/stretchr/testify/mock"
 )
@@ -25,9 +25,9 @@ func (m *MockETLProcessComponent) Name() string {
 }
 
 // Type mocks the Type method of ComponentInterface interface.
-func (m *MockETLProcessComponent) Type() components.ComponentType {
+func (m *MockETLProcessComponent) Type() component.ComponentType {
 	args := m.Called()
-	return args.Get(0).(components.ComponentType)
+	return args.Get(0).(component.ComponentType)
 }
 
 // Description mocks the Description method of ComponentInterface interface.
@@ -1,14 +1,14 @@
 package process
 
-import "github.com/edward1christian/block-forge/pkg/application/components"
+import configApi "github.com/edward1christian/block-forge/pkg/application/config"
 
 // ETLProcessConfig represents the configuration for an ETL process.
 type ETLProcessConfig struct {
-	Components []*components.ComponentConfig // Components configuration for the ETL process
+	Components []*configApi.ComponentConfig // Components configuration for the ETL process
 }
 
 // PipelineConfig represents the configuration for a transformation pipeline.
 type PipelineConfig struct {
-	components.ComponentConfig
-	Stages []components.ComponentConfig // Stages configuration for the pipeline
+	configApi.ComponentConfig
+	Stages []configApi.ComponentConfig // Stages configuration for the pipeline
 }
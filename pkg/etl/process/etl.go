@@ -2,6 +2,7 @@ package process
 
 import (
 	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	"github.com/edward1christian/block-forge/pkg/application/component"
 	"github.com/edward1christian/block-forge/pkg/application/system"
 	etlComponentsApi "github.com/edward1christian/block-forge/pkg/etl/components"
 )
@@ -101,4 +102,18 @@ type ETLProcess struct {
 	Config     *ETLProcessConfig                               // Configuration of the ETL process
 	Status     ETLProcessStatus                                // Status of the ETL process
 	Components map[string]etlComponentsApi.ETLProcessComponent // Map to track instantiated components by name
+	Scope      component.ComponentRegistrarInterface           // Registry scope holding the components of the process
+}
+
+// TearDown removes the components of the process along with its registry scope, once the process
+// is stopped or removed. A process without a scope has nothing to tear down.
+func (p *ETLProcess) TearDown(ctx *context.Context) error {
+	if p.Scope == nil {
+		return nil
+	}
+	if err := p.Scope.TearDown(ctx); err != nil {
+		return err
+	}
+	p.Scope = nil
+	return nil
 }
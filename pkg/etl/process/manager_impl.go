@@ -5,7 +5,7 @@ import (
 	"sync"
 
 	"github.com/edward1christian/block-forge/pkg/application/common/context"
-	"github.com/edward1christian/block-forge/pkg/application/components"
+	"github.com/edward1christian/block-forge/pkg/application/component"
 	systemApi "github.com/edward1christian/block-forge/pkg/application/system"
 	"github.com/edward1christian/block-forge/pkg/etl"
 	"github.com/edward1christian/block-forge/pkg/etl/common"
@@ -23,7 +23,7 @@ func NewETLManagerService(id, name, description string) *ProcessManager {
 	return &ProcessManager{
 		processes: make(map[string]*ETLProcess),
 		BaseSystemComponent: systemApi.BaseSystemComponent{
-			BaseComponent: components.BaseComponent{
+			BaseComponent: component.BaseComponent{
 				Id:   id,
 				Nm:   name,
 				Desc: description,
@@ -37,7 +37,7 @@ func (m *ProcessManager) InitializeProcess(ctx *context.Context, config *ETLProc
 	system := m.BaseSystemComponent.System
 
 	// Execute the operation
-	output, err := system.ExecuteOperation(ctx, common.ProcessOpInitializeETL, &systemApi.OperationInput{
+	output, err := system.ExecuteOperation(ctx, common.ProcessOpInitializeETL, &systemApi.SystemOperationInput{
 		Data: config,
 	})
 	if err != nil {
@@ -71,7 +71,7 @@ func (m *ProcessManager) StartProcess(ctx *context.Context, processID string) er
 	system := m.BaseSystemComponent.System
 
 	// Execute the operation
-	_, err := system.ExecuteOperation(ctx, common.ProcessOpStartETL, &systemApi.OperationInput{
+	_, err := system.ExecuteOperation(ctx, common.ProcessOpStartETL, &systemApi.SystemOperationInput{
 		Data: processID,
 	})
 	if err != nil {
@@ -94,7 +94,7 @@ func (m *ProcessManager) StopProcess(ctx *context.Context, processID string) err
 	system := m.BaseSystemComponent.System
 
 	// Execute the operation
-	_, err := system.ExecuteOperation(ctx, common.ProcessOpStopETL, &systemApi.OperationInput{
+	_, err := system.ExecuteOperation(ctx, common.ProcessOpStopETL, &systemApi.SystemOperationInput{
 		Data: processID,
 	})
 	if err != nil {
@@ -147,14 +147,18 @@ func (m *ProcessManager) GetAllProcesses() []*ETLProcess {
 	return processes
 }
 
-// RemoveProcess removes an ETL process with the given ID
+// RemoveProcess removes an ETL process with the given ID, tearing down the scope holding its components.
 func (m *ProcessManager) RemoveProcess(processID string) error {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
-	if _, found := m.processes[processID]; !found {
+	process, found := m.processes[processID]
+	if !found {
 		return etl.ErrNotProcessNotFound
 	}
+	if err := process.TearDown(context.Background()); err != nil {
+		return fmt.Errorf("error removing process: %v", err)
+	}
 	delete(m.processes, processID)
 	return nil
 }
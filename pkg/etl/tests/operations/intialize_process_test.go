@@ -8,7 +8,7 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"github.com/edward1christian/block-forge/pkg/application/common/context"
-	"github.com/edward1christian/block-forge/pkg/application/components"
+	configApi "github.com/edward1christian/block-forge/pkg/application/config"
 	systemApi "github.com/edward1christian/block-forge/pkg/application/system"
 	etlOpsApi "github.com/edward1christian/block-forge/pkg/etl/components/operations"
 	"github.com/edward1christian/block-forge/pkg/etl/process"
@@ -18,37 +18,37 @@ import (
 )
 
 var (
-	component1 = &components.ComponentConfig{
+	component1 = &configApi.ComponentConfig{
 		ID:           "AdapterID",
 		Name:         "Adapter",
 		Description:  "Extracts data from source",
-		FactoryName:  "AdaptorFactory",
+		FactoryID:    "AdaptorFactory",
 		CustomConfig: map[string]interface{}{"param1": "value1", "param2": 123},
 	}
 
-	component2 = &components.ComponentConfig{
+	component2 = &configApi.ComponentConfig{
 		ID:           "TransformerID",
 		Name:         "Transformer",
 		Description:  "Transforms extracted data",
-		FactoryName:  "TransformerFactory",
+		FactoryID:    "TransformerFactory",
 		CustomConfig: map[string]interface{}{"param3": "value3", "param4": 456},
 	}
 )
 
 // Demo data for ETLProcessConfig
 var config = &process.ETLProcessConfig{
-	Components: []*components.ComponentConfig{component1, component2},
+	Components: []*configApi.ComponentConfig{component1, component2},
 }
 
 // createMocks creates and returns mock objects required for testing.
 func createMocks() (
 	*mocks.MockSystem, *mocks.MockIDGenerator,
-	*mocks.MockComponentFactory, *mocks.MockComponentRegistrar,
+	*mocks.MockComponentRegistrar, *mocks.MockComponentRegistrar,
 	*etlMocksApi.MockETLProcessComponent, *etlMocksApi.MockETLProcessComponent) {
 
 	mockSystem := &mocks.MockSystem{}
 	mockIDGenerator := &mocks.MockIDGenerator{}
-	mockFactory := &mocks.MockComponentFactory{}
+	mockScope := &mocks.MockComponentRegistrar{}
 	mockRegistrar := &mocks.MockComponentRegistrar{}
 
 	adapterComponent := &etlMocksApi.MockETLProcessComponent{}
@@ -61,10 +61,9 @@ func createMocks() (
 	mockIDGenerator.On("GenerateID").Return("123456", nil)
 	mockSystem.On("ComponentRegistry").Return(mockRegistrar)
 
-	mockRegistrar.On("GetComponentFactory", "AdaptorFactory").Return(mockFactory, nil)
-	mockRegistrar.On("GetComponentFactory", "TransformerFactory").Return(mockFactory, nil)
+	mockRegistrar.On("CreateScope", "123456").Return(mockScope, nil)
 
-	return mockSystem, mockIDGenerator, mockFactory, mockRegistrar, adapterComponent, transformerComponent
+	return mockSystem, mockIDGenerator, mockScope, mockRegistrar, adapterComponent, transformerComponent
 }
 
 // TestInitializeProcessOperation_Initialize tests the Initialize method of InitializeETLProcessOperation.
@@ -88,10 +87,10 @@ func TestInitializeProcessOperation_Initialize(t *testing.T) {
 func TestInitializeProcessOperation_InitializeProcess_Success(t *testing.T) {
 	// Mocks
 	ctx := &context.Context{}
-	mockSystem, mockIDGenerator, mockFactory, mockRegistrar, adapterComponent, transformerComponent := createMocks()
+	mockSystem, mockIDGenerator, mockScope, mockRegistrar, adapterComponent, transformerComponent := createMocks()
 
-	mockFactory.On("CreateComponent", mock.Anything).Return(adapterComponent, nil)
-	mockFactory.On("CreateComponent", mock.Anything).Return(transformerComponent, nil)
+	mockScope.On("CreateComponent", ctx, mock.Anything).Return(adapterComponent, nil)
+	mockScope.On("CreateComponent", ctx, mock.Anything).Return(transformerComponent, nil)
 
 	// Create and initialize the InitializeETLProcessOperation instance
 	ie := etlOpsApi.NewInitializeETLProcessOperation(
@@ -108,22 +107,24 @@ func TestInitializeProcessOperation_InitializeProcess_Success(t *testing.T) {
 	// Assert that the ETL process is initialized correctly
 	assert.NotNil(t, etlProcess)
 	assert.Equal(t, "123456", etlProcess.ID)
+	assert.Equal(t, mockScope, etlProcess.Scope)
 
 	// Assert that mock functions were called
 	mockSystem.AssertExpectations(t)
 	mockRegistrar.AssertExpectations(t)
-	mockFactory.AssertExpectations(t)
+	mockScope.AssertExpectations(t)
 }
 
 // TestInitializeProcessOperation_InitializeETLProcess_Error tests the InitializeProcess method of InitializeETLProcessOperation with an error returned during initialization.
 func TestInitializeProcessOperation_InitializeETLProcess_Error(t *testing.T) {
 	// Mocks
 	ctx := &context.Context{}
-	mockSystem, mockIDGenerator, mockFactory, mockRegistrar, adapterComponent, transformerComponent := createMocks()
+	mockSystem, mockIDGenerator, mockScope, mockRegistrar, adapterComponent, transformerComponent := createMocks()
 
-	mockFactory.On("CreateComponent", component1).Return(adapterComponent, nil)
-	mockFactory.On("CreateComponent", component2).Return(
+	mockScope.On("CreateComponent", ctx, component1).Return(adapterComponent, nil)
+	mockScope.On("CreateComponent", ctx, component2).Return(
 		transformerComponent, errors.New("error creating component"))
+	mockScope.On("TearDown", ctx).Return(nil)
 
 	// Create InitializeETLProcessOperation instance
 	ie := etlOpsApi.NewInitializeETLProcessOperation(
@@ -141,17 +142,17 @@ func TestInitializeProcessOperation_InitializeETLProcess_Error(t *testing.T) {
 	// Assert that the ComponentRegistry and CreateComponent methods are called
 	mockSystem.AssertExpectations(t)
 	mockRegistrar.AssertExpectations(t)
-	mockFactory.AssertExpectations(t)
+	mockScope.AssertExpectations(t)
 }
 
 // TestInitializeProcessOperation_Execute tests the Execute method of InitializeETLProcessOperation.
 func TestInitializeProcessOperation_Execute_Success(t *testing.T) {
 	// Mocks
 	ctx := &context.Context{}
-	mockSystem, mockIDGenerator, mockFactory, _, adapterComponent, transformerComponent := createMocks()
+	mockSystem, mockIDGenerator, mockScope, _, adapterComponent, transformerComponent := createMocks()
 
-	mockFactory.On("CreateComponent", mock.Anything).Return(adapterComponent, nil)
-	mockFactory.On("CreateComponent", mock.Anything).Return(transformerComponent, nil)
+	mockScope.On("CreateComponent", ctx, mock.Anything).Return(adapterComponent, nil)
+	mockScope.On("CreateComponent", ctx, mock.Anything).Return(transformerComponent, nil)
 
 	// Create InitializeETLProcessOperation instance
 	ie := etlOpsApi.NewInitializeETLProcessOperation(
@@ -160,7 +161,7 @@ func TestInitializeProcessOperation_Execute_Success(t *testing.T) {
 	ie.Initialize(ctx, mockSystem)
 
 	// Call Execute method
-	output, err := ie.Execute(ctx, &systemApi.OperationInput{
+	output, err := ie.Execute(ctx, &systemApi.SystemOperationInput{
 		Data: []*process.ETLProcessConfig{config},
 	})
 
@@ -174,11 +175,12 @@ func TestInitializeProcessOperation_Execute_Success(t *testing.T) {
 func TestInitializeProcessOperation_Execute_Error(t *testing.T) {
 	// Mocks
 	ctx := &context.Context{}
-	mockSystem, mockIDGenerator, mockFactory, _, adapterComponent, transformerComponent := createMocks()
+	mockSystem, mockIDGenerator, mockScope, _, adapterComponent, transformerComponent := createMocks()
 
-	mockFactory.On("CreateComponent", component1).Return(adapterComponent, nil)
-	mockFactory.On("CreateComponent", component2).Return(transformerComponent,
+	mockScope.On("CreateComponent", ctx, component1).Return(adapterComponent, nil)
+	mockScope.On("CreateComponent", ctx, component2).Return(transformerComponent,
 		errors.New("error creating component"))
+	mockScope.On("TearDown", ctx).Return(nil)
 
 	// Simulate error during Execute
 	adapterComponent.On("Start", ctx).Return(errors.New("error starting adapter"))
@@ -190,7 +192,7 @@ func TestInitializeProcessOperation_Execute_Error(t *testing.T) {
 	ie.Initialize(ctx, mockSystem)
 
 	// Call Execute method
-	output, err := ie.Execute(ctx, &systemApi.OperationInput{
+	output, err := ie.Execute(ctx, &systemApi.SystemOperationInput{
 		Data: []*process.ETLProcessConfig{config},
 	})
 
@@ -34,7 +34,7 @@ func TestStartProcessOperation_Execute_Success(t *testing.T) {
 	}
 
 	// Call Execute method
-	output, err := startOp.Execute(ctx, &system.OperationInput{Data: process})
+	output, err := startOp.Execute(ctx, &system.SystemOperationInput{Data: process})
 
 	// Check if the Execute method returns no error
 	assert.NoError(t, err)
@@ -53,7 +53,7 @@ func TestStartProcessOperation_Execute_Error_NotProcess(t *testing.T) {
 	startOp := operations.NewStartProcessOperation("1", "TestOperation", "Test Description")
 
 	// Call Execute method with non-process input
-	output, err := startOp.Execute(ctx, &system.OperationInput{Data: "not a process"})
+	output, err := startOp.Execute(ctx, &system.SystemOperationInput{Data: "not a process"})
 
 	// Check if the Execute method returns an error
 	assert.Error(t, err)
@@ -80,7 +80,7 @@ func TestStartProcessOperation_Execute_Error_ComponentStart(t *testing.T) {
 	}
 
 	// Call Execute method
-	output, err := startOp.Execute(ctx, &system.OperationInput{Data: process})
+	output, err := startOp.Execute(ctx, &system.SystemOperationInput{Data: process})
 
 	// Check if the Execute method returns an error
 	assert.Error(t, err)
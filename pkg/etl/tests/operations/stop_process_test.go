@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	"github.com/edward1christian/block-forge/pkg/application/component"
 	"github.com/edward1christian/block-forge/pkg/application/system"
 	etlComponentsApi "github.com/edward1christian/block-forge/pkg/etl/components"
 	"github.com/edward1christian/block-forge/pkg/etl/components/operations"
@@ -34,7 +35,7 @@ func TestStopProcessOperation_Execute_Success(t *testing.T) {
 	}
 
 	// Call Execute method
-	output, err := stopOp.Execute(ctx, &system.OperationInput{Data: process})
+	output, err := stopOp.Execute(ctx, &system.SystemOperationInput{Data: process})
 
 	// Check if the Execute method returns no error
 	assert.NoError(t, err)
@@ -44,6 +45,39 @@ func TestStopProcessOperation_Execute_Success(t *testing.T) {
 	assert.Equal(t, processApi.ETLProcessStatusStopped, process.Status)
 }
 
+// TestStopProcessOperation_Execute_TearsDownScope verifies that StopProcessOperation.Execute() removes the scope of the process.
+func TestStopProcessOperation_Execute_TearsDownScope(t *testing.T) {
+	// Mocks
+	ctx := &context.Context{}
+	mockEtlComponent := &etlMocksApi.MockETLProcessComponent{}
+	mockEtlComponent.On("Stop", ctx).Return(nil)
+
+	registrar := component.NewComponentRegistrar()
+	scope, err := registrar.CreateScope("processID")
+	assert.NoError(t, err)
+
+	// Create StopProcessOperation instance
+	stopOp := operations.NewStopProcessOperation("1", "TestOperation", "Test Description")
+
+	process := &processApi.ETLProcess{
+		ID: "processID",
+		Components: map[string]etlComponentsApi.ETLProcessComponent{
+			"AdapterID": mockEtlComponent,
+		},
+		Status: processApi.ETLProcessStatusRunning,
+		Scope:  scope,
+	}
+
+	// Call Execute method
+	_, err = stopOp.Execute(ctx, &system.SystemOperationInput{Data: process})
+	assert.NoError(t, err)
+
+	// Assert that the scope is gone
+	assert.Nil(t, process.Scope)
+	_, err = registrar.GetScope("processID")
+	assert.Error(t, err)
+}
+
 // TestStopProcessOperation_Execute_Error_NotProcess verifies that StopProcessOperation.Execute() returns an error when input is not an ETLProcess.
 func TestStopProcessOperation_Execute_Error_NotProcess(t *testing.T) {
 	// Mocks
@@ -53,7 +87,7 @@ func TestStopProcessOperation_Execute_Error_NotProcess(t *testing.T) {
 	stopOp := operations.NewStopProcessOperation("1", "TestOperation", "Test Description")
 
 	// Call Execute method with non-process input
-	output, err := stopOp.Execute(ctx, &system.OperationInput{Data: "not a process"})
+	output, err := stopOp.Execute(ctx, &system.SystemOperationInput{Data: "not a process"})
 
 	// Check if the Execute method returns an error
 	assert.Error(t, err)
@@ -80,7 +114,7 @@ func TestStopProcessOperation_Execute_Error_ComponentStop(t *testing.T) {
 	}
 
 	// Call Execute method
-	output, err := stopOp.Execute(ctx, &system.OperationInput{Data: process})
+	output, err := stopOp.Execute(ctx, &system.SystemOperationInput{Data: process})
 
 	// Check if the Execute method returns an error
 	assert.Error(t, err)
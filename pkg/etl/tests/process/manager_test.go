@@ -8,7 +8,8 @@ import (
 	"github.com/stretchr/testify/mock"
 
 	"github.com/edward1christian/block-forge/pkg/application/common/context"
-	"github.com/edward1christian/block-forge/pkg/application/components"
+	"github.com/edward1christian/block-forge/pkg/application/component"
+	configApi "github.com/edward1christian/block-forge/pkg/application/config"
 	"github.com/edward1christian/block-forge/pkg/application/mocks"
 	systemApi "github.com/edward1christian/block-forge/pkg/application/system"
 	"github.com/edward1christian/block-forge/pkg/etl"
@@ -17,26 +18,26 @@ import (
 )
 
 var (
-	component1 = &components.ComponentConfig{
+	component1 = &configApi.ComponentConfig{
 		ID:           "AdapterID",
 		Name:         "Adapter",
 		Description:  "Extracts data from source",
-		FactoryName:  "AdaptorFactory",
+		FactoryID:    "AdaptorFactory",
 		CustomConfig: map[string]interface{}{"param1": "value1", "param2": 123},
 	}
 
-	component2 = &components.ComponentConfig{
+	component2 = &configApi.ComponentConfig{
 		ID:           "TransformerID",
 		Name:         "Transformer",
 		Description:  "Transforms extracted data",
-		FactoryName:  "TransformerFactory",
+		FactoryID:    "TransformerFactory",
 		CustomConfig: map[string]interface{}{"param3": "value3", "param4": 456},
 	}
 )
 
 // Demo data for ETLProcessConfig
 var config = &process.ETLProcessConfig{
-	Components: []*components.ComponentConfig{component1, component2},
+	Components: []*configApi.ComponentConfig{component1, component2},
 }
 
 func TestProcessManager_InitializeProcess_Success(t *testing.T) {
@@ -45,7 +46,7 @@ func TestProcessManager_InitializeProcess_Success(t *testing.T) {
 	expectedOutput := &process.ETLProcess{ID: "123"}
 
 	mockSystem.On("ExecuteOperation", ctx, common.ProcessOpInitializeETL, mock.Anything).
-		Return(&systemApi.OperationOutput{Data: expectedOutput}, nil)
+		Return(&systemApi.SystemOperationOutput{Data: expectedOutput}, nil)
 
 	manager := process.NewETLManagerService("TestMangerID", "TestMangerID", "TestManger ID")
 	manager.Initialize(ctx, mockSystem)
@@ -63,7 +64,7 @@ func TestProcessManager_InitializeProcess_Failure(t *testing.T) {
 	expectedOutput := &process.ETLProcess{ID: "123"}
 
 	mockSystem.On("ExecuteOperation", ctx, common.ProcessOpInitializeETL, mock.Anything).
-		Return(&systemApi.OperationOutput{Data: expectedOutput}, errors.New("error executing system operation"))
+		Return(&systemApi.SystemOperationOutput{Data: expectedOutput}, errors.New("error executing system operation"))
 
 	manager := process.NewETLManagerService("TestMangerID", "TestMangerID", "TestManger ID")
 	manager.Initialize(ctx, mockSystem)
@@ -81,10 +82,10 @@ func TestProcessManager_StartProcess_Success(t *testing.T) {
 	expectedOutput := &process.ETLProcess{ID: "123"}
 
 	mockSystem.On("ExecuteOperation", ctx, common.ProcessOpInitializeETL, mock.Anything).
-		Return(&systemApi.OperationOutput{Data: expectedOutput}, nil)
+		Return(&systemApi.SystemOperationOutput{Data: expectedOutput}, nil)
 
 	mockSystem.On("ExecuteOperation", ctx, common.ProcessOpStartETL, mock.Anything).
-		Return(&systemApi.OperationOutput{Data: expectedOutput}, nil)
+		Return(&systemApi.SystemOperationOutput{Data: expectedOutput}, nil)
 
 	manager := process.NewETLManagerService("TestMangerID", "TestMangerID", "TestManger ID")
 
@@ -105,10 +106,10 @@ func TestProcessManager_StartProcess_Failure(t *testing.T) {
 	expectedOutput := &process.ETLProcess{ID: "123"}
 
 	mockSystem.On("ExecuteOperation", ctx, common.ProcessOpInitializeETL, mock.Anything).
-		Return(&systemApi.OperationOutput{Data: expectedOutput}, nil)
+		Return(&systemApi.SystemOperationOutput{Data: expectedOutput}, nil)
 
 	mockSystem.On("ExecuteOperation", ctx, common.ProcessOpStartETL, mock.Anything).
-		Return(&systemApi.OperationOutput{Data: expectedOutput}, errors.New("error executing system operation"))
+		Return(&systemApi.SystemOperationOutput{Data: expectedOutput}, errors.New("error executing system operation"))
 
 	manager := process.NewETLManagerService("TestMangerID", "TestMangerID", "TestManger ID")
 	manager.Initialize(ctx, mockSystem)
@@ -128,13 +129,13 @@ func TestProcessManager_StopProcess_Success(t *testing.T) {
 	expectedOutput := &process.ETLProcess{ID: "123"}
 
 	mockSystem.On("ExecuteOperation", ctx, common.ProcessOpInitializeETL, mock.Anything).
-		Return(&systemApi.OperationOutput{Data: expectedOutput}, nil)
+		Return(&systemApi.SystemOperationOutput{Data: expectedOutput}, nil)
 
 	mockSystem.On("ExecuteOperation", ctx, common.ProcessOpStartETL, mock.Anything).
-		Return(&systemApi.OperationOutput{}, nil)
+		Return(&systemApi.SystemOperationOutput{}, nil)
 
 	mockSystem.On("ExecuteOperation", ctx, common.ProcessOpStopETL, mock.Anything).
-		Return(&systemApi.OperationOutput{}, nil)
+		Return(&systemApi.SystemOperationOutput{}, nil)
 
 	manager := process.NewETLManagerService("TestMangerID", "TestMangerID", "TestManger ID")
 	manager.Initialize(ctx, mockSystem)
@@ -172,7 +173,7 @@ func TestProcessManager_GetProcess_Success(t *testing.T) {
 	expectedOutput := &process.ETLProcess{ID: processID}
 
 	mockSystem.On("ExecuteOperation", ctx, common.ProcessOpInitializeETL, mock.Anything).
-		Return(&systemApi.OperationOutput{Data: expectedOutput}, nil)
+		Return(&systemApi.SystemOperationOutput{Data: expectedOutput}, nil)
 
 	manager := process.NewETLManagerService("TestMangerID", "TestMangerID", "TestManger ID")
 	manager.Initialize(ctx, mockSystem)
@@ -207,7 +208,7 @@ func TestProcessManager_GetAllProcesses(t *testing.T) {
 	expectedProcesses := []*process.ETLProcess{expectedProcess}
 
 	mockSystem.On("ExecuteOperation", ctx, common.ProcessOpInitializeETL, mock.Anything).
-		Return(&systemApi.OperationOutput{Data: expectedProcess}, nil)
+		Return(&systemApi.SystemOperationOutput{Data: expectedProcess}, nil)
 
 	manager := process.NewETLManagerService("TestMangerID", "TestMangerID", "TestManger ID")
 	manager.Initialize(ctx, mockSystem)
@@ -227,13 +228,13 @@ func TestProcessManager_RestartProcess_Success(t *testing.T) {
 	expectedOutput := &process.ETLProcess{ID: "123"}
 
 	mockSystem.On("ExecuteOperation", ctx, common.ProcessOpInitializeETL, mock.Anything).
-		Return(&systemApi.OperationOutput{Data: expectedOutput}, nil)
+		Return(&systemApi.SystemOperationOutput{Data: expectedOutput}, nil)
 
 	mockSystem.On("ExecuteOperation", ctx, common.ProcessOpStartETL, mock.Anything).
-		Return(&systemApi.OperationOutput{}, nil)
+		Return(&systemApi.SystemOperationOutput{}, nil)
 
 	mockSystem.On("ExecuteOperation", ctx, common.ProcessOpStopETL, mock.Anything).
-		Return(&systemApi.OperationOutput{}, nil)
+		Return(&systemApi.SystemOperationOutput{}, nil)
 
 	manager := process.NewETLManagerService("TestMangerID", "TestMangerID", "TestManger ID")
 	manager.Initialize(ctx, mockSystem)
@@ -270,7 +271,7 @@ func TestProcessManager_RemoveProcess_Success(t *testing.T) {
 	expectedOutput := &process.ETLProcess{ID: processID}
 
 	mockSystem.On("ExecuteOperation", ctx, common.ProcessOpInitializeETL, mock.Anything).
-		Return(&systemApi.OperationOutput{Data: expectedOutput}, nil)
+		Return(&systemApi.SystemOperationOutput{Data: expectedOutput}, nil)
 
 	manager := process.NewETLManagerService("TestMangerID", "TestMangerID", "TestManger ID")
 	manager.Initialize(ctx, mockSystem)
@@ -285,6 +286,34 @@ func TestProcessManager_RemoveProcess_Success(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestProcessManager_RemoveProcess_TearsDownScope(t *testing.T) {
+	processID := "123"
+	ctx := &context.Context{}
+	mockSystem := new(mocks.MockSystem)
+
+	registrar := component.NewComponentRegistrar()
+	scope, err := registrar.CreateScope(processID)
+	assert.NoError(t, err)
+	expectedOutput := &process.ETLProcess{ID: processID, Scope: scope}
+
+	mockSystem.On("ExecuteOperation", ctx, common.ProcessOpInitializeETL, mock.Anything).
+		Return(&systemApi.SystemOperationOutput{Data: expectedOutput}, nil)
+
+	manager := process.NewETLManagerService("TestMangerID", "TestMangerID", "TestManger ID")
+	manager.Initialize(ctx, mockSystem)
+
+	_, err = manager.InitializeProcess(ctx, config)
+	assert.NoError(t, err)
+
+	err = manager.RemoveProcess(processID)
+	assert.NoError(t, err)
+
+	_, err = registrar.GetScope(processID)
+	assert.Error(t, err)
+	_, err = manager.GetProcess(processID)
+	assert.Error(t, err)
+}
+
 func TestProcessManager_RemoveProcess_NotFound(t *testing.T) {
 	processID := "123"
 	ctx := &context.Context{}
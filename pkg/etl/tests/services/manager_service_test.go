@@ -1,12 +1,15 @@
 package services_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
 	"github.com/edward1christian/block-forge/pkg/application/common/context"
+	"github.com/edward1christian/block-forge/pkg/application/component"
+	configApi "github.com/edward1christian/block-forge/pkg/application/config"
 	"github.com/edward1christian/block-forge/pkg/application/mocks"
 	"github.com/edward1christian/block-forge/pkg/etl"
 	"github.com/edward1christian/block-forge/pkg/etl/components/services"
@@ -104,3 +107,27 @@ func TestProcessManagerService_Stop_Error(t *testing.T) {
 	assert.Error(t, err)
 	assert.EqualError(t, err, "failed to stop ETL process: etl process not found")
 }
+
+// managerFactory creates process managers.
+type managerFactory struct{}
+
+// CreateComponent creates a process manager with the configured ID.
+func (f *managerFactory) CreateComponent(config *configApi.ComponentConfig) (component.ComponentInterface, error) {
+	return process.NewETLManagerService(config.ID, config.Name, config.Description), nil
+}
+
+func TestProcessManagerServiceFactory_InjectsProcessManager(t *testing.T) {
+	ctx := context.Background()
+	registrar := component.NewComponentRegistrar()
+	assert.NoError(t, registrar.RegisterFactory(ctx, "manager", &managerFactory{}))
+	assert.NoError(t, registrar.RegisterFactory(ctx, "service", &services.ProcessManagerServiceFactory{}))
+
+	_, err := registrar.CreateComponent(ctx, &configApi.ComponentConfig{ID: "service", FactoryID: "service"})
+	assert.True(t, errors.Is(err, component.ErrMissingDependency))
+
+	_, err = registrar.CreateComponent(ctx, &configApi.ComponentConfig{ID: "manager", FactoryID: "manager"})
+	assert.NoError(t, err)
+	service, err := registrar.CreateComponent(ctx, &configApi.ComponentConfig{ID: "service", FactoryID: "service"})
+	assert.NoError(t, err)
+	assert.IsType(t, &services.ProcessManagerService{}, service)
+}
@@ -10,9 +10,9 @@ func ExecuteSystemOp(
 	ctx *context.Context,
 	sys system.SystemInterface,
 	operationID string,
-	data interface{}) (*system.OperationOutput, error) {
+	data interface{}) (*system.SystemOperationOutput, error) {
 
-	opInput := &system.OperationInput{
+	opInput := &system.SystemOperationInput{
 		Data: data,
 	}
 